@@ -0,0 +1,252 @@
+package ingressmonitor_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// manifests are the manifests which install the CRDs.
+var manifests = []string{
+	"../../docs/kube/with-rbac.yaml",
+	"../../docs/kube/conversion.yaml",
+}
+
+// schema describes the parts of an OpenAPI v3 schema which are compared with
+// the Go types.
+type schema struct {
+	Type                 string            `json:"type"`
+	Properties           map[string]schema `json:"properties"`
+	Required             []string          `json:"required"`
+	Items                *schema           `json:"items"`
+	AdditionalProperties *schema           `json:"additionalProperties"`
+
+	PreserveUnknownFields bool `json:"x-kubernetes-preserve-unknown-fields"`
+}
+
+type crd struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Schema struct {
+				OpenAPIV3Schema schema `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+var (
+	timeType         = reflect.TypeOf(metav1.Time{})
+	durationType     = reflect.TypeOf(metav1.Duration{})
+	objectMetaType   = reflect.TypeOf(metav1.ObjectMeta{})
+	rawExtensionType = reflect.TypeOf(runtime.RawExtension{})
+)
+
+// TestCRDSchemas makes sure the schemas of the CRDs in the manifests describe
+// every field of the Go types of every version, and only those fields.
+func TestCRDSchemas(t *testing.T) {
+	for _, manifest := range manifests {
+		t.Run(manifest, func(t *testing.T) {
+			testCRDSchemas(t, manifest)
+		})
+	}
+}
+
+func testCRDSchemas(t *testing.T, manifest string) {
+	raw, err := ioutil.ReadFile(manifest)
+	if err != nil {
+		t.Fatalf("Could not read the manifest: %s", err)
+	}
+
+	types := map[string]reflect.Type{
+		"v1alpha1/Provider":               reflect.TypeOf(v1alpha1.Provider{}),
+		"v1alpha1/ClusterProvider":        reflect.TypeOf(v1alpha1.ClusterProvider{}),
+		"v1alpha1/MonitorTemplate":        reflect.TypeOf(v1alpha1.MonitorTemplate{}),
+		"v1alpha1/ClusterMonitorTemplate": reflect.TypeOf(v1alpha1.ClusterMonitorTemplate{}),
+		"v1alpha1/Monitor":                reflect.TypeOf(v1alpha1.Monitor{}),
+		"v1alpha1/IngressMonitor":         reflect.TypeOf(v1alpha1.IngressMonitor{}),
+		"v1beta1/Provider":                reflect.TypeOf(v1beta1.Provider{}),
+		"v1beta1/ClusterProvider":         reflect.TypeOf(v1beta1.ClusterProvider{}),
+		"v1beta1/MonitorTemplate":         reflect.TypeOf(v1beta1.MonitorTemplate{}),
+		"v1beta1/ClusterMonitorTemplate":  reflect.TypeOf(v1beta1.ClusterMonitorTemplate{}),
+		"v1beta1/Monitor":                 reflect.TypeOf(v1beta1.Monitor{}),
+		"v1beta1/IngressMonitor":          reflect.TypeOf(v1beta1.IngressMonitor{}),
+	}
+
+	for _, doc := range bytes.Split(raw, []byte("\n---\n")) {
+		var def crd
+		if err := yaml.Unmarshal(doc, &def); err != nil {
+			t.Fatalf("Could not decode the manifest: %s", err)
+		}
+
+		if def.Kind != "CustomResourceDefinition" || def.Spec.Group != v1alpha1.GroupName {
+			continue
+		}
+
+		for _, version := range def.Spec.Versions {
+			name := version.Name + "/" + def.Spec.Names.Kind
+
+			typ, ok := types[name]
+			if !ok {
+				t.Errorf("Unknown version %s in the manifest", name)
+				continue
+			}
+			delete(types, name)
+
+			t.Run(name, func(t *testing.T) {
+				compareSchema(t, def.Spec.Names.Kind, typ, version.Schema.OpenAPIV3Schema, true)
+			})
+		}
+	}
+
+	for kind := range types {
+		t.Errorf("Expected a CRD version for %s in the manifest", kind)
+	}
+}
+
+func compareSchema(t *testing.T, path string, typ reflect.Type, s schema, root bool) {
+	t.Helper()
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	expected := schemaType(typ)
+	if s.Type != expected {
+		t.Errorf("Expected %s to be of type `%s`, got `%s`", path, expected, s.Type)
+		return
+	}
+
+	switch {
+	case typ == timeType || typ == durationType || typ == objectMetaType:
+	case typ == rawExtensionType:
+		if !s.PreserveUnknownFields {
+			t.Errorf("Expected %s to preserve unknown fields", path)
+		}
+	case typ.Kind() == reflect.Struct:
+		fields := map[string]reflect.Type{}
+		var required []string
+		collectFields(typ, fields, &required)
+
+		for name, fieldType := range fields {
+			prop, ok := s.Properties[name]
+			if !ok {
+				t.Errorf("Expected %s to have a schema for `%s`", path, name)
+				continue
+			}
+
+			compareSchema(t, path+"."+name, fieldType, prop, false)
+		}
+
+		for name := range s.Properties {
+			if _, ok := fields[name]; !ok {
+				t.Errorf("Schema of %s describes `%s`, which isn't a field", path, name)
+			}
+		}
+
+		// Objects are submitted without a status and the metadata is
+		// validated by the API server.
+		if root {
+			required = remove(required, "metadata", "status")
+		}
+
+		actual := append([]string{}, s.Required...)
+		sort.Strings(required)
+		sort.Strings(actual)
+		if !reflect.DeepEqual(required, actual) && (len(required) > 0 || len(actual) > 0) {
+			t.Errorf("Expected %s to require %v, got %v", path, required, actual)
+		}
+	case typ.Kind() == reflect.Slice:
+		if s.Items == nil {
+			t.Errorf("Expected %s to describe its items", path)
+			return
+		}
+
+		compareSchema(t, path+"[]", typ.Elem(), *s.Items, false)
+	case typ.Kind() == reflect.Map:
+		if s.AdditionalProperties == nil {
+			t.Errorf("Expected %s to describe its values", path)
+			return
+		}
+
+		compareSchema(t, path+"{}", typ.Elem(), *s.AdditionalProperties, false)
+	}
+}
+
+// collectFields collects the JSON fields of a struct, including the fields of
+// inlined structs. Fields which aren't omitted when empty are required.
+func collectFields(typ reflect.Type, fields map[string]reflect.Type, required *[]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag[0] == "" {
+			collectFields(field.Type, fields, required)
+			continue
+		}
+
+		fields[tag[0]] = field.Type
+
+		omitempty := false
+		for _, opt := range tag[1:] {
+			omitempty = omitempty || opt == "omitempty"
+		}
+		if !omitempty {
+			*required = append(*required, tag[0])
+		}
+	}
+}
+
+func schemaType(typ reflect.Type) string {
+	if typ == timeType || typ == durationType {
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Slice:
+		return "array"
+	}
+
+	return "object"
+}
+
+func remove(list []string, values ...string) []string {
+	var out []string
+	for _, item := range list {
+		keep := true
+		for _, v := range values {
+			keep = keep && item != v
+		}
+
+		if keep {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,51 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ClusterProviderSpec is the detailed configuration for a ClusterProvider.
+type ClusterProviderSpec struct {
+	ProviderSpec `json:",inline"`
+
+	// AllowedNamespaces limits the namespaces whose Monitors can use the
+	// ClusterProvider. When it isn't set, Monitors in all namespaces can use
+	// it.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// AllowedNamespaces describes a set of namespaces. A namespace is part of the
+// set when it's listed in Names or when it matches the Selector.
+type AllowedNamespaces struct {
+	// Names is a list of namespaces by their name.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// Selector selects namespaces by their labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+
+// ClusterProvider is a cluster scoped Provider which can be used by Monitors
+// in every namespace it allows. The secrets it references are looked up in the
+// namespace the Operator is configured with for cluster resources.
+type ClusterProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ClusterProviderSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterProviderList is a list of ClusterProviders.
+type ClusterProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterProvider `json:"items"`
+}
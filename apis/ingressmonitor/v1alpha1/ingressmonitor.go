@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,17 +24,89 @@ type IngressMonitorStatus struct {
 
 	// IngressName is the name of the Ingress this IngressMonitor is linked to.
 	IngressName string `json:"ingressName"`
+
+	// ProviderURL is the URL where the monitor can be inspected with the
+	// provider. This is only set when the provider supports it.
+	ProviderURL string `json:"providerURL,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the IngressMonitor
+	// which has been successfully synced with the provider.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the last time a successful sync with the provider
+	// changed the status of the IngressMonitor. Periodic resyncs which don't
+	// change anything don't update it.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastError is the error which occurred during the last sync with the
+	// provider. This is empty when the last sync was successful.
+	LastError string `json:"lastError,omitempty"`
+
+	// Conditions describe the current state of the IngressMonitor.
+	Conditions []IngressMonitorCondition `json:"conditions,omitempty"`
+}
+
+// IngressMonitorConditionType is the type of condition which is set on an
+// IngressMonitor.
+type IngressMonitorConditionType string
+
+const (
+	// IngressMonitorReady indicates that the monitor is configured with the
+	// provider and is up to date with the latest spec.
+	IngressMonitorReady IngressMonitorConditionType = "Ready"
+
+	// IngressMonitorProviderSynced indicates whether or not the last sync with
+	// the provider succeeded.
+	IngressMonitorProviderSynced IngressMonitorConditionType = "ProviderSynced"
+
+	// IngressMonitorDegraded indicates that a monitor is configured with the
+	// provider, but it couldn't be updated to reflect the latest spec.
+	IngressMonitorDegraded IngressMonitorConditionType = "Degraded"
+)
+
+// IngressMonitorCondition describes the state of an IngressMonitor at a
+// certain point.
+type IngressMonitorCondition struct {
+	// Type of the condition.
+	Type IngressMonitorConditionType `json:"type"`
+
+	// Status of the condition, one of True, False or Unknown.
+	Status v1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one
+	// status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a unique, one-word, CamelCase reason for the condition's last
+	// transition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message indicating details about the
+	// transition.
+	Message string `json:"message,omitempty"`
 }
 
 // NamespacedProvider contains all the details about a provider, including the
 // namespace where the provider lives. This namespace will be used to fetch
+// the Secrets referenced by the provider.
 type NamespacedProvider struct {
-	Namespace    string `json:"namespace"`
+	// Namespace is the namespace the Provider lives in, Secrets referenced by
+	// the Provider are fetched from this namespace.
+	Namespace string `json:"namespace"`
+
 	ProviderSpec `json:",inline"`
 }
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type="string",description="The provider this test is registered with",JSONPath=".spec.provider.type"
+// +kubebuilder:printcolumn:name="Check",type="string",description="The type of check",JSONPath=".spec.template.type"
+// +kubebuilder:printcolumn:name="URL",type="string",description="The fully qualified URL to test",JSONPath=".spec.template.http.url"
+// +kubebuilder:printcolumn:name="Provider ID",type="string",description="ID Used with the Provider",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="Ready",type="string",description="Whether or not the test is configured with the Provider",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Ingress",type="string",description="The name of the Ingress this is linked to",JSONPath=".status.ingressName",priority=1
 
 // IngressMonitor is the detailed implementation of a Monitor which relates to
 // a HTTP check. It's a fully qualified configuration which doesn't need to
@@ -43,7 +116,9 @@ type IngressMonitor struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata"`
 
-	Spec   IngressMonitorSpec   `json:"spec"`
+	Spec IngressMonitorSpec `json:"spec"`
+
+	// +optional
 	Status IngressMonitorStatus `json:"status"`
 }
 
@@ -2,9 +2,20 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+// These are the types of checks which can be configured with a
+// MonitorTemplate.
+const (
+	// CheckTypeHTTP performs a HTTP request against the monitored URL.
+	CheckTypeHTTP = "HTTP"
+
+	// CheckTypeTCP opens a TCP connection to the monitored host and port.
+	CheckTypeTCP = "TCP"
+)
+
 // MonitorTemplateSpec is the concrete configuration for a Monitor Check.
 type MonitorTemplateSpec struct {
 	// Type describes the type of check we want to use.
+	// +kubebuilder:validation:Enum=HTTP;TCP
 	Type string `json:"type"`
 
 	// Name is the template that will be used to set the name of the check. If
@@ -14,21 +25,40 @@ type MonitorTemplateSpec struct {
 	// CheckRate describes the number of seconds between checks. This defaults
 	// to the provider's default.
 	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	CheckRate *string `json:"checkRate,omitempty"`
 
 	// Confirmations describes the amount of fails should occur before a check
 	// is marked as a failure. This defaults to the provider's default.
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	Confirmations *int `json:"confirmations,omitempty"`
 
 	// Timeout describes the duration of how long a check should wait before
 	// marking itself as unhealthy. Defaults to the provider's default.
 	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	Timeout *string `json:"timeout,omitempty"`
 
 	// HTTP is the template for a HTTP Check. This is required when the type is
 	// set to `HTTP`.
 	HTTP *HTTPTemplate `json:"http,omitempty"`
+
+	// TCP is the template for a TCP Check. This is populated by the Operator
+	// when the type is set to `TCP`.
+	// +optional
+	TCP *TCPTemplate `json:"tcp,omitempty"`
+}
+
+// TCPTemplate describes the configuration options for a TCP Check.
+type TCPTemplate struct {
+	// Host is the hostname or IP address the check connects to.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port is the port the check connects to.
+	// +optional
+	Port int32 `json:"port,omitempty"`
 }
 
 // HTTPTemplate describes the configuration options for a HTTP Check.
@@ -74,6 +104,7 @@ type HTTPTemplate struct {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
 
 // MonitorTemplate is the CRD specification for a MonitorTemplate. This
 // MonitorTemplate allows you to configure monitors for the resources selected
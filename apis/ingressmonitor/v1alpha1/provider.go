@@ -8,11 +8,33 @@ import (
 // ProviderSpec is the detailed configuration for a Provider.
 type ProviderSpec struct {
 	// Type describes the type of Provider which this CRD will configure.
+	// +kubebuilder:validation:Enum=StatusCake;Logger;Pingdom;UptimeRobot;Blackbox;Datadog;Webhook
 	Type string `json:"type"`
 
 	// StatusCake describes the StatusCake Monitoring Provider
 	// +optional
 	StatusCake *StatusCakeProvider `json:"statusCake,omitempty"`
+
+	// Pingdom describes the Pingdom Monitoring Provider
+	// +optional
+	Pingdom *PingdomProvider `json:"pingdom,omitempty"`
+
+	// UptimeRobot describes the UptimeRobot Monitoring Provider
+	// +optional
+	UptimeRobot *UptimeRobotProvider `json:"uptimeRobot,omitempty"`
+
+	// Blackbox describes the Prometheus Blackbox Exporter Monitoring Provider
+	// +optional
+	Blackbox *BlackboxProvider `json:"blackbox,omitempty"`
+
+	// Datadog describes the Datadog Synthetics Monitoring Provider
+	// +optional
+	Datadog *DatadogProvider `json:"datadog,omitempty"`
+
+	// Webhook describes a Monitoring Provider which implements the webhook
+	// contract
+	// +optional
+	Webhook *WebhookProvider `json:"webhook,omitempty"`
 }
 
 // StatusCakeProvider describes the configuration options for the StatusCake
@@ -30,6 +52,130 @@ type StatusCakeProvider struct {
 	ContactGroups []string `json:"contactGroups,omitempty"`
 }
 
+// PingdomProvider describes the configuration options for the Pingdom
+// provider.
+type PingdomProvider struct {
+	// APIToken is the API token used to connect to Pingdom.
+	APIToken SecretVar `json:"apiToken"`
+
+	// Optional: IntegrationIDs is a list of IDs of the integrations which
+	// should be notified when a check fails.
+	// +optional
+	IntegrationIDs []int `json:"integrationIDs,omitempty"`
+
+	// Optional: UserIDs is a list of IDs of the users which should be alerted
+	// when a check fails.
+	// +optional
+	UserIDs []int `json:"userIDs,omitempty"`
+
+	// Optional: Tags is a list of tags which are added to the checks.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// UptimeRobotProvider describes the configuration options for the UptimeRobot
+// provider.
+type UptimeRobotProvider struct {
+	// APIKey is the API Key used to connect to UptimeRobot.
+	APIKey SecretVar `json:"apiKey"`
+
+	// Optional: AlertContacts is a list of IDs of the alert contacts which
+	// should be notified when a monitor goes down.
+	// +optional
+	AlertContacts []string `json:"alertContacts,omitempty"`
+}
+
+// These are the ways the Blackbox provider renders its probe targets.
+const (
+	// BlackboxOutputConfigMap writes the targets as file_sd targets to the
+	// ConfigMap of the provider.
+	BlackboxOutputConfigMap = "ConfigMap"
+
+	// BlackboxOutputProbe sets up a Prometheus Operator Probe for every
+	// target.
+	BlackboxOutputProbe = "Probe"
+)
+
+// BlackboxProvider describes the configuration options for the Prometheus
+// Blackbox Exporter provider.
+type BlackboxProvider struct {
+	// Optional: Output describes how the probe targets are rendered. This is
+	// either `ConfigMap`, for file_sd targets in the ConfigMap, or `Probe`,
+	// for Prometheus Operator Probes. Defaults to `ConfigMap`.
+	// +kubebuilder:validation:Enum=ConfigMap;Probe
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// Optional: ConfigMap is the name of the ConfigMap the blackbox modules
+	// and file_sd targets are written to. Defaults to
+	// `ingress-monitor-blackbox`.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Optional: ProberURL is the address of the blackbox exporter the probes
+	// are sent to, for example `blackbox-exporter.monitoring:9115`. This is
+	// required when the output is set to `Probe`.
+	// +optional
+	ProberURL string `json:"proberURL,omitempty"`
+
+	// Optional: Labels are added to the probe targets and the Probes, so
+	// Prometheus can select them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// DatadogProvider describes the configuration options for the Datadog
+// Synthetics provider.
+type DatadogProvider struct {
+	// APIKey is the API Key used to connect to Datadog.
+	APIKey SecretVar `json:"apiKey"`
+
+	// AppKey is the application key used to connect to Datadog.
+	AppKey SecretVar `json:"appKey"`
+
+	// Optional: Site is the Datadog site the account lives on. Defaults to
+	// `datadoghq.com`.
+	// +optional
+	Site string `json:"site,omitempty"`
+
+	// Optional: Locations is a list of the locations the tests run from.
+	// Defaults to `aws:us-east-1`.
+	// +optional
+	Locations []string `json:"locations,omitempty"`
+
+	// Optional: Tags is a list of tags which are added to the tests.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Optional: Notify is a list of `@`-handles which are notified when a
+	// test fails, for example `@slack-ops`.
+	// +optional
+	Notify []string `json:"notify,omitempty"`
+}
+
+// WebhookProvider describes the configuration options for the Webhook
+// provider.
+type WebhookProvider struct {
+	// URL is the base URL of the monitoring service. Checks are managed
+	// through the `monitors` endpoints relative to this URL.
+	URL string `json:"url"`
+
+	// AuthHeader is the value of the authentication header which is sent with
+	// every request.
+	AuthHeader SecretVar `json:"authHeader"`
+
+	// Optional: AuthHeaderName is the name of the authentication header.
+	// Defaults to `Authorization`.
+	// +optional
+	AuthHeaderName string `json:"authHeaderName,omitempty"`
+
+	// Optional: CA is the PEM encoded CA bundle which is used to verify the
+	// certificate of the monitoring service. Defaults to the CAs of the
+	// system.
+	// +optional
+	CA *SecretVar `json:"ca,omitempty"`
+}
+
 // SecretVar describes a secret var option which can be used to either provide
 // a plaintext value or a secret value.
 type SecretVar struct {
@@ -44,6 +190,7 @@ type SecretVar struct {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
 
 // Provider is the CRD specification for an Provider. This
 // Provider allows you to configure providers which will be used to set
@@ -39,6 +39,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&MonitorTemplateList{},
 		&Provider{},
 		&ProviderList{},
+		&ClusterProvider{},
+		&ClusterProviderList{},
+		&ClusterMonitorTemplate{},
+		&ClusterMonitorTemplateList{},
 		&IngressMonitor{},
 		&IngressMonitorList{},
 	)
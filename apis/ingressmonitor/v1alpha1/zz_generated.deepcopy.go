@@ -32,6 +32,230 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxProvider) DeepCopyInto(out *BlackboxProvider) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxProvider.
+func (in *BlackboxProvider) DeepCopy() *BlackboxProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMonitorTemplate) DeepCopyInto(out *ClusterMonitorTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorTemplate.
+func (in *ClusterMonitorTemplate) DeepCopy() *ClusterMonitorTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMonitorTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMonitorTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMonitorTemplateList) DeepCopyInto(out *ClusterMonitorTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterMonitorTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorTemplateList.
+func (in *ClusterMonitorTemplateList) DeepCopy() *ClusterMonitorTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMonitorTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMonitorTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvider) DeepCopyInto(out *ClusterProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvider.
+func (in *ClusterProvider) DeepCopy() *ClusterProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProviderList) DeepCopyInto(out *ClusterProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProviderList.
+func (in *ClusterProviderList) DeepCopy() *ClusterProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProviderSpec) DeepCopyInto(out *ClusterProviderSpec) {
+	*out = *in
+	in.ProviderSpec.DeepCopyInto(&out.ProviderSpec)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProviderSpec.
+func (in *ClusterProviderSpec) DeepCopy() *ClusterProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogProvider) DeepCopyInto(out *DatadogProvider) {
+	*out = *in
+	in.APIKey.DeepCopyInto(&out.APIKey)
+	in.AppKey.DeepCopyInto(&out.AppKey)
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogProvider.
+func (in *DatadogProvider) DeepCopy() *DatadogProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPTemplate) DeepCopyInto(out *HTTPTemplate) {
 	*out = *in
@@ -59,7 +283,7 @@ func (in *IngressMonitor) DeepCopyInto(out *IngressMonitor) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -81,11 +305,28 @@ func (in *IngressMonitor) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressMonitorCondition) DeepCopyInto(out *IngressMonitorCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressMonitorCondition.
+func (in *IngressMonitorCondition) DeepCopy() *IngressMonitorCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressMonitorCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressMonitorList) DeepCopyInto(out *IngressMonitorList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]IngressMonitor, len(*in))
@@ -135,6 +376,17 @@ func (in *IngressMonitorSpec) DeepCopy() *IngressMonitorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressMonitorStatus) DeepCopyInto(out *IngressMonitorStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IngressMonitorCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -154,6 +406,7 @@ func (in *Monitor) DeepCopyInto(out *Monitor) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -175,11 +428,28 @@ func (in *Monitor) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorCondition) DeepCopyInto(out *MonitorCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorCondition.
+func (in *MonitorCondition) DeepCopy() *MonitorCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorList) DeepCopyInto(out *MonitorList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]Monitor, len(*in))
@@ -216,7 +486,21 @@ func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Provider = in.Provider
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceSource)
+		**out = **in
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ProviderReference)
+		**out = **in
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderReference, len(*in))
+		copy(*out, *in)
+	}
 	out.Template = in.Template
 	return
 }
@@ -231,6 +515,34 @@ func (in *MonitorSpec) DeepCopy() *MonitorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorStatus) DeepCopyInto(out *MonitorStatus) {
+	*out = *in
+	if in.IngressMonitors != nil {
+		in, out := &in.IngressMonitors, &out.IngressMonitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MonitorCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
+func (in *MonitorStatus) DeepCopy() *MonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorTemplate) DeepCopyInto(out *MonitorTemplate) {
 	*out = *in
@@ -262,7 +574,7 @@ func (in *MonitorTemplate) DeepCopyObject() runtime.Object {
 func (in *MonitorTemplateList) DeepCopyInto(out *MonitorTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]MonitorTemplate, len(*in))
@@ -314,6 +626,11 @@ func (in *MonitorTemplateSpec) DeepCopyInto(out *MonitorTemplateSpec) {
 		*out = new(HTTPTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPTemplate)
+		**out = **in
+	}
 	return
 }
 
@@ -344,6 +661,38 @@ func (in *NamespacedProvider) DeepCopy() *NamespacedProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomProvider) DeepCopyInto(out *PingdomProvider) {
+	*out = *in
+	in.APIToken.DeepCopyInto(&out.APIToken)
+	if in.IntegrationIDs != nil {
+		in, out := &in.IntegrationIDs, &out.IntegrationIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserIDs != nil {
+		in, out := &in.UserIDs, &out.UserIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomProvider.
+func (in *PingdomProvider) DeepCopy() *PingdomProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
@@ -375,7 +724,7 @@ func (in *Provider) DeepCopyObject() runtime.Object {
 func (in *ProviderList) DeepCopyInto(out *ProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]Provider, len(*in))
@@ -404,6 +753,22 @@ func (in *ProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderReference) DeepCopyInto(out *ProviderReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderReference.
+func (in *ProviderReference) DeepCopy() *ProviderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
@@ -412,6 +777,31 @@ func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 		*out = new(StatusCakeProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Pingdom != nil {
+		in, out := &in.Pingdom, &out.Pingdom
+		*out = new(PingdomProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UptimeRobot != nil {
+		in, out := &in.UptimeRobot, &out.UptimeRobot
+		*out = new(UptimeRobotProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Blackbox != nil {
+		in, out := &in.Blackbox, &out.Blackbox
+		*out = new(BlackboxProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Datadog != nil {
+		in, out := &in.Datadog, &out.Datadog
+		*out = new(DatadogProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookProvider)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -451,6 +841,22 @@ func (in *SecretVar) DeepCopy() *SecretVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSource) DeepCopyInto(out *ServiceSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSource.
+func (in *ServiceSource) DeepCopy() *ServiceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusCakeProvider) DeepCopyInto(out *StatusCakeProvider) {
 	*out = *in
@@ -473,3 +879,79 @@ func (in *StatusCakeProvider) DeepCopy() *StatusCakeProvider {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPTemplate) DeepCopyInto(out *TCPTemplate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPTemplate.
+func (in *TCPTemplate) DeepCopy() *TCPTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UptimeRobotProvider) DeepCopyInto(out *UptimeRobotProvider) {
+	*out = *in
+	in.APIKey.DeepCopyInto(&out.APIKey)
+	if in.AlertContacts != nil {
+		in, out := &in.AlertContacts, &out.AlertContacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UptimeRobotProvider.
+func (in *UptimeRobotProvider) DeepCopy() *UptimeRobotProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(UptimeRobotProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
+	*out = *in
+	in.AuthHeader.DeepCopyInto(&out.AuthHeader)
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(SecretVar)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookProvider.
+func (in *WebhookProvider) DeepCopy() *WebhookProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookProvider)
+	in.DeepCopyInto(out)
+	return out
+}
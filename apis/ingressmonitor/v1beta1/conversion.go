@@ -0,0 +1,562 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// v1alpha1 is the version objects are stored as. Every v1beta1 type can be
+// converted from and to its v1alpha1 counterpart:
+//
+// - durations are stored as strings in v1alpha1, they have to be valid Go
+//   durations to be converted and are formatted like `5m0s`;
+// - the headers are stored as `Name: value` lines in the v1alpha1
+//   `customHeader`, sorted by name;
+// - the Config of a Provider is stored in the v1alpha1 field named after the
+//   Type, `statusCake` for `StatusCake`.
+//
+// v1alpha1 values which can't be represented in v1beta1, like malformed
+// durations or the configuration of a provider other than the Type, are left
+// out of the v1beta1 object and kept in the ConversionAnnotation. They're
+// restored when the object is converted back, unless the v1beta1 object sets
+// a value in their place. Durations and headers which are formatted
+// differently, like `5m` or headers which aren't sorted, are kept as well and
+// restored as long as the v1beta1 value doesn't change.
+
+// ConversionAnnotation is the annotation on v1beta1 objects which holds the
+// v1alpha1 values that can't be represented in v1beta1.
+const ConversionAnnotation = GroupName + "/v1alpha1-fields"
+
+// conversionData holds the v1alpha1 values which can't be represented in
+// v1beta1.
+type conversionData struct {
+	// CheckRate and Timeout are durations which can't be parsed, or which
+	// are formatted differently than v1beta1 formats them.
+	CheckRate *string `json:"checkRate,omitempty"`
+	Timeout   *string `json:"timeout,omitempty"`
+
+	// Confirmations is out of the range of an int32.
+	Confirmations *int `json:"confirmations,omitempty"`
+
+	// CustomHeader contains lines which can't be parsed as headers, or
+	// headers which are formatted differently than v1beta1 formats them,
+	// like duplicate or padded headers.
+	CustomHeader *string `json:"customHeader,omitempty"`
+
+	// ProviderConfigs are the configurations of the providers other than the
+	// Type, keyed by their v1alpha1 field.
+	ProviderConfigs map[string]json.RawMessage `json:"providerConfigs,omitempty"`
+}
+
+// ConvertTo converts the Provider to its v1alpha1 counterpart.
+func (in *Provider) ConvertTo(out *v1alpha1.Provider) error {
+	data, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	return convertProviderSpecTo(&in.Spec, &out.Spec, data)
+}
+
+// ConvertFrom converts the v1alpha1 Provider to a v1beta1 Provider.
+func (in *Provider) ConvertFrom(prov *v1alpha1.Provider) error {
+	data := &conversionData{}
+	if err := convertProviderSpecFrom(&prov.Spec, &in.Spec, data); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&prov.ObjectMeta, &in.ObjectMeta, data)
+}
+
+// ConvertTo converts the ClusterProvider to its v1alpha1 counterpart.
+func (in *ClusterProvider) ConvertTo(out *v1alpha1.ClusterProvider) error {
+	data, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	if err := convertProviderSpecTo(&in.Spec.ProviderSpec, &out.Spec.ProviderSpec, data); err != nil {
+		return err
+	}
+
+	return convertShared(&in.Spec.AllowedNamespaces, &out.Spec.AllowedNamespaces)
+}
+
+// ConvertFrom converts the v1alpha1 ClusterProvider to a v1beta1
+// ClusterProvider.
+func (in *ClusterProvider) ConvertFrom(prov *v1alpha1.ClusterProvider) error {
+	data := &conversionData{}
+	if err := convertProviderSpecFrom(&prov.Spec.ProviderSpec, &in.Spec.ProviderSpec, data); err != nil {
+		return err
+	}
+
+	if err := convertShared(&prov.Spec.AllowedNamespaces, &in.Spec.AllowedNamespaces); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&prov.ObjectMeta, &in.ObjectMeta, data)
+}
+
+// ConvertTo converts the MonitorTemplate to its v1alpha1 counterpart.
+func (in *MonitorTemplate) ConvertTo(out *v1alpha1.MonitorTemplate) error {
+	data, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	return convertTemplateSpecTo(&in.Spec, &out.Spec, data)
+}
+
+// ConvertFrom converts the v1alpha1 MonitorTemplate to a v1beta1
+// MonitorTemplate.
+func (in *MonitorTemplate) ConvertFrom(tmpl *v1alpha1.MonitorTemplate) error {
+	data := &conversionData{}
+	if err := convertTemplateSpecFrom(&tmpl.Spec, &in.Spec, data); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&tmpl.ObjectMeta, &in.ObjectMeta, data)
+}
+
+// ConvertTo converts the ClusterMonitorTemplate to its v1alpha1 counterpart.
+func (in *ClusterMonitorTemplate) ConvertTo(out *v1alpha1.ClusterMonitorTemplate) error {
+	data, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	return convertTemplateSpecTo(&in.Spec, &out.Spec, data)
+}
+
+// ConvertFrom converts the v1alpha1 ClusterMonitorTemplate to a v1beta1
+// ClusterMonitorTemplate.
+func (in *ClusterMonitorTemplate) ConvertFrom(tmpl *v1alpha1.ClusterMonitorTemplate) error {
+	data := &conversionData{}
+	if err := convertTemplateSpecFrom(&tmpl.Spec, &in.Spec, data); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&tmpl.ObjectMeta, &in.ObjectMeta, data)
+}
+
+// ConvertTo converts the Monitor to its v1alpha1 counterpart. Both versions
+// share the same shape.
+func (in *Monitor) ConvertTo(out *v1alpha1.Monitor) error {
+	if _, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta); err != nil {
+		return err
+	}
+
+	return convertShared(&in.Spec, &out.Spec, &in.Status, &out.Status)
+}
+
+// ConvertFrom converts the v1alpha1 Monitor to a v1beta1 Monitor.
+func (in *Monitor) ConvertFrom(mon *v1alpha1.Monitor) error {
+	if err := convertShared(&mon.Spec, &in.Spec, &mon.Status, &in.Status); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&mon.ObjectMeta, &in.ObjectMeta, &conversionData{})
+}
+
+// ConvertTo converts the IngressMonitor to its v1alpha1 counterpart.
+func (in *IngressMonitor) ConvertTo(out *v1alpha1.IngressMonitor) error {
+	data, err := convertMetaTo(&in.ObjectMeta, &out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	out.Spec.Provider.Namespace = in.Spec.Provider.Namespace
+
+	if err := convertProviderSpecTo(&in.Spec.Provider.ProviderSpec, &out.Spec.Provider.ProviderSpec, data); err != nil {
+		return err
+	}
+
+	if err := convertTemplateSpecTo(&in.Spec.Template, &out.Spec.Template, data); err != nil {
+		return err
+	}
+
+	return convertShared(&in.Status, &out.Status)
+}
+
+// ConvertFrom converts the v1alpha1 IngressMonitor to a v1beta1
+// IngressMonitor.
+func (in *IngressMonitor) ConvertFrom(im *v1alpha1.IngressMonitor) error {
+	data := &conversionData{}
+	in.Spec.Provider.Namespace = im.Spec.Provider.Namespace
+
+	if err := convertProviderSpecFrom(&im.Spec.Provider.ProviderSpec, &in.Spec.Provider.ProviderSpec, data); err != nil {
+		return err
+	}
+
+	if err := convertTemplateSpecFrom(&im.Spec.Template, &in.Spec.Template, data); err != nil {
+		return err
+	}
+
+	if err := convertShared(&im.Status, &in.Status); err != nil {
+		return err
+	}
+
+	return convertMetaFrom(&im.ObjectMeta, &in.ObjectMeta, data)
+}
+
+// convertMetaTo copies the metadata of a v1beta1 object to its v1alpha1
+// counterpart and returns the values kept in the ConversionAnnotation, which
+// isn't copied.
+func convertMetaTo(in, out *metav1.ObjectMeta) (*conversionData, error) {
+	in.DeepCopyInto(out)
+
+	data := &conversionData{}
+	raw, ok := out.Annotations[ConversionAnnotation]
+	if !ok {
+		return data, nil
+	}
+
+	delete(out.Annotations, ConversionAnnotation)
+	if len(out.Annotations) == 0 {
+		out.Annotations = nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), data); err != nil {
+		return nil, fmt.Errorf("Could not decode the %s annotation: %s", ConversionAnnotation, err)
+	}
+
+	return data, nil
+}
+
+// convertMetaFrom copies the metadata of a v1alpha1 object to its v1beta1
+// counterpart and keeps the values which can't be represented in v1beta1 in
+// the ConversionAnnotation.
+func convertMetaFrom(in, out *metav1.ObjectMeta, data *conversionData) error {
+	in.DeepCopyInto(out)
+	delete(out.Annotations, ConversionAnnotation)
+
+	if reflect.DeepEqual(*data, conversionData{}) {
+		return nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[ConversionAnnotation] = string(raw)
+
+	return nil
+}
+
+// convertShared converts types which have the same JSON representation in
+// both versions. The values are given in pairs of the source and destination.
+func convertShared(pairs ...interface{}) error {
+	for i := 0; i < len(pairs); i += 2 {
+		dst := reflect.ValueOf(pairs[i+1]).Elem()
+		dst.Set(reflect.Zero(dst.Type()))
+
+		raw, err := json.Marshal(pairs[i])
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(raw, pairs[i+1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertProviderSpecTo(in *ProviderSpec, out *v1alpha1.ProviderSpec, data *conversionData) error {
+	*out = v1alpha1.ProviderSpec{Type: in.Type}
+
+	field, ok := providerConfigField(in.Type)
+	if in.Config != nil && !ok {
+		return fmt.Errorf("Provider type '%s' doesn't take a config", in.Type)
+	}
+
+	fields := map[string]interface{}{"type": in.Type}
+	for name, cfg := range data.ProviderConfigs {
+		if name != field && name != "type" {
+			fields[name] = cfg
+		}
+	}
+
+	if in.Config != nil {
+		fields[field] = json.RawMessage(in.Config.Raw)
+	}
+
+	if len(fields) == 1 {
+		return nil
+	}
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("Could not decode the config of Provider type '%s': %s", in.Type, err)
+	}
+
+	return nil
+}
+
+func convertProviderSpecFrom(in *v1alpha1.ProviderSpec, out *ProviderSpec, data *conversionData) error {
+	*out = ProviderSpec{Type: in.Type}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	delete(fields, "type")
+
+	if field, ok := providerConfigField(in.Type); ok {
+		if cfg, ok := fields[field]; ok {
+			out.Config = &runtime.RawExtension{Raw: cfg}
+			delete(fields, field)
+		}
+	}
+
+	// The configuration of other providers is ignored by the Operator, it
+	// can't be represented in v1beta1.
+	if len(fields) > 0 {
+		data.ProviderConfigs = fields
+	}
+
+	return nil
+}
+
+// providerConfigField returns the JSON name of the v1alpha1 ProviderSpec field
+// which holds the configuration for the given provider type.
+func providerConfigField(typ string) (string, bool) {
+	if typ == "" {
+		return "", false
+	}
+
+	name := strings.ToLower(typ[:1]) + typ[1:]
+
+	t := reflect.TypeOf(v1alpha1.ProviderSpec{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name && tag != "type" {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func convertTemplateSpecTo(in *MonitorTemplateSpec, out *v1alpha1.MonitorTemplateSpec, data *conversionData) error {
+	*out = v1alpha1.MonitorTemplateSpec{
+		Type:      in.Type,
+		Name:      in.Name,
+		CheckRate: durationTo(in.CheckRate, data.CheckRate),
+		Timeout:   durationTo(in.Timeout, data.Timeout),
+	}
+
+	if in.Confirmations != nil {
+		confirmations := int(*in.Confirmations)
+		out.Confirmations = &confirmations
+	} else {
+		out.Confirmations = data.Confirmations
+	}
+
+	if in.HTTP != nil {
+		out.HTTP = &v1alpha1.HTTPTemplate{
+			URL:               in.HTTP.URL,
+			Endpoint:          in.HTTP.Endpoint,
+			CustomHeader:      headersTo(in.HTTP.Headers, data.CustomHeader),
+			UserAgent:         in.HTTP.UserAgent,
+			VerifyCertificate: in.HTTP.VerifyCertificate,
+			ShouldContain:     in.HTTP.ShouldContain,
+			ShouldNotContain:  in.HTTP.ShouldNotContain,
+			FollowRedirects:   in.HTTP.FollowRedirects,
+		}
+	}
+
+	if in.TCP != nil {
+		out.TCP = &v1alpha1.TCPTemplate{Host: in.TCP.Host, Port: in.TCP.Port}
+	}
+
+	return nil
+}
+
+func convertTemplateSpecFrom(in *v1alpha1.MonitorTemplateSpec, out *MonitorTemplateSpec, data *conversionData) error {
+	*out = MonitorTemplateSpec{
+		Type: in.Type,
+		Name: in.Name,
+	}
+
+	out.CheckRate, data.CheckRate = durationFrom(in.CheckRate)
+	out.Timeout, data.Timeout = durationFrom(in.Timeout)
+
+	if in.Confirmations != nil {
+		confirmations := *in.Confirmations
+		if confirmations > math.MaxInt32 || confirmations < math.MinInt32 {
+			data.Confirmations = &confirmations
+		} else {
+			c := int32(confirmations)
+			out.Confirmations = &c
+		}
+	}
+
+	if in.HTTP != nil {
+		var headers map[string]string
+		headers, data.CustomHeader = headersFrom(in.HTTP.CustomHeader)
+
+		out.HTTP = &HTTPTemplate{
+			URL:               in.HTTP.URL,
+			Endpoint:          in.HTTP.Endpoint,
+			Headers:           headers,
+			UserAgent:         in.HTTP.UserAgent,
+			VerifyCertificate: in.HTTP.VerifyCertificate,
+			ShouldContain:     in.HTTP.ShouldContain,
+			ShouldNotContain:  in.HTTP.ShouldNotContain,
+			FollowRedirects:   in.HTTP.FollowRedirects,
+		}
+	}
+
+	if in.TCP != nil {
+		out.TCP = &TCPTemplate{Host: in.TCP.Host, Port: in.TCP.Port}
+	}
+
+	return nil
+}
+
+// durationTo formats the duration as a v1alpha1 duration. The original
+// v1alpha1 value is returned when there's no duration, or when it's the same
+// duration formatted differently.
+func durationTo(d *metav1.Duration, original *string) *string {
+	if d == nil {
+		return copyString(original)
+	}
+
+	if original != nil {
+		if od, err := time.ParseDuration(*original); err == nil && od == d.Duration {
+			return copyString(original)
+		}
+	}
+
+	s := d.Duration.String()
+	return &s
+}
+
+// durationFrom parses the v1alpha1 duration. The original value is returned
+// as well when it can't be parsed or isn't formatted the way durationTo
+// formats it, so it can be kept in the ConversionAnnotation.
+func durationFrom(s *string) (*metav1.Duration, *string) {
+	if s == nil {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return nil, copyString(s)
+	}
+
+	if d.String() != *s {
+		return &metav1.Duration{Duration: d}, copyString(s)
+	}
+
+	return &metav1.Duration{Duration: d}, nil
+}
+
+// headersTo formats the headers as a v1alpha1 customHeader. The original
+// v1alpha1 value is returned when it parses to the same headers, or when it
+// can't be parsed and there are no headers.
+func headersTo(headers map[string]string, original *string) string {
+	if original == nil {
+		return formatHeaders(headers)
+	}
+
+	parsed, err := parseHeaders(*original)
+	if err != nil && len(headers) == 0 {
+		return *original
+	}
+
+	if err == nil && reflect.DeepEqual(parsed, headers) {
+		return *original
+	}
+
+	return formatHeaders(headers)
+}
+
+// headersFrom parses the v1alpha1 customHeader. The original value is
+// returned as well when it can't be parsed or isn't formatted the way
+// headersTo formats it, so it can be kept in the ConversionAnnotation.
+func headersFrom(s string) (map[string]string, *string) {
+	headers, err := parseHeaders(s)
+	if err != nil {
+		return nil, &s
+	}
+
+	if formatHeaders(headers) != s {
+		return headers, &s
+	}
+
+	return headers, nil
+}
+
+// formatHeaders formats the headers as `Name: value` lines, sorted by name.
+func formatHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + ": " + headers[name]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseHeaders parses `Name: value` lines into a map of headers. Blank lines
+// are skipped, like the providers do when they send the headers.
+func parseHeaders(s string) (map[string]string, error) {
+	var headers map[string]string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Expected a header formatted as 'Name: value', got '%s'", line)
+		}
+
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return headers, nil
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	cp := *s
+	return &cp
+}
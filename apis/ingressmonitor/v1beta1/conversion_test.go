@@ -0,0 +1,485 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	fuzz "github.com/google/gofuzz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const fuzzIterations = 500
+
+var providerTypes = []string{"StatusCake", "Logger", "Pingdom", "UptimeRobot", "Blackbox", "Datadog", "Webhook"}
+
+// providerConfigs return the field of the v1alpha1 ProviderSpec which holds the
+// configuration of the provider types that take one.
+var providerConfigs = map[string]func(*v1alpha1.ProviderSpec) interface{}{
+	"StatusCake":  func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.StatusCake },
+	"Pingdom":     func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.Pingdom },
+	"UptimeRobot": func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.UptimeRobot },
+	"Blackbox":    func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.Blackbox },
+	"Datadog":     func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.Datadog },
+	"Webhook":     func(spec *v1alpha1.ProviderSpec) interface{} { return &spec.Webhook },
+}
+
+// alphaFuzzer fuzzes v1alpha1 objects the way they're stored: with valid
+// durations, headers formatted by v1beta1 and only the configuration of the
+// selected provider. Some objects get values which can't be represented in
+// v1beta1, like the ones written before v1beta1 existed.
+func alphaFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(.2).Funcs(
+		fuzzTypeMeta,
+		func(spec *v1alpha1.ProviderSpec, c fuzz.Continue) {
+			*spec = v1alpha1.ProviderSpec{Type: providerTypes[c.Intn(len(providerTypes))]}
+			if cfg, ok := providerConfigs[spec.Type]; ok {
+				c.Fuzz(cfg(spec))
+			}
+
+			if other := providerTypes[c.Intn(len(providerTypes))]; other != spec.Type && c.Intn(5) == 0 {
+				if cfg, ok := providerConfigs[other]; ok {
+					c.Fuzz(cfg(spec))
+				}
+			}
+		},
+		func(spec *v1alpha1.MonitorTemplateSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(spec)
+
+			spec.CheckRate = fuzzDurationString(c)
+			spec.Timeout = fuzzDurationString(c)
+
+			if spec.Confirmations != nil {
+				confirmations := int(c.Int31())
+				if c.Intn(5) == 0 {
+					confirmations = math.MaxInt32 + int(c.Int31())
+				}
+				spec.Confirmations = &confirmations
+			}
+		},
+		func(http *v1alpha1.HTTPTemplate, c fuzz.Continue) {
+			c.FuzzNoCustom(http)
+
+			var headers map[string]string
+			fuzzHeaders(&headers, c)
+			http.CustomHeader = formatHeaders(headers)
+
+			switch c.Intn(5) {
+			case 0:
+				http.CustomHeader += fmt.Sprintf("\nX-Malformed-%d", c.Intn(100))
+			case 1:
+				// Headers which parse, but aren't formatted like
+				// v1beta1 formats them.
+				http.CustomHeader += fmt.Sprintf("\n  X-Padded-%d :  %s  ", c.Intn(100), c.RandString())
+			case 2:
+				http.CustomHeader += fmt.Sprintf("\nX-Duplicate: %d\nX-Duplicate: %d", c.Intn(100), c.Intn(100))
+			}
+		},
+	)
+}
+
+// betaFuzzer fuzzes v1beta1 objects with a Config matching the provider type
+// and headers which can be sent with a request.
+func betaFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(.2).Funcs(
+		fuzzTypeMeta,
+		func(spec *ProviderSpec, c fuzz.Continue) {
+			*spec = ProviderSpec{Type: providerTypes[c.Intn(len(providerTypes))]}
+			field, ok := providerConfigs[spec.Type]
+			if !ok || c.RandBool() {
+				return
+			}
+
+			// The field is a pointer to the configuration, fuzz a
+			// configuration which is set.
+			cfg := reflect.New(reflect.TypeOf(field(&v1alpha1.ProviderSpec{})).Elem().Elem()).Interface()
+			c.Fuzz(cfg)
+
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				panic(err)
+			}
+			spec.Config = &runtime.RawExtension{Raw: raw}
+		},
+		func(d *metav1.Duration, c fuzz.Continue) {
+			d.Duration = time.Duration(c.Int63n(int64(24 * time.Hour)))
+		},
+		fuzzHeaders,
+	)
+}
+
+// fuzzTypeMeta leaves the TypeMeta empty, it's set when the converted object
+// is encoded.
+func fuzzTypeMeta(tm *metav1.TypeMeta, c fuzz.Continue) {
+	*tm = metav1.TypeMeta{}
+}
+
+func fuzzDurationString(c fuzz.Continue) *string {
+	if c.RandBool() {
+		return nil
+	}
+
+	d := time.Duration(c.Int63n(int64(24 * time.Hour))).String()
+	switch c.Intn(5) {
+	case 0:
+		// Numbers without a unit, other than 0, aren't valid durations.
+		d = fmt.Sprintf("%d", c.Intn(100)+1)
+	case 1:
+		// Valid durations which aren't formatted like v1beta1 formats
+		// them.
+		d = fmt.Sprintf("%d%s", c.Intn(100), []string{"s", "m", "h"}[c.Intn(3)])
+	}
+
+	return &d
+}
+
+func fuzzHeaders(headers *map[string]string, c fuzz.Continue) {
+	*headers = nil
+
+	n := c.Intn(4)
+	for i := 0; i < n; i++ {
+		if *headers == nil {
+			*headers = map[string]string{}
+		}
+
+		value := strings.TrimSpace(strings.Replace(c.RandString(), "\n", "", -1))
+		(*headers)[fmt.Sprintf("X-Header-%d", c.Intn(100))] = value
+	}
+}
+
+func TestConversion_RoundTripFromV1alpha1(t *testing.T) {
+	tcs := []struct {
+		alpha func() interface{}
+		beta  func() interface{}
+	}{
+		{func() interface{} { return &v1alpha1.Provider{} }, func() interface{} { return &Provider{} }},
+		{func() interface{} { return &v1alpha1.ClusterProvider{} }, func() interface{} { return &ClusterProvider{} }},
+		{func() interface{} { return &v1alpha1.MonitorTemplate{} }, func() interface{} { return &MonitorTemplate{} }},
+		{func() interface{} { return &v1alpha1.ClusterMonitorTemplate{} }, func() interface{} { return &ClusterMonitorTemplate{} }},
+		{func() interface{} { return &v1alpha1.Monitor{} }, func() interface{} { return &Monitor{} }},
+		{func() interface{} { return &v1alpha1.IngressMonitor{} }, func() interface{} { return &IngressMonitor{} }},
+	}
+
+	for _, tc := range tcs {
+		kind := reflect.TypeOf(tc.alpha()).Elem().Name()
+
+		t.Run(kind, func(t *testing.T) {
+			f := alphaFuzzer(1)
+			for i := 0; i < fuzzIterations; i++ {
+				original := tc.alpha()
+				f.Fuzz(original)
+
+				beta := tc.beta()
+				if err := convertFrom(beta, original); err != nil {
+					t.Fatalf("Could not convert %#v to v1beta1: %s", original, err)
+				}
+
+				alpha := tc.alpha()
+				if err := convertTo(beta, alpha); err != nil {
+					t.Fatalf("Could not convert %#v to v1alpha1: %s", beta, err)
+				}
+
+				if !reflect.DeepEqual(original, alpha) {
+					t.Fatalf("Expected the round trip to return\n%#v\ngot\n%#v", original, alpha)
+				}
+			}
+		})
+	}
+}
+
+func TestConversion_RoundTripFromV1beta1(t *testing.T) {
+	tcs := []struct {
+		alpha func() interface{}
+		beta  func() interface{}
+	}{
+		{func() interface{} { return &v1alpha1.Provider{} }, func() interface{} { return &Provider{} }},
+		{func() interface{} { return &v1alpha1.ClusterProvider{} }, func() interface{} { return &ClusterProvider{} }},
+		{func() interface{} { return &v1alpha1.MonitorTemplate{} }, func() interface{} { return &MonitorTemplate{} }},
+		{func() interface{} { return &v1alpha1.ClusterMonitorTemplate{} }, func() interface{} { return &ClusterMonitorTemplate{} }},
+		{func() interface{} { return &v1alpha1.Monitor{} }, func() interface{} { return &Monitor{} }},
+		{func() interface{} { return &v1alpha1.IngressMonitor{} }, func() interface{} { return &IngressMonitor{} }},
+	}
+
+	for _, tc := range tcs {
+		kind := reflect.TypeOf(tc.beta()).Elem().Name()
+
+		t.Run(kind, func(t *testing.T) {
+			f := betaFuzzer(1)
+			for i := 0; i < fuzzIterations; i++ {
+				original := tc.beta()
+				f.Fuzz(original)
+
+				alpha := tc.alpha()
+				if err := convertTo(original, alpha); err != nil {
+					t.Fatalf("Could not convert %#v to v1alpha1: %s", original, err)
+				}
+
+				beta := tc.beta()
+				if err := convertFrom(beta, alpha); err != nil {
+					t.Fatalf("Could not convert %#v to v1beta1: %s", alpha, err)
+				}
+
+				if !reflect.DeepEqual(original, beta) {
+					t.Fatalf("Expected the round trip to return\n%#v\ngot\n%#v", original, beta)
+				}
+			}
+		})
+	}
+}
+
+func TestConversion_Errors(t *testing.T) {
+	t.Run("with a config for a provider which doesn't take one", func(t *testing.T) {
+		prov := &Provider{Spec: ProviderSpec{
+			Type:   "Logger",
+			Config: &runtime.RawExtension{Raw: []byte(`{"level":"debug"}`)},
+		}}
+
+		if err := prov.ConvertTo(new(v1alpha1.Provider)); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with a malformed conversion annotation", func(t *testing.T) {
+		tmpl := &MonitorTemplate{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{ConversionAnnotation: "{"},
+		}}
+
+		if err := tmpl.ConvertTo(new(v1alpha1.MonitorTemplate)); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+}
+
+func TestConversion_LegacyValues(t *testing.T) {
+	rate := "60"
+	timeout := "30s"
+	confirmations := math.MaxInt32 + 1
+	original := &v1alpha1.IngressMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "go-http"},
+		Spec: v1alpha1.IngressMonitorSpec{
+			Provider: v1alpha1.NamespacedProvider{
+				Namespace: "default",
+				ProviderSpec: v1alpha1.ProviderSpec{
+					Type:    "StatusCake",
+					Pingdom: &v1alpha1.PingdomProvider{Tags: []string{"gophers"}},
+				},
+			},
+			Template: v1alpha1.MonitorTemplateSpec{
+				Type:          v1alpha1.CheckTypeHTTP,
+				CheckRate:     &rate,
+				Timeout:       &timeout,
+				Confirmations: &confirmations,
+				HTTP:          &v1alpha1.HTTPTemplate{CustomHeader: "X-Team: gophers\nCustom-Header"},
+			},
+		},
+	}
+
+	var beta IngressMonitor
+	if err := beta.ConvertFrom(original); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	tmpl := beta.Spec.Template
+	if tmpl.CheckRate != nil || tmpl.Confirmations != nil || tmpl.HTTP.Headers != nil {
+		t.Errorf("Expected the values which can't be represented to be left out, got %#v", tmpl)
+	}
+
+	if tmpl.Timeout == nil || tmpl.Timeout.Duration != 30*time.Second {
+		t.Errorf("Expected the timeout to be converted, got %v", tmpl.Timeout)
+	}
+
+	if beta.Spec.Provider.Config != nil {
+		t.Errorf("Expected no config, got %s", beta.Spec.Provider.Config.Raw)
+	}
+
+	if _, ok := beta.Annotations[ConversionAnnotation]; !ok {
+		t.Fatalf("Expected the values to be kept in the %s annotation", ConversionAnnotation)
+	}
+
+	t.Run("converting back", func(t *testing.T) {
+		var alpha v1alpha1.IngressMonitor
+		if err := beta.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if !reflect.DeepEqual(original, &alpha) {
+			t.Errorf("Expected the round trip to return\n%#v\ngot\n%#v", original, &alpha)
+		}
+	})
+
+	t.Run("converting back with new values", func(t *testing.T) {
+		updated := beta.DeepCopy()
+		updated.Spec.Template.CheckRate = &metav1.Duration{Duration: time.Minute}
+		updated.Spec.Template.HTTP.Headers = map[string]string{"X-Team": "gophers"}
+
+		var alpha v1alpha1.IngressMonitor
+		if err := updated.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if *alpha.Spec.Template.CheckRate != "1m0s" {
+			t.Errorf("Expected the new checkRate, got %s", *alpha.Spec.Template.CheckRate)
+		}
+
+		if alpha.Spec.Template.HTTP.CustomHeader != "X-Team: gophers" {
+			t.Errorf("Expected the new headers, got %q", alpha.Spec.Template.HTTP.CustomHeader)
+		}
+
+		if _, ok := alpha.Annotations[ConversionAnnotation]; ok {
+			t.Errorf("Expected the %s annotation to be removed", ConversionAnnotation)
+		}
+	})
+}
+
+func TestConversion_BlankHeaderLines(t *testing.T) {
+	tmpl := &v1alpha1.MonitorTemplate{Spec: v1alpha1.MonitorTemplateSpec{
+		HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "X-Foo: bar\n\nX-Team: gophers\n"},
+	}}
+
+	var beta MonitorTemplate
+	if err := beta.ConvertFrom(tmpl); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	expected := map[string]string{"X-Foo": "bar", "X-Team": "gophers"}
+	if !reflect.DeepEqual(beta.Spec.HTTP.Headers, expected) {
+		t.Errorf("Expected headers %v, got %v", expected, beta.Spec.HTTP.Headers)
+	}
+
+	var alpha v1alpha1.MonitorTemplate
+	if err := beta.ConvertTo(&alpha); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if !reflect.DeepEqual(tmpl, &alpha) {
+		t.Errorf("Expected the round trip to return\n%#v\ngot\n%#v", tmpl, &alpha)
+	}
+}
+
+func TestConversion_DuplicateHeaders(t *testing.T) {
+	tmpl := &v1alpha1.MonitorTemplate{Spec: v1alpha1.MonitorTemplateSpec{
+		HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "X-Team: gophers\nX-Team: rustaceans"},
+	}}
+
+	var beta MonitorTemplate
+	if err := beta.ConvertFrom(tmpl); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	expected := map[string]string{"X-Team": "rustaceans"}
+	if !reflect.DeepEqual(beta.Spec.HTTP.Headers, expected) {
+		t.Errorf("Expected headers %v, got %v", expected, beta.Spec.HTTP.Headers)
+	}
+
+	t.Run("converting back", func(t *testing.T) {
+		var alpha v1alpha1.MonitorTemplate
+		if err := beta.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if !reflect.DeepEqual(tmpl, &alpha) {
+			t.Errorf("Expected the round trip to return\n%#v\ngot\n%#v", tmpl, &alpha)
+		}
+	})
+
+	t.Run("converting back without headers", func(t *testing.T) {
+		updated := beta.DeepCopy()
+		updated.Spec.HTTP.Headers = nil
+
+		var alpha v1alpha1.MonitorTemplate
+		if err := updated.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if h := alpha.Spec.HTTP.CustomHeader; h != "" {
+			t.Errorf("Expected the headers to be removed, got %q", h)
+		}
+	})
+}
+
+func TestConversion_MonitorTemplate(t *testing.T) {
+	rate := "1m"
+	tmpl := &v1alpha1.MonitorTemplate{Spec: v1alpha1.MonitorTemplateSpec{
+		Type:      v1alpha1.CheckTypeHTTP,
+		CheckRate: &rate,
+		HTTP: &v1alpha1.HTTPTemplate{
+			CustomHeader: "X-Team: gophers\nCustom-Header:IngressMonitor",
+		},
+	}}
+
+	var beta MonitorTemplate
+	if err := beta.ConvertFrom(tmpl); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if beta.Spec.CheckRate.Duration != time.Minute {
+		t.Errorf("Expected the checkRate to be 1m, got %s", beta.Spec.CheckRate.Duration)
+	}
+
+	expected := map[string]string{"X-Team": "gophers", "Custom-Header": "IngressMonitor"}
+	if !reflect.DeepEqual(beta.Spec.HTTP.Headers, expected) {
+		t.Errorf("Expected headers %v, got %v", expected, beta.Spec.HTTP.Headers)
+	}
+
+	var alpha v1alpha1.MonitorTemplate
+	if err := beta.ConvertTo(&alpha); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if *alpha.Spec.CheckRate != "1m" {
+		t.Errorf("Expected the checkRate to be 1m, got %s", *alpha.Spec.CheckRate)
+	}
+
+	if h := alpha.Spec.HTTP.CustomHeader; h != tmpl.Spec.HTTP.CustomHeader {
+		t.Errorf("Expected the headers to be kept, got %q", h)
+	}
+
+	t.Run("converting back with a new checkRate", func(t *testing.T) {
+		updated := beta.DeepCopy()
+		updated.Spec.CheckRate = &metav1.Duration{Duration: 2 * time.Minute}
+
+		var alpha v1alpha1.MonitorTemplate
+		if err := updated.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if *alpha.Spec.CheckRate != "2m0s" {
+			t.Errorf("Expected the checkRate to be 2m0s, got %s", *alpha.Spec.CheckRate)
+		}
+	})
+
+	t.Run("converting back with new headers", func(t *testing.T) {
+		updated := beta.DeepCopy()
+		updated.Spec.HTTP.Headers["X-Env"] = "production"
+
+		var alpha v1alpha1.MonitorTemplate
+		if err := updated.ConvertTo(&alpha); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if h := alpha.Spec.HTTP.CustomHeader; h != "Custom-Header: IngressMonitor\nX-Env: production\nX-Team: gophers" {
+			t.Errorf("Expected the headers to be sorted by name, got %q", h)
+		}
+	})
+}
+
+// convertFrom and convertTo call the conversion functions of the v1beta1
+// object with the v1alpha1 object of the same kind.
+func convertFrom(beta, alpha interface{}) error {
+	out := reflect.ValueOf(beta).MethodByName("ConvertFrom").Call([]reflect.Value{reflect.ValueOf(alpha)})
+	err, _ := out[0].Interface().(error)
+	return err
+}
+
+func convertTo(beta, alpha interface{}) error {
+	out := reflect.ValueOf(beta).MethodByName("ConvertTo").Call([]reflect.Value{reflect.ValueOf(alpha)})
+	err, _ := out[0].Interface().(error)
+	return err
+}
@@ -0,0 +1,7 @@
+// +k8s:deepcopy-gen=package
+
+// Package v1beta1 is the v1beta1 version of the API. Objects are stored as
+// v1alpha1, the conversion between both versions is described in
+// conversion.go.
+// +groupName=ingressmonitor.sphc.io
+package v1beta1
@@ -0,0 +1,234 @@
+package v1beta1
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// These are the kinds of objects a Monitor can select.
+const (
+	// SourceKindIngress selects Ingresses and sets up a monitor for each of
+	// their hosts.
+	SourceKindIngress = "Ingress"
+
+	// SourceKindService selects Services of type LoadBalancer and sets up a
+	// monitor for each of their load balancer addresses.
+	SourceKindService = "Service"
+
+	// SourceKindHTTPRoute selects Gateway API HTTPRoutes and sets up a
+	// monitor for each of their hostnames.
+	SourceKindHTTPRoute = "HTTPRoute"
+
+	// SourceKindRoute selects OpenShift Routes and sets up a monitor for
+	// their host.
+	SourceKindRoute = "Route"
+
+	// SourceKindVirtualService selects Istio VirtualServices and sets up a
+	// monitor for each of their hosts.
+	SourceKindVirtualService = "VirtualService"
+)
+
+// These are the kinds of providers and templates a Monitor can reference.
+const (
+	// ProviderKind references a Provider in the namespace of the Monitor.
+	ProviderKind = "Provider"
+
+	// ClusterProviderKind references a cluster scoped ClusterProvider.
+	ClusterProviderKind = "ClusterProvider"
+
+	// MonitorTemplateKind references a MonitorTemplate in the namespace of
+	// the Monitor.
+	MonitorTemplateKind = "MonitorTemplate"
+
+	// ClusterMonitorTemplateKind references a cluster scoped
+	// ClusterMonitorTemplate.
+	ClusterMonitorTemplateKind = "ClusterMonitorTemplate"
+)
+
+// SourceKinds are all the kinds of objects a Monitor can select.
+var SourceKinds = []string{
+	SourceKindIngress,
+	SourceKindService,
+	SourceKindHTTPRoute,
+	SourceKindRoute,
+	SourceKindVirtualService,
+}
+
+// MonitorSpec is the detailed configuration for an Monitor.
+type MonitorSpec struct {
+	// SourceKind describes the kind of objects the Selector selects. This is
+	// either `Ingress`, `Service`, `HTTPRoute`, `Route` or `VirtualService`.
+	// Defaults to `Ingress`.
+	// +optional
+	// +kubebuilder:validation:Enum=Ingress;Service;HTTPRoute;Route;VirtualService
+	SourceKind string `json:"sourceKind,omitempty"`
+
+	// Selector describes the LabelSelector which will be used to select the
+	// enabled Ingresses which we want to set up monitors for.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// IngressClassName limits the selected Ingresses to the ones which use
+	// the given IngressClass. When empty, Ingresses of all classes are
+	// selected.
+	IngressClassName string `json:"ingressClassName,omitempty"`
+
+	// PerPath sets up a monitor for every path of the rules of the selected
+	// Ingresses instead of one for every host. The health endpoint is checked
+	// relative to the path. Only used when the SourceKind is `Ingress`.
+	// +optional
+	PerPath bool `json:"perPath,omitempty"`
+
+	// Service describes how the selected Services are monitored. This is only
+	// used when the SourceKind is set to `Service`.
+	// +optional
+	Service *ServiceSource `json:"service,omitempty"`
+
+	// Provider describes the provider we want to use to set up the monitor
+	// with. Either Provider or Providers has to be set.
+	// +optional
+	Provider *ProviderReference `json:"provider,omitempty"`
+
+	// Providers describes multiple providers we want to set up the monitor
+	// with. An IngressMonitor is set up for every target with each of the
+	// providers. Either Provider or Providers has to be set.
+	// +optional
+	Providers []ProviderReference `json:"providers,omitempty"`
+
+	// Template describes the monitor configuration.
+	Template TemplateReference `json:"template"`
+}
+
+// ProviderReference references the Provider or ClusterProvider a Monitor sets
+// up its checks with.
+type ProviderReference struct {
+	// Kind is the kind of the referenced provider, either `Provider` or
+	// `ClusterProvider`. Defaults to `Provider`.
+	// +optional
+	// +kubebuilder:validation:Enum=Provider;ClusterProvider
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the referenced provider. A Provider is looked up
+	// in the namespace of the Monitor.
+	Name string `json:"name"`
+}
+
+// TemplateReference references the MonitorTemplate or ClusterMonitorTemplate
+// a Monitor configures its checks with.
+type TemplateReference struct {
+	// Kind is the kind of the referenced template, either `MonitorTemplate`
+	// or `ClusterMonitorTemplate`. Defaults to `MonitorTemplate`.
+	// +optional
+	// +kubebuilder:validation:Enum=MonitorTemplate;ClusterMonitorTemplate
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the referenced template. A MonitorTemplate is
+	// looked up in the namespace of the Monitor.
+	Name string `json:"name"`
+}
+
+// ServiceSource describes how Services of type LoadBalancer are monitored.
+type ServiceSource struct {
+	// Port is the name of the Service port which will be monitored. Defaults
+	// to the first port of the Service.
+	// +optional
+	Port string `json:"port,omitempty"`
+}
+
+// MonitorStatus describes the result of the last reconciliation of a Monitor.
+type MonitorStatus struct {
+	// ObservedGeneration is the most recent generation of the Monitor which
+	// has been reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SelectedIngresses is the amount of Ingresses, or the objects of the
+	// configured SourceKind, which are selected by the Monitor.
+	SelectedIngresses int `json:"selectedIngresses"`
+
+	// ReadyIngressMonitors is the amount of IngressMonitors managed by this
+	// Monitor which are configured with the provider.
+	ReadyIngressMonitors int `json:"readyIngressMonitors"`
+
+	// FailedIngressMonitors is the amount of IngressMonitors managed by this
+	// Monitor which couldn't be synced with the provider.
+	FailedIngressMonitors int `json:"failedIngressMonitors"`
+
+	// IngressMonitors is the list of names of the IngressMonitors which are
+	// managed by this Monitor.
+	IngressMonitors []string `json:"ingressMonitors,omitempty"`
+
+	// Conditions describe the current state of the Monitor.
+	Conditions []MonitorCondition `json:"conditions,omitempty"`
+}
+
+// MonitorConditionType is the type of condition which is set on a Monitor.
+type MonitorConditionType string
+
+const (
+	// MonitorReady indicates that the Monitor has been reconciled and all the
+	// IngressMonitors it manages are ready.
+	MonitorReady MonitorConditionType = "Ready"
+
+	// MonitorReferencesResolved indicates whether or not the provider and
+	// template referenced by the Monitor could be found and may be used by
+	// the Monitor.
+	MonitorReferencesResolved MonitorConditionType = "ReferencesResolved"
+
+	// MonitorSelectionValid indicates whether or not every rule and
+	// annotation of the objects selected by the Monitor can be monitored.
+	// The rules which are skipped and the malformed annotations are listed
+	// in its message.
+	MonitorSelectionValid MonitorConditionType = "SelectionValid"
+)
+
+// MonitorCondition describes the state of a Monitor at a certain point.
+type MonitorCondition struct {
+	// Type of the condition.
+	Type MonitorConditionType `json:"type"`
+
+	// Status of the condition, one of True, False or Unknown.
+	Status v1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one
+	// status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a unique, one-word, CamelCase reason for the condition's last
+	// transition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message indicating details about the
+	// transition.
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Selector",type="string",description="The label selector used to select objects",JSONPath=".spec.selector"
+// +kubebuilder:printcolumn:name="Provider",type="string",description="The Provider checks are set up with",JSONPath=".spec.provider.name"
+// +kubebuilder:printcolumn:name="Providers",type="string",description="The providers checks are set up with",JSONPath=".spec.providers[*].name",priority=1
+// +kubebuilder:printcolumn:name="Template",type="string",description="The MonitorTemplate checks are set up with",JSONPath=".spec.template.name"
+// +kubebuilder:printcolumn:name="Ingresses",type="integer",description="The amount of Ingresses selected by the Monitor",JSONPath=".status.selectedIngresses"
+// +kubebuilder:printcolumn:name="Ready",type="string",description="Whether or not all IngressMonitors are configured",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+
+// Monitor is the CRD specification for an Monitor. This
+// Monitor allows you to configure monitors for the resources selected
+// by it's configuration and instantiate them in the specified MonitorProvider.
+type Monitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec MonitorSpec `json:"spec"`
+
+	// +optional
+	Status MonitorStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MonitorList is a list of Monitors
+type MonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Monitor `json:"items"`
+}
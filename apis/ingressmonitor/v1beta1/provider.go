@@ -0,0 +1,50 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ProviderSpec is the detailed configuration for a Provider. The Type
+// describes which provider is configured and how its Config looks.
+type ProviderSpec struct {
+	// Type describes the type of Provider which this CRD will configure.
+	// +kubebuilder:validation:Enum=StatusCake;Logger;Pingdom;UptimeRobot;Blackbox;Datadog;Webhook
+	Type string `json:"type"`
+
+	// Config is the configuration of the provider of the given Type. For
+	// `StatusCake`, this contains the `username`, `apiKey` and
+	// `contactGroups`. For `Pingdom`, this contains the `apiToken`,
+	// `integrationIDs`, `userIDs` and `tags`. For `UptimeRobot`, this
+	// contains the `apiKey` and `alertContacts`. For `Blackbox`, this contains
+	// the `output`, `configMap`, `proberURL` and `labels`. For `Datadog`, this
+	// contains the `apiKey`, `appKey`, `site`, `locations`, `tags` and
+	// `notify`. For `Webhook`, this contains the `url`, `authHeader`,
+	// `authHeaderName` and `ca`. Providers without configuration, like
+	// `Logger`, don't take a Config.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Provider is the CRD specification for an Provider. This
+// Provider allows you to configure providers which will be used to set
+// up monitors.
+type Provider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ProviderSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProviderList is a list of Providers.
+type ProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Provider `json:"items"`
+}
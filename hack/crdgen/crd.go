@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+type customResourceDefinition struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   crdMetadata `json:"metadata"`
+	Spec       crdSpec     `json:"spec"`
+}
+
+type crdMetadata struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type crdSpec struct {
+	Group      string         `json:"group"`
+	Scope      string         `json:"scope"`
+	Names      crdNames       `json:"names"`
+	Conversion *crdConversion `json:"conversion,omitempty"`
+	Versions   []crdVersion   `json:"versions"`
+}
+
+type crdNames struct {
+	Kind     string `json:"kind"`
+	ListKind string `json:"listKind"`
+	Plural   string `json:"plural"`
+	Singular string `json:"singular"`
+}
+
+type crdConversion struct {
+	Strategy string             `json:"strategy"`
+	Webhook  *conversionWebhook `json:"webhook,omitempty"`
+}
+
+type conversionWebhook struct {
+	ConversionReviewVersions []string `json:"conversionReviewVersions"`
+	ClientConfig             struct {
+		Service serviceReference `json:"service"`
+	} `json:"clientConfig"`
+}
+
+type serviceReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Path      string `json:"path"`
+	Port      int32  `json:"port"`
+}
+
+type crdVersion struct {
+	Name    string `json:"name"`
+	Served  bool   `json:"served"`
+	Storage bool   `json:"storage"`
+	Schema  struct {
+		OpenAPIV3Schema *schemaProps `json:"openAPIV3Schema"`
+	} `json:"schema"`
+	Subresources             *crdSubresources `json:"subresources,omitempty"`
+	AdditionalPrinterColumns []printerColumn  `json:"additionalPrinterColumns,omitempty"`
+}
+
+type crdSubresources struct {
+	Status *struct{} `json:"status,omitempty"`
+}
+
+type printerColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	JSONPath    string `json:"jsonPath"`
+	Priority    int32  `json:"priority,omitempty"`
+}
+
+// crds generates the CRDs for all kinds of the group, sorted by their kind.
+// When a conversion webhook is given, the CRDs are configured to use it and
+// serve every version. Otherwise only the storage version is served, as the
+// API server can't convert between versions with a different schema.
+func (g *group) crds(webhook string) ([][]byte, error) {
+	var kinds []string
+	seen := map[string]bool{}
+	for _, ver := range g.versions {
+		for _, kind := range ver.kinds {
+			if !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+
+	var docs [][]byte
+	for _, kind := range sortedStrings(kinds) {
+		crd, err := g.crd(kind, webhook)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", kind, err)
+		}
+
+		doc, err := yaml.Marshal(crd)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (g *group) crd(kind, webhook string) (*customResourceDefinition, error) {
+	singular := strings.ToLower(kind)
+	plural := singular + "s"
+
+	crd := &customResourceDefinition{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Metadata: crdMetadata{
+			Name:   plural + "." + g.name,
+			Labels: map[string]string{"component": singular},
+		},
+		Spec: crdSpec{
+			Group: g.name,
+			Scope: "Namespaced",
+			Names: crdNames{
+				Kind:     kind,
+				ListKind: kind + "List",
+				Plural:   plural,
+				Singular: singular,
+			},
+		},
+	}
+
+	if webhook != "" {
+		parts := strings.SplitN(webhook, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Expected the conversion webhook as namespace/name, got %s", webhook)
+		}
+
+		// cert-manager injects the CA of the webhook certificate, which has
+		// the same name as the Service.
+		crd.Metadata.Annotations = map[string]string{"cert-manager.io/inject-ca-from": webhook}
+		crd.Spec.Conversion = &crdConversion{Strategy: "Webhook", Webhook: &conversionWebhook{
+			ConversionReviewVersions: []string{"v1"},
+		}}
+		crd.Spec.Conversion.Webhook.ClientConfig.Service = serviceReference{
+			Name:      parts[1],
+			Namespace: parts[0],
+			Path:      conversionPath,
+			Port:      conversionPort,
+		}
+	}
+
+	storage := 0
+	for _, ver := range g.versions {
+		info, ok := ver.types[kind]
+		if !ok {
+			continue
+		}
+
+		cv := crdVersion{Name: ver.name, Served: true}
+		for _, marker := range info.markers {
+			if !strings.HasPrefix(marker, "+kubebuilder:") {
+				continue
+			}
+
+			name, value := splitMarker(marker)
+			switch name {
+			case "+kubebuilder:storageversion":
+				cv.Storage = true
+				storage++
+			case "+kubebuilder:subresource:status":
+				cv.Subresources = &crdSubresources{Status: &struct{}{}}
+			case "+kubebuilder:resource:scope":
+				crd.Spec.Scope = value
+			case "+kubebuilder:printcolumn:name":
+				col, err := parsePrinterColumn(strings.TrimPrefix(marker, "+kubebuilder:printcolumn:"))
+				if err != nil {
+					return nil, err
+				}
+				cv.AdditionalPrinterColumns = append(cv.AdditionalPrinterColumns, col)
+			default:
+				return nil, fmt.Errorf("Unsupported marker %s", marker)
+			}
+		}
+
+		schema, err := ver.schema(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		// Objects are submitted without a status.
+		schema.Required = remove(schema.Required, "status")
+		cv.Schema.OpenAPIV3Schema = schema
+
+		crd.Spec.Versions = append(crd.Spec.Versions, cv)
+	}
+
+	if storage != 1 {
+		return nil, fmt.Errorf("Expected one version with the +kubebuilder:storageversion marker, got %d", storage)
+	}
+
+	if webhook == "" {
+		for i := range crd.Spec.Versions {
+			crd.Spec.Versions[i].Served = crd.Spec.Versions[i].Storage
+		}
+	}
+
+	return crd, nil
+}
+
+// parsePrinterColumn parses the arguments of a printcolumn marker, like
+// `name="Ready",type="string",JSONPath=".status.ready"`.
+func parsePrinterColumn(args string) (printerColumn, error) {
+	var col printerColumn
+
+	for args != "" {
+		parts := strings.SplitN(args, "=", 2)
+		if len(parts) != 2 {
+			return col, fmt.Errorf("Invalid printcolumn argument %s", args)
+		}
+
+		key, rest := parts[0], parts[1]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := closingQuote(rest)
+			if end < 0 {
+				return col, fmt.Errorf("Unterminated value for %s", key)
+			}
+
+			var err error
+			if value, err = strconv.Unquote(rest[:end+1]); err != nil {
+				return col, fmt.Errorf("Invalid value for %s: %s", key, err)
+			}
+			rest = rest[end+1:]
+		} else {
+			end := strings.Index(rest, ",")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		args = strings.TrimPrefix(rest, ",")
+
+		switch key {
+		case "name":
+			col.Name = value
+		case "type":
+			col.Type = value
+		case "description":
+			col.Description = value
+		case "JSONPath":
+			col.JSONPath = value
+		case "priority":
+			priority, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return col, fmt.Errorf("Invalid priority %s: %s", value, err)
+			}
+			col.Priority = int32(priority)
+		default:
+			return col, fmt.Errorf("Unknown printcolumn argument %s", key)
+		}
+	}
+
+	return col, nil
+}
+
+// closingQuote returns the index of the quote which closes the quoted string
+// s starts with.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func remove(list []string, value string) []string {
+	var out []string
+	for _, item := range list {
+		if item != value {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+func sortedStrings(list []string) []string {
+	out := append([]string{}, list...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,309 @@
+// Command crdgen generates the CustomResourceDefinitions of an API group from
+// the Go types in its version packages. The OpenAPI v3 schemas are built from
+// the fields, doc comments and `+kubebuilder` markers of the types, the types
+// marked with `+genclient` are the kinds of the group.
+//
+// The CRDs of the group in the manifest are replaced by the generated ones,
+// other objects in the manifest are kept as they are. Run it through
+// `make manifests`.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// conversionPath is the path the webhook command serves the conversion
+	// webhook on.
+	conversionPath = "/convert"
+
+	// conversionPort is the port of the webhook Service.
+	conversionPort = 443
+
+	// documentSeparator separates the objects in a manifest.
+	documentSeparator = "\n\n---\n\n"
+)
+
+func main() {
+	apis := flag.String("apis", "apis/ingressmonitor", "The directory of the API group, containing a package for every version.")
+	manifest := flag.String("manifest", "", "The manifest the CRDs are written to. The CRDs are written to stdout when it's empty.")
+	webhook := flag.String("conversion-webhook", "", "The namespace/name of the Service which serves the conversion webhook. When it's empty, only the storage version is served.")
+	flag.Parse()
+
+	grp, err := loadGroup(*apis)
+	if err != nil {
+		log.Fatalf("Could not load the API group: %s", err)
+	}
+
+	crds, err := grp.crds(*webhook)
+	if err != nil {
+		log.Fatalf("Could not generate the CRDs: %s", err)
+	}
+
+	if err := writeManifest(*manifest, grp.name, crds); err != nil {
+		log.Fatalf("Could not write the manifest: %s", err)
+	}
+}
+
+// writeManifest replaces the CRDs of the group in the manifest with the given
+// documents. The generated CRDs take the place of the first CRD of the group,
+// or are appended when the manifest doesn't contain any yet.
+func writeManifest(path, group string, crds [][]byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(joinDocuments(crds))
+		return err
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var docs [][]byte
+	inserted := false
+	for _, doc := range bytes.Split(raw, []byte("\n---\n")) {
+		doc = bytes.TrimSpace(doc)
+		if len(doc) == 0 {
+			continue
+		}
+
+		var obj struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Group string `json:"group"`
+			} `json:"spec"`
+		}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return fmt.Errorf("Could not decode %s: %s", path, err)
+		}
+
+		if obj.Kind != "CustomResourceDefinition" || obj.Spec.Group != group {
+			docs = append(docs, doc)
+			continue
+		}
+
+		if !inserted {
+			// Keep the comment in front of the CRDs, the generated CRDs
+			// don't have one.
+			generated := append([][]byte{}, crds...)
+			if comment := leadingComment(doc); len(comment) > 0 {
+				generated[0] = append(append(comment, '\n'), generated[0]...)
+			}
+
+			docs = append(docs, generated...)
+			inserted = true
+		}
+	}
+
+	if !inserted {
+		docs = append(docs, crds...)
+	}
+
+	return ioutil.WriteFile(path, joinDocuments(docs), 0644)
+}
+
+// leadingComment returns the comment lines a document starts with.
+func leadingComment(doc []byte) []byte {
+	var comment [][]byte
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		if len(line) > 0 && line[0] != '#' {
+			break
+		}
+		comment = append(comment, line)
+	}
+
+	return bytes.Join(comment, []byte("\n"))
+}
+
+func joinDocuments(docs [][]byte) []byte {
+	trimmed := make([][]byte, len(docs))
+	for i, doc := range docs {
+		trimmed[i] = bytes.TrimSpace(doc)
+	}
+
+	return append(bytes.Join(trimmed, []byte(documentSeparator)), '\n')
+}
+
+// group is an API group with the types of all of its versions.
+type group struct {
+	name     string
+	versions []*version
+}
+
+// version is a version of an API group with the types declared in its
+// package.
+type version struct {
+	name  string
+	types map[string]*typeInfo
+	kinds []string
+}
+
+// typeInfo is a type declaration of a version package.
+type typeInfo struct {
+	name    string
+	doc     string
+	markers []string
+	expr    ast.Expr
+	imports map[string]string
+}
+
+func loadGroup(dir string) (*group, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	grp := &group{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		ver, name, err := loadVersion(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Could not load %s: %s", entry.Name(), err)
+		}
+
+		if grp.name != "" && grp.name != name {
+			return nil, fmt.Errorf("Version %s belongs to group %s instead of %s", ver.name, name, grp.name)
+		}
+
+		grp.name = name
+		grp.versions = append(grp.versions, ver)
+	}
+
+	if grp.name == "" {
+		return nil, fmt.Errorf("No versions with a +groupName marker found in %s", dir)
+	}
+
+	return grp, nil
+}
+
+// loadVersion parses the package of a version and returns its types and the
+// name of the group it belongs to.
+func loadVersion(dir string) (*version, string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasPrefix(fi.Name(), "zz_generated")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, "", fmt.Errorf("Expected a single package, found %d", len(pkgs))
+	}
+
+	var groupName string
+	ver := &version{types: map[string]*typeInfo{}}
+	for _, pkg := range pkgs {
+		ver.name = pkg.Name
+
+		for _, file := range pkg.Files {
+			if file.Doc != nil {
+				for _, marker := range markers(file.Doc) {
+					if strings.HasPrefix(marker, "+groupName=") {
+						groupName = strings.TrimPrefix(marker, "+groupName=")
+					}
+				}
+			}
+
+			imports := map[string]string{}
+			for _, imp := range file.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				name := filepath.Base(path)
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				imports[name] = path
+			}
+
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					info := &typeInfo{name: ts.Name.Name, expr: ts.Type, imports: imports}
+
+					// Markers like +genclient are separated from the doc
+					// comment by an empty line.
+					for _, cg := range file.Comments {
+						if gen.Doc != nil && fset.Position(cg.End()).Line == fset.Position(gen.Doc.Pos()).Line-2 {
+							info.markers = append(info.markers, markers(cg)...)
+						}
+					}
+
+					if gen.Doc != nil {
+						info.doc = description(gen.Doc)
+						info.markers = append(info.markers, markers(gen.Doc)...)
+					}
+
+					ver.types[info.name] = info
+					if hasMarker(info.markers, "+genclient") {
+						ver.kinds = append(ver.kinds, info.name)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(ver.kinds)
+	return ver, groupName, nil
+}
+
+// markers returns the marker lines, starting with a +, of a comment.
+func markers(cg *ast.CommentGroup) []string {
+	var out []string
+	for _, c := range cg.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if strings.HasPrefix(line, "+") {
+			out = append(out, line)
+		}
+	}
+
+	return out
+}
+
+// description joins the lines of a comment which aren't markers.
+func description(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range strings.Split(cg.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "+") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, " ")
+}
+
+func hasMarker(markers []string, marker string) bool {
+	for _, m := range markers {
+		if m == marker {
+			return true
+		}
+	}
+
+	return false
+}
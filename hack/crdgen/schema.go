@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaProps is the subset of an OpenAPI v3 schema which is used by the
+// CRDs.
+type schemaProps struct {
+	Description           string                  `json:"description,omitempty"`
+	Type                  string                  `json:"type,omitempty"`
+	Format                string                  `json:"format,omitempty"`
+	Enum                  []string                `json:"enum,omitempty"`
+	Pattern               string                  `json:"pattern,omitempty"`
+	Minimum               *int64                  `json:"minimum,omitempty"`
+	Properties            map[string]*schemaProps `json:"properties,omitempty"`
+	Required              []string                `json:"required,omitempty"`
+	Items                 *schemaProps            `json:"items,omitempty"`
+	AdditionalProperties  *schemaProps            `json:"additionalProperties,omitempty"`
+	PreserveUnknownFields bool                    `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+const (
+	metav1Package  = "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1Package  = "k8s.io/api/core/v1"
+	runtimePackage = "k8s.io/apimachinery/pkg/runtime"
+)
+
+// externalTypes are the schemas of the types from other packages which are
+// used by the API types. Their source isn't parsed, the schemas follow the
+// ones the Kubernetes API publishes.
+var externalTypes = map[string]func() *schemaProps{
+	metav1Package + ".ObjectMeta": func() *schemaProps {
+		return &schemaProps{Type: "object"}
+	},
+	metav1Package + ".Time": func() *schemaProps {
+		return &schemaProps{Type: "string", Format: "date-time"}
+	},
+	metav1Package + ".Duration": func() *schemaProps {
+		return &schemaProps{Type: "string"}
+	},
+	metav1Package + ".LabelSelector": func() *schemaProps {
+		return &schemaProps{
+			Description: "A label selector is a label query over a set of resources. The result of matchLabels and matchExpressions are ANDed. An empty label selector matches all objects. A null label selector matches no objects.",
+			Type:        "object",
+			Properties: map[string]*schemaProps{
+				"matchExpressions": {
+					Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+					Type:        "array",
+					Items: &schemaProps{
+						Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+						Type:        "object",
+						Properties: map[string]*schemaProps{
+							"key": {
+								Description: "key is the label key that the selector applies to.",
+								Type:        "string",
+							},
+							"operator": {
+								Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+								Type:        "string",
+							},
+							"values": {
+								Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+								Type:        "array",
+								Items:       &schemaProps{Type: "string"},
+							},
+						},
+						Required: []string{"key", "operator"},
+					},
+				},
+				"matchLabels": {
+					Description:          `matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.`,
+					Type:                 "object",
+					AdditionalProperties: &schemaProps{Type: "string"},
+				},
+			},
+		}
+	},
+	corev1Package + ".SecretKeySelector": func() *schemaProps {
+		return &schemaProps{
+			Description: "SecretKeySelector selects a key of a Secret.",
+			Type:        "object",
+			Properties: map[string]*schemaProps{
+				"key": {
+					Description: "The key of the secret to select from.  Must be a valid secret key.",
+					Type:        "string",
+				},
+				"name": {
+					Description: "Name of the referent.",
+					Type:        "string",
+				},
+				"optional": {
+					Description: "Specify whether the Secret or its key must be defined",
+					Type:        "boolean",
+				},
+			},
+			Required: []string{"key"},
+		}
+	},
+	corev1Package + ".ConditionStatus": func() *schemaProps {
+		return &schemaProps{Type: "string"}
+	},
+	runtimePackage + ".RawExtension": func() *schemaProps {
+		return &schemaProps{Type: "object", PreserveUnknownFields: true}
+	},
+}
+
+// typeMetaProperties are the properties of an inlined metav1.TypeMeta.
+var typeMetaProperties = map[string]string{
+	"apiVersion": "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+	"kind":       "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+}
+
+var builtinTypes = map[string]*schemaProps{
+	"string": {Type: "string"},
+	"bool":   {Type: "boolean"},
+	"int":    {Type: "integer"},
+	"int32":  {Type: "integer", Format: "int32"},
+	"int64":  {Type: "integer", Format: "int64"},
+}
+
+// schema builds the schema of the given named type of the version.
+func (v *version) schema(name string) (*schemaProps, error) {
+	info, ok := v.types[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown type %s", name)
+	}
+
+	s, err := v.exprSchema(info, info.expr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+
+	if info.doc != "" {
+		s.Description = info.doc
+	}
+
+	return s, nil
+}
+
+// exprSchema builds the schema of a type expression used in the declaration
+// of the given type.
+func (v *version) exprSchema(info *typeInfo, expr ast.Expr) (*schemaProps, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return v.exprSchema(info, t.X)
+	case *ast.ArrayType:
+		items, err := v.exprSchema(info, t.Elt)
+		if err != nil {
+			return nil, err
+		}
+
+		return &schemaProps{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		if key, ok := t.Key.(*ast.Ident); !ok || key.Name != "string" {
+			return nil, fmt.Errorf("Maps need to be keyed by strings")
+		}
+
+		values, err := v.exprSchema(info, t.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &schemaProps{Type: "object", AdditionalProperties: values}, nil
+	case *ast.Ident:
+		if s, ok := builtinTypes[t.Name]; ok {
+			cp := *s
+			return &cp, nil
+		}
+
+		return v.schema(t.Name)
+	case *ast.SelectorExpr:
+		name, err := externalName(info, t)
+		if err != nil {
+			return nil, err
+		}
+
+		fn, ok := externalTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("No schema for %s", name)
+		}
+
+		return fn(), nil
+	case *ast.StructType:
+		return v.structSchema(info, t)
+	}
+
+	return nil, fmt.Errorf("Unsupported type %T", expr)
+}
+
+func (v *version) structSchema(info *typeInfo, st *ast.StructType) (*schemaProps, error) {
+	s := &schemaProps{Type: "object", Properties: map[string]*schemaProps{}}
+
+	for _, field := range st.Fields.List {
+		tag := jsonTag(field)
+		if tag[0] == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 && tag[0] == "" {
+			if err := v.inline(info, field, s); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if tag[0] == "" {
+			return nil, fmt.Errorf("Field %s doesn't have a JSON name", field.Names[0].Name)
+		}
+
+		prop, err := v.exprSchema(info, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", tag[0], err)
+		}
+
+		if doc := description(field.Doc); doc != "" {
+			prop.Description = doc
+		}
+
+		var fieldMarkers []string
+		if field.Doc != nil {
+			fieldMarkers = markers(field.Doc)
+		}
+
+		if err := applyFieldMarkers(prop, fieldMarkers); err != nil {
+			return nil, fmt.Errorf("%s: %s", tag[0], err)
+		}
+
+		s.Properties[tag[0]] = prop
+
+		omitempty := false
+		for _, opt := range tag[1:] {
+			omitempty = omitempty || opt == "omitempty"
+		}
+
+		// The metadata is validated by the API server.
+		if !omitempty && !hasMarker(fieldMarkers, "+optional") && tag[0] != "metadata" {
+			s.Required = append(s.Required, tag[0])
+		}
+	}
+
+	sort.Strings(s.Required)
+	return s, nil
+}
+
+// inline adds the properties of an embedded struct to the schema.
+func (v *version) inline(info *typeInfo, field *ast.Field, s *schemaProps) error {
+	if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+		name, err := externalName(info, sel)
+		if err != nil {
+			return err
+		}
+
+		if name != metav1Package+".TypeMeta" {
+			return fmt.Errorf("Can't inline %s", name)
+		}
+
+		for prop, desc := range typeMetaProperties {
+			s.Properties[prop] = &schemaProps{Type: "string", Description: desc}
+		}
+		return nil
+	}
+
+	ident, ok := field.Type.(*ast.Ident)
+	if !ok {
+		return fmt.Errorf("Can't inline %T", field.Type)
+	}
+
+	embedded, err := v.schema(ident.Name)
+	if err != nil {
+		return err
+	}
+
+	for name, prop := range embedded.Properties {
+		s.Properties[name] = prop
+	}
+	s.Required = append(s.Required, embedded.Required...)
+
+	return nil
+}
+
+// applyFieldMarkers applies the validation markers of a field to its schema.
+func applyFieldMarkers(s *schemaProps, markers []string) error {
+	for _, marker := range markers {
+		if !strings.HasPrefix(marker, "+kubebuilder:") {
+			continue
+		}
+
+		name, value := splitMarker(marker)
+		switch name {
+		case "+kubebuilder:validation:Enum":
+			s.Enum = strings.Split(value, ";")
+		case "+kubebuilder:validation:Pattern":
+			pattern, err := unquote(value)
+			if err != nil {
+				return fmt.Errorf("Invalid pattern %s: %s", value, err)
+			}
+			s.Pattern = pattern
+		case "+kubebuilder:validation:Minimum":
+			min, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("Invalid minimum %s: %s", value, err)
+			}
+			s.Minimum = &min
+		case "+kubebuilder:pruning:PreserveUnknownFields":
+			s.PreserveUnknownFields = true
+		default:
+			return fmt.Errorf("Unsupported marker %s", marker)
+		}
+	}
+
+	return nil
+}
+
+// splitMarker splits a marker in its name and value, separated by the first
+// `=`.
+func splitMarker(marker string) (string, string) {
+	parts := strings.SplitN(marker, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// unquote removes the backticks or double quotes around a marker value.
+func unquote(value string) (string, error) {
+	if strings.HasPrefix(value, "`") || strings.HasPrefix(value, `"`) {
+		return strconv.Unquote(value)
+	}
+
+	return value, nil
+}
+
+func externalName(info *typeInfo, sel *ast.SelectorExpr) (string, error) {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", fmt.Errorf("Unsupported type %T", sel.X)
+	}
+
+	path, ok := info.imports[pkg.Name]
+	if !ok {
+		return "", fmt.Errorf("Unknown package %s", pkg.Name)
+	}
+
+	return path + "." + sel.Sel.Name, nil
+}
+
+func jsonTag(field *ast.Field) []string {
+	if field.Tag == nil {
+		return []string{""}
+	}
+
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	return strings.Split(reflect.StructTag(tag).Get("json"), ",")
+}
@@ -0,0 +1,204 @@
+// Package admission implements a validating admission webhook for the
+// IngressMonitor CRDs. It rejects objects the Operator wouldn't be able to
+// work with before they're stored in the cluster.
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxRequestSize is the maximum size of an AdmissionReview we accept. The API
+// server limits objects to a few megabytes, this leaves room for both the old
+// and new object.
+const maxRequestSize = 8 << 20
+
+// Validator is a http.Handler which validates the AdmissionReviews for
+// Providers, ClusterProviders, MonitorTemplates, ClusterMonitorTemplates,
+// Monitors and IngressMonitors it receives from the API server.
+type Validator struct {
+	factory provider.FactoryInterface
+}
+
+// NewValidator creates a new Validator which validates Providers against the
+// providers which are registered with the given factory.
+func NewValidator(fact provider.FactoryInterface) *Validator {
+	return &Validator{factory: fact}
+}
+
+// ServeHTTP decodes the AdmissionReview, validates the object in it and
+// writes the response back.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("Could not decode AdmissionReview: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, "The AdmissionReview doesn't contain a request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = v.review(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Printf("Could not write AdmissionReview response: %s", err)
+	}
+}
+
+// review validates the object in the request and builds the response for it.
+func (v *Validator) review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	resp := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+
+	if skipValidation(req) {
+		return resp
+	}
+
+	errs, err := v.validate(req)
+	if err != nil {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusBadRequest,
+			Reason:  metav1.StatusReasonBadRequest,
+			Message: err.Error(),
+		}
+		return resp
+	}
+
+	if len(errs) > 0 {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusUnprocessableEntity,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: fmt.Sprintf("%s %s is invalid: %s", req.Kind.Kind, req.Name, errs.ToAggregate()),
+		}
+	}
+
+	return resp
+}
+
+// validate decodes the object in the request based on its kind and validates
+// it. An error is returned when the object can't be decoded.
+func (v *Validator) validate(req *admissionv1.AdmissionRequest) (field.ErrorList, error) {
+	if req.Kind.Group != v1alpha1.SchemeGroupVersion.Group {
+		return nil, fmt.Errorf("Unsupported group '%s'", req.Kind.Group)
+	}
+
+	switch req.Kind.Kind {
+	case "Provider":
+		var prov v1alpha1.Provider
+		if err := decode(req, &prov); err != nil {
+			return nil, err
+		}
+
+		return validateProvider(v.factory, &prov), nil
+	case "ClusterProvider":
+		var prov v1alpha1.ClusterProvider
+		if err := decode(req, &prov); err != nil {
+			return nil, err
+		}
+
+		return validateClusterProvider(v.factory, &prov), nil
+	case "MonitorTemplate":
+		var tmpl v1alpha1.MonitorTemplate
+		if err := decode(req, &tmpl); err != nil {
+			return nil, err
+		}
+
+		return validateMonitorTemplate(&tmpl), nil
+	case "ClusterMonitorTemplate":
+		var tmpl v1alpha1.ClusterMonitorTemplate
+		if err := decode(req, &tmpl); err != nil {
+			return nil, err
+		}
+
+		return validateClusterMonitorTemplate(&tmpl), nil
+	case "Monitor":
+		var mon v1alpha1.Monitor
+		if err := decode(req, &mon); err != nil {
+			return nil, err
+		}
+
+		return validateMonitor(&mon), nil
+	case "IngressMonitor":
+		var im v1alpha1.IngressMonitor
+		if err := decode(req, &im); err != nil {
+			return nil, err
+		}
+
+		// The namespace isn't always set on objects which are being
+		// created.
+		if im.Namespace == "" {
+			im.Namespace = req.Namespace
+		}
+
+		return validateIngressMonitor(v.factory, &im), nil
+	}
+
+	return nil, fmt.Errorf("Unsupported kind '%s'", req.Kind.Kind)
+}
+
+// skipValidation reports whether the object in the request can be allowed
+// without validating it. Deleting an object, updating an object which is
+// being deleted and updates which only change the metadata or status, like
+// removing a finalizer, don't need validating. Otherwise an object which has
+// become invalid could never be finalized.
+func skipValidation(req *admissionv1.AdmissionRequest) bool {
+	if req.Operation == admissionv1.Delete {
+		return true
+	}
+
+	if req.Operation != admissionv1.Update {
+		return false
+	}
+
+	// Objects which can't be decoded are validated, so the decoding error is
+	// reported.
+	var obj, old map[string]interface{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return false
+	}
+
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok && meta["deletionTimestamp"] != nil {
+		return true
+	}
+
+	if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil || old == nil {
+		return false
+	}
+
+	for _, fld := range []string{"metadata", "status"} {
+		delete(obj, fld)
+		delete(old, fld)
+	}
+
+	return reflect.DeepEqual(obj, old)
+}
+
+func decode(req *admissionv1.AdmissionRequest, obj interface{}) error {
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return fmt.Errorf("Could not decode %s: %s", req.Kind.Kind, err)
+	}
+
+	return nil
+}
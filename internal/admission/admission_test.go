@@ -0,0 +1,613 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/statuscake"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newFactory() provider.FactoryInterface {
+	fact := provider.NewFactory(nil)
+	fact.Register("Simple", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.Register("HTTPOnly", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.RegisterCheckTypes("HTTPOnly", v1alpha1.CheckTypeHTTP)
+	statuscake.Register(fact)
+
+	return fact
+}
+
+func TestValidator_Provider(t *testing.T) {
+	tcs := []struct {
+		name string
+		spec v1alpha1.ProviderSpec
+		errs []string
+	}{
+		{"registered provider", v1alpha1.ProviderSpec{Type: "Simple"}, nil},
+		{"without type", v1alpha1.ProviderSpec{}, []string{"spec.type: Required value"}},
+		{"unknown type", v1alpha1.ProviderSpec{Type: "Unknown"}, []string{`spec.type: Unsupported value: "Unknown"`}},
+		{
+			"StatusCake without configuration",
+			v1alpha1.ProviderSpec{Type: "StatusCake"},
+			[]string{"the statusCake configuration is required"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateProvider(newFactory(), &v1alpha1.Provider{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_ClusterProvider(t *testing.T) {
+	tcs := []struct {
+		name string
+		spec v1alpha1.ClusterProviderSpec
+		errs []string
+	}{
+		{"registered provider", v1alpha1.ClusterProviderSpec{ProviderSpec: v1alpha1.ProviderSpec{Type: "Simple"}}, nil},
+		{"unknown type", v1alpha1.ClusterProviderSpec{ProviderSpec: v1alpha1.ProviderSpec{Type: "Unknown"}}, []string{`spec.type: Unsupported value: "Unknown"`}},
+		{
+			"with allowed namespaces",
+			v1alpha1.ClusterProviderSpec{
+				ProviderSpec: v1alpha1.ProviderSpec{Type: "Simple"},
+				AllowedNamespaces: &v1alpha1.AllowedNamespaces{
+					Names:    []string{"websites"},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "gophers"}},
+				},
+			},
+			nil,
+		},
+		{
+			"with an invalid namespace selector",
+			v1alpha1.ClusterProviderSpec{
+				ProviderSpec: v1alpha1.ProviderSpec{Type: "Simple"},
+				AllowedNamespaces: &v1alpha1.AllowedNamespaces{
+					Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: "Matches"},
+					}},
+				},
+			},
+			[]string{"spec.allowedNamespaces.selector: Invalid value"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateClusterProvider(newFactory(), &v1alpha1.ClusterProvider{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_MonitorTemplate(t *testing.T) {
+	tcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+		errs []string
+	}{
+		{
+			"valid HTTP template",
+			v1alpha1.MonitorTemplateSpec{
+				Name:      "{{.Host}}",
+				Type:      v1alpha1.CheckTypeHTTP,
+				CheckRate: ptrString("30s"),
+				Timeout:   ptrString("5s"),
+				HTTP:      &v1alpha1.HTTPTemplate{Endpoint: ptrString("/_healthz")},
+			},
+			nil,
+		},
+		{
+			"valid TCP template",
+			v1alpha1.MonitorTemplateSpec{Name: "{{.Host}}", Type: v1alpha1.CheckTypeTCP},
+			nil,
+		},
+		{
+			"HTTP template without http",
+			v1alpha1.MonitorTemplateSpec{Name: "test", Type: v1alpha1.CheckTypeHTTP},
+			[]string{"spec.http: Required value"},
+		},
+		{
+			"unknown type",
+			v1alpha1.MonitorTemplateSpec{Name: "test", Type: "UDP"},
+			[]string{`spec.type: Unsupported value: "UDP"`},
+		},
+		{
+			"invalid durations",
+			v1alpha1.MonitorTemplateSpec{
+				Name:      "test",
+				Type:      v1alpha1.CheckTypeTCP,
+				CheckRate: ptrString("often"),
+				Timeout:   ptrString("-5s"),
+			},
+			[]string{"spec.checkRate: Invalid value", "spec.timeout: Invalid value"},
+		},
+		{
+			"negative confirmations",
+			v1alpha1.MonitorTemplateSpec{Name: "test", Type: v1alpha1.CheckTypeTCP, Confirmations: ptrInt(-1)},
+			[]string{"spec.confirmations: Invalid value"},
+		},
+		{
+			"broken template",
+			v1alpha1.MonitorTemplateSpec{Name: "{{.Host", Type: v1alpha1.CheckTypeTCP},
+			[]string{"Could not parse name"},
+		},
+		{
+			"template with an unknown value",
+			v1alpha1.MonitorTemplateSpec{Name: "{{.Hostname}}", Type: v1alpha1.CheckTypeTCP},
+			[]string{"Could not render name"},
+		},
+		{
+			"template with a default for an optional label",
+			v1alpha1.MonitorTemplateSpec{Name: `{{index .Labels "team" | default "unowned"}}`, Type: v1alpha1.CheckTypeTCP},
+			nil,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateMonitorTemplate(&v1alpha1.MonitorTemplate{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+
+			errs = validateClusterMonitorTemplate(&v1alpha1.ClusterMonitorTemplate{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_Monitor(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}
+	refs := v1alpha1.MonitorSpec{
+		Provider: &v1alpha1.ProviderReference{Name: "provider"},
+		Template: v1alpha1.TemplateReference{Name: "template"},
+	}
+
+	withRefs := func(spec v1alpha1.MonitorSpec) v1alpha1.MonitorSpec {
+		spec.Provider = refs.Provider
+		spec.Template = refs.Template
+		return spec
+	}
+
+	tcs := []struct {
+		name string
+		spec v1alpha1.MonitorSpec
+		errs []string
+	}{
+		{"valid Monitor", withRefs(v1alpha1.MonitorSpec{Selector: selector}), nil},
+		{
+			"without selector",
+			withRefs(v1alpha1.MonitorSpec{}),
+			[]string{"spec.selector: Required value"},
+		},
+		{
+			"with invalid selector",
+			withRefs(v1alpha1.MonitorSpec{Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Matches"},
+				},
+			}}),
+			[]string{"spec.selector: Invalid value"},
+		},
+		{
+			"with unknown source kind",
+			withRefs(v1alpha1.MonitorSpec{Selector: selector, SourceKind: "Deployment"}),
+			[]string{`spec.sourceKind: Unsupported value: "Deployment"`},
+		},
+		{
+			"perPath for Services",
+			withRefs(v1alpha1.MonitorSpec{Selector: selector, SourceKind: v1alpha1.SourceKindService, PerPath: true}),
+			[]string{"spec.perPath: Forbidden"},
+		},
+		{
+			"without references",
+			v1alpha1.MonitorSpec{Selector: selector},
+			[]string{"spec.provider: Required value", "spec.template.name: Required value"},
+		},
+		{
+			"with cluster references",
+			v1alpha1.MonitorSpec{
+				Selector: selector,
+				Provider: &v1alpha1.ProviderReference{Kind: v1alpha1.ClusterProviderKind, Name: "provider"},
+				Template: v1alpha1.TemplateReference{Kind: v1alpha1.ClusterMonitorTemplateKind, Name: "template"},
+			},
+			nil,
+		},
+		{
+			"with unknown reference kinds",
+			v1alpha1.MonitorSpec{
+				Selector: selector,
+				Provider: &v1alpha1.ProviderReference{Kind: "Secret", Name: "provider"},
+				Template: v1alpha1.TemplateReference{Kind: v1alpha1.ClusterProviderKind, Name: "template"},
+			},
+			[]string{`spec.provider.kind: Unsupported value: "Secret"`, `spec.template.kind: Unsupported value: "ClusterProvider"`},
+		},
+		{
+			"with multiple providers",
+			v1alpha1.MonitorSpec{
+				Selector: selector,
+				Providers: []v1alpha1.ProviderReference{
+					{Name: "provider"},
+					{Kind: v1alpha1.ClusterProviderKind, Name: "provider"},
+				},
+				Template: refs.Template,
+			},
+			nil,
+		},
+		{
+			"with provider and providers",
+			v1alpha1.MonitorSpec{
+				Selector:  selector,
+				Provider:  refs.Provider,
+				Providers: []v1alpha1.ProviderReference{{Name: "other-provider"}},
+				Template:  refs.Template,
+			},
+			[]string{"spec.providers: Forbidden"},
+		},
+		{
+			"with invalid providers",
+			v1alpha1.MonitorSpec{
+				Selector: selector,
+				Providers: []v1alpha1.ProviderReference{
+					{Name: "provider"},
+					{Kind: v1alpha1.ProviderKind, Name: "provider"},
+					{Kind: "Secret"},
+				},
+				Template: refs.Template,
+			},
+			[]string{"spec.providers[1]: Duplicate value", `spec.providers[2].kind: Unsupported value: "Secret"`, "spec.providers[2].name: Required value"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateMonitor(&v1alpha1.Monitor{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_IngressMonitor(t *testing.T) {
+	provider := v1alpha1.NamespacedProvider{ProviderSpec: v1alpha1.ProviderSpec{Type: "Simple"}}
+
+	tcs := []struct {
+		name string
+		spec v1alpha1.IngressMonitorSpec
+		errs []string
+	}{
+		{
+			"valid HTTP check",
+			v1alpha1.IngressMonitorSpec{
+				Provider: provider,
+				Template: v1alpha1.MonitorTemplateSpec{
+					Name: "test",
+					Type: v1alpha1.CheckTypeHTTP,
+					HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com/_healthz"},
+				},
+			},
+			nil,
+		},
+		{
+			"HTTP check without URL",
+			v1alpha1.IngressMonitorSpec{
+				Provider: provider,
+				Template: v1alpha1.MonitorTemplateSpec{
+					Name: "test",
+					Type: v1alpha1.CheckTypeHTTP,
+					HTTP: &v1alpha1.HTTPTemplate{},
+				},
+			},
+			[]string{"spec.template.http.url: Required value"},
+		},
+		{
+			"TCP check without port",
+			v1alpha1.IngressMonitorSpec{
+				Provider: provider,
+				Template: v1alpha1.MonitorTemplateSpec{
+					Name: "test",
+					Type: v1alpha1.CheckTypeTCP,
+					TCP:  &v1alpha1.TCPTemplate{Host: "example.com"},
+				},
+			},
+			[]string{"spec.template.tcp: Required value"},
+		},
+		{
+			"unknown provider",
+			v1alpha1.IngressMonitorSpec{
+				Provider: v1alpha1.NamespacedProvider{ProviderSpec: v1alpha1.ProviderSpec{Type: "Unknown"}},
+				Template: v1alpha1.MonitorTemplateSpec{
+					Name: "test",
+					Type: v1alpha1.CheckTypeTCP,
+					TCP:  &v1alpha1.TCPTemplate{Host: "example.com", Port: 443},
+				},
+			},
+			[]string{`spec.provider.type: Unsupported value: "Unknown"`},
+		},
+		{
+			"TCP check with a provider which only supports HTTP",
+			v1alpha1.IngressMonitorSpec{
+				Provider: v1alpha1.NamespacedProvider{ProviderSpec: v1alpha1.ProviderSpec{Type: "HTTPOnly"}},
+				Template: v1alpha1.MonitorTemplateSpec{
+					Name: "test",
+					Type: v1alpha1.CheckTypeTCP,
+					TCP:  &v1alpha1.TCPTemplate{Host: "example.com", Port: 443},
+				},
+			},
+			[]string{`spec.template.type: Invalid value: "TCP": HTTPOnly only supports HTTP checks, got 'TCP'`},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateIngressMonitor(newFactory(), &v1alpha1.IngressMonitor{Spec: tc.spec})
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_IngressMonitorProviderNamespace(t *testing.T) {
+	monitorReference := metav1.OwnerReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "Monitor",
+		Name:       "test-monitor",
+	}
+	ingressReference := metav1.OwnerReference{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Name:       "test-ingress",
+	}
+
+	tcs := []struct {
+		name      string
+		namespace string
+		owners    []metav1.OwnerReference
+		errs      []string
+	}{
+		{"own namespace", "testing", nil, nil},
+		{"other namespace", "ingress-monitor", nil, []string{"spec.provider.namespace: Forbidden"}},
+		{"other namespace owned by an Ingress", "ingress-monitor", []metav1.OwnerReference{ingressReference}, []string{"spec.provider.namespace: Forbidden"}},
+		{"other namespace owned by a Monitor", "ingress-monitor", []metav1.OwnerReference{ingressReference, monitorReference}, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			im := &v1alpha1.IngressMonitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testing", OwnerReferences: tc.owners},
+				Spec: v1alpha1.IngressMonitorSpec{
+					Provider: v1alpha1.NamespacedProvider{
+						Namespace:    tc.namespace,
+						ProviderSpec: v1alpha1.ProviderSpec{Type: "Simple"},
+					},
+					Template: v1alpha1.MonitorTemplateSpec{
+						Name: "test",
+						Type: v1alpha1.CheckTypeHTTP,
+						HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com/_healthz"},
+					},
+				},
+			}
+
+			errs := validateIngressMonitor(newFactory(), im)
+			expectErrors(t, errs.ToAggregate(), tc.errs)
+		})
+	}
+}
+
+func TestValidator_ServeHTTP(t *testing.T) {
+	srv := httptest.NewServer(NewValidator(newFactory()))
+	defer srv.Close()
+
+	send := func(t *testing.T, op admissionv1.Operation, kind string, obj interface{}) *admissionv1.AdmissionResponse {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("Could not encode object: %s", err)
+		}
+
+		review := admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       types.UID("review-uid"),
+				Kind:      metav1.GroupVersionKind{Group: v1alpha1.GroupName, Version: "v1alpha1", Kind: kind},
+				Name:      "test",
+				Operation: op,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+
+		body, err := json.Marshal(review)
+		if err != nil {
+			t.Fatalf("Could not encode AdmissionReview: %s", err)
+		}
+
+		resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Could not send AdmissionReview: %s", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+
+		var result admissionv1.AdmissionReview
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("Could not decode AdmissionReview: %s", err)
+		}
+
+		if result.Response == nil || result.Response.UID != "review-uid" {
+			t.Fatalf("Expected a response for the request, got %#v", result.Response)
+		}
+
+		return result.Response
+	}
+
+	invalid := v1alpha1.Provider{Spec: v1alpha1.ProviderSpec{Type: "StatusCake"}}
+
+	t.Run("with a valid object", func(t *testing.T) {
+		resp := send(t, admissionv1.Create, "Provider", v1alpha1.Provider{Spec: v1alpha1.ProviderSpec{Type: "Simple"}})
+		if !resp.Allowed {
+			t.Errorf("Expected the Provider to be allowed, got %s", resp.Result.Message)
+		}
+	})
+
+	t.Run("with an invalid object", func(t *testing.T) {
+		resp := send(t, admissionv1.Update, "Provider", invalid)
+		if resp.Allowed {
+			t.Fatalf("Expected the Provider to be denied")
+		}
+
+		if !strings.Contains(resp.Result.Message, "statusCake configuration is required") {
+			t.Errorf("Expected the message to describe the error, got %s", resp.Result.Message)
+		}
+	})
+
+	t.Run("when deleting", func(t *testing.T) {
+		resp := send(t, admissionv1.Delete, "Provider", invalid)
+		if !resp.Allowed {
+			t.Errorf("Expected deletes to be allowed")
+		}
+	})
+
+	t.Run("with an unknown kind", func(t *testing.T) {
+		resp := send(t, admissionv1.Create, "Secret", invalid)
+		if resp.Allowed {
+			t.Errorf("Expected unknown kinds to be denied")
+		}
+	})
+
+	t.Run("with a malformed body", func(t *testing.T) {
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader("{"))
+		if err != nil {
+			t.Fatalf("Could not send request: %s", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestValidator_UpdateInvalidObject(t *testing.T) {
+	// The stored IngressMonitor references a provider which isn't valid
+	// anymore, it should still be possible to finalize it.
+	old := v1alpha1.IngressMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Finalizers: []string{"ingressmonitor.sphc.io/provider-cleanup"},
+		},
+		Spec: v1alpha1.IngressMonitorSpec{
+			Provider: v1alpha1.NamespacedProvider{ProviderSpec: v1alpha1.ProviderSpec{Type: "StatusCake"}},
+			Template: v1alpha1.MonitorTemplateSpec{
+				Name: "test",
+				Type: v1alpha1.CheckTypeHTTP,
+				HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com"},
+			},
+		},
+	}
+
+	now := metav1.Now()
+
+	tcs := []struct {
+		name    string
+		mutate  func(im *v1alpha1.IngressMonitor)
+		allowed bool
+	}{
+		{
+			"removing the finalizer",
+			func(im *v1alpha1.IngressMonitor) { im.Finalizers = nil },
+			true,
+		},
+		{
+			"updating the status",
+			func(im *v1alpha1.IngressMonitor) { im.Status.ID = "12345" },
+			true,
+		},
+		{
+			"updating the spec while being deleted",
+			func(im *v1alpha1.IngressMonitor) {
+				im.DeletionTimestamp = &now
+				im.Spec.Template.Name = "renamed"
+			},
+			true,
+		},
+		{
+			"updating the spec",
+			func(im *v1alpha1.IngressMonitor) { im.Spec.Template.Name = "renamed" },
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := old.DeepCopy()
+			tc.mutate(obj)
+
+			req := &admissionv1.AdmissionRequest{
+				UID:       types.UID("review-uid"),
+				Kind:      metav1.GroupVersionKind{Group: v1alpha1.GroupName, Version: "v1alpha1", Kind: "IngressMonitor"},
+				Name:      "test",
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: mustMarshal(t, obj)},
+				OldObject: runtime.RawExtension{Raw: mustMarshal(t, old)},
+			}
+
+			resp := NewValidator(newFactory()).review(req)
+			if resp.Allowed != tc.allowed {
+				t.Errorf("Expected allowed to be %t, got %t", tc.allowed, resp.Allowed)
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, obj interface{}) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Could not encode object: %s", err)
+	}
+
+	return raw
+}
+
+func expectErrors(t *testing.T, err error, expected []string) {
+	t.Helper()
+
+	if len(expected) == 0 {
+		if err != nil {
+			t.Errorf("Expected no errors, got %s", err)
+		}
+		return
+	}
+
+	if err == nil {
+		t.Fatalf("Expected errors %v, got none", expected)
+	}
+
+	for _, exp := range expected {
+		if !strings.Contains(err.Error(), exp) {
+			t.Errorf("Expected error to contain `%s`, got `%s`", exp, err)
+		}
+	}
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
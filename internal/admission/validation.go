@@ -0,0 +1,285 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/ingressmonitor"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// checkTypes are the types of checks a MonitorTemplate can configure.
+	checkTypes = []string{v1alpha1.CheckTypeHTTP, v1alpha1.CheckTypeTCP}
+
+	// providerKinds are the kinds of providers a Monitor can reference.
+	providerKinds = []string{v1alpha1.ProviderKind, v1alpha1.ClusterProviderKind}
+
+	// templateKinds are the kinds of templates a Monitor can reference.
+	templateKinds = []string{v1alpha1.MonitorTemplateKind, v1alpha1.ClusterMonitorTemplateKind}
+)
+
+// validateProvider validates a Provider against the providers which are
+// registered with the factory.
+func validateProvider(fact provider.FactoryInterface, prov *v1alpha1.Provider) field.ErrorList {
+	return validateProviderSpec(fact, prov.Spec, field.NewPath("spec"))
+}
+
+// validateClusterProvider validates a ClusterProvider and the namespaces it
+// allows.
+func validateClusterProvider(fact provider.FactoryInterface, prov *v1alpha1.ClusterProvider) field.ErrorList {
+	fldPath := field.NewPath("spec")
+
+	allErrs := validateProviderSpec(fact, prov.Spec.ProviderSpec, fldPath)
+
+	if allowed := prov.Spec.AllowedNamespaces; allowed != nil && allowed.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(allowed.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedNamespaces", "selector"), allowed.Selector, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateProviderSpec(fact provider.FactoryInterface, spec v1alpha1.ProviderSpec, fldPath *field.Path) field.ErrorList {
+	if spec.Type == "" {
+		return field.ErrorList{field.Required(fldPath.Child("type"), "")}
+	}
+
+	if err := fact.Validate(spec); err == provider.ErrProviderNotFound {
+		return field.ErrorList{field.NotSupported(fldPath.Child("type"), spec.Type, nil)}
+	} else if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, spec.Type, err.Error())}
+	}
+
+	return nil
+}
+
+// validateMonitorTemplate validates a MonitorTemplate, including the templates
+// in its fields.
+func validateMonitorTemplate(tmpl *v1alpha1.MonitorTemplate) field.ErrorList {
+	return validateRenderableTemplateSpec(tmpl.Spec, field.NewPath("spec"))
+}
+
+// validateClusterMonitorTemplate validates a ClusterMonitorTemplate, including
+// the templates in its fields.
+func validateClusterMonitorTemplate(tmpl *v1alpha1.ClusterMonitorTemplate) field.ErrorList {
+	return validateRenderableTemplateSpec(tmpl.Spec, field.NewPath("spec"))
+}
+
+func validateRenderableTemplateSpec(spec v1alpha1.MonitorTemplateSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateTemplateSpec(spec, fldPath)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	// Render the templates once, so a broken template doesn't fail every
+	// IngressMonitor which is set up with it.
+	if err := ingressmonitor.ValidateTemplateSpec(spec); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.Name, err.Error()))
+	}
+
+	return allErrs
+}
+
+// validateTemplateSpec validates the parts of a MonitorTemplateSpec which are
+// shared between MonitorTemplates and IngressMonitors.
+func validateTemplateSpec(spec v1alpha1.MonitorTemplateSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch spec.Type {
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	case v1alpha1.CheckTypeHTTP:
+		if spec.HTTP == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("http"), "required for HTTP checks"))
+		}
+	case v1alpha1.CheckTypeTCP:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), spec.Type, checkTypes))
+	}
+
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+
+	allErrs = append(allErrs, validateDuration(spec.CheckRate, fldPath.Child("checkRate"))...)
+	allErrs = append(allErrs, validateDuration(spec.Timeout, fldPath.Child("timeout"))...)
+
+	if spec.Confirmations != nil && *spec.Confirmations < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("confirmations"), *spec.Confirmations, "must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+// validateDuration validates that an optional duration parses and is
+// positive.
+func validateDuration(d *string, fldPath *field.Path) field.ErrorList {
+	if d == nil {
+		return nil
+	}
+
+	dur, err := time.ParseDuration(*d)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, *d, "must be a duration, like 30s or 5m")}
+	}
+
+	if dur <= 0 {
+		return field.ErrorList{field.Invalid(fldPath, *d, "must be positive")}
+	}
+
+	return nil
+}
+
+// validateMonitor validates the selection and references of a Monitor.
+func validateMonitor(mon *v1alpha1.Monitor) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec")
+
+	kind := mon.Spec.SourceKind
+	if kind == "" {
+		kind = v1alpha1.SourceKindIngress
+	}
+
+	var knownKind bool
+	for _, k := range v1alpha1.SourceKinds {
+		knownKind = knownKind || k == kind
+	}
+	if !knownKind {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sourceKind"), mon.Spec.SourceKind, v1alpha1.SourceKinds))
+	}
+
+	if mon.Spec.Selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("selector"), ""))
+	} else if _, err := metav1.LabelSelectorAsSelector(mon.Spec.Selector); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), mon.Spec.Selector, err.Error()))
+	}
+
+	if mon.Spec.IngressClassName != "" && kind != v1alpha1.SourceKindIngress {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingressClassName"), "only supported when the sourceKind is Ingress"))
+	}
+
+	if mon.Spec.PerPath && kind != v1alpha1.SourceKindIngress {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("perPath"), "only supported when the sourceKind is Ingress"))
+	}
+
+	if mon.Spec.Service != nil && kind != v1alpha1.SourceKindService {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("service"), "only supported when the sourceKind is Service"))
+	}
+
+	allErrs = append(allErrs, validateProviderReferences(mon, fldPath)...)
+	allErrs = append(allErrs, validateReference(mon.Spec.Template.Kind, mon.Spec.Template.Name, templateKinds, fldPath.Child("template"))...)
+
+	return allErrs
+}
+
+// validateProviderReferences validates the providers of a Monitor. These are
+// either set through the single provider or through the list of providers,
+// every provider can only be referenced once.
+func validateProviderReferences(mon *v1alpha1.Monitor, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case mon.Spec.Provider != nil && len(mon.Spec.Providers) > 0:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("providers"), "may not be set together with provider"))
+	case mon.Spec.Provider != nil:
+		ref := mon.Spec.Provider
+		allErrs = append(allErrs, validateReference(ref.Kind, ref.Name, providerKinds, fldPath.Child("provider"))...)
+	case len(mon.Spec.Providers) > 0:
+		seen := map[v1alpha1.ProviderReference]bool{}
+		for i, ref := range mon.Spec.Providers {
+			idxPath := fldPath.Child("providers").Index(i)
+			allErrs = append(allErrs, validateReference(ref.Kind, ref.Name, providerKinds, idxPath)...)
+
+			if ref.Kind == "" {
+				ref.Kind = v1alpha1.ProviderKind
+			}
+			if seen[ref] {
+				allErrs = append(allErrs, field.Duplicate(idxPath, ref))
+			}
+			seen[ref] = true
+		}
+	default:
+		allErrs = append(allErrs, field.Required(fldPath.Child("provider"), "either provider or providers is required"))
+	}
+
+	return allErrs
+}
+
+// validateReference validates a reference to one of the given kinds. An
+// empty kind references the first kind.
+func validateReference(kind, name string, kinds []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if kind != "" {
+		var knownKind bool
+		for _, k := range kinds {
+			knownKind = knownKind || k == kind
+		}
+		if !knownKind {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), kind, kinds))
+		}
+	}
+
+	if name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+
+	return allErrs
+}
+
+// validateIngressMonitor validates an IngressMonitor. The template of an
+// IngressMonitor has already been rendered, it describes the actual check.
+func validateIngressMonitor(fact provider.FactoryInterface, im *v1alpha1.IngressMonitor) field.ErrorList {
+	fldPath := field.NewPath("spec")
+
+	allErrs := validateProviderSpec(fact, im.Spec.Provider.ProviderSpec, fldPath.Child("provider"))
+
+	// The secrets of the provider are looked up in its namespace. Only
+	// IngressMonitors set up by a Monitor may use the secrets of a
+	// ClusterProvider, which live in the namespace for cluster resources. The
+	// Operator checks whether the Monitor is allowed to use the
+	// ClusterProvider before syncing the IngressMonitor.
+	if im.Spec.Provider.Namespace != im.Namespace && !ownedByMonitor(im) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("provider", "namespace"), "may only reference the namespace of the IngressMonitor"))
+	}
+
+	tplPath := fldPath.Child("template")
+	allErrs = append(allErrs, validateTemplateSpec(im.Spec.Template, tplPath)...)
+
+	// Checks the provider can't set up would fail every sync.
+	if err := fact.ValidateCheckType(im.Spec.Provider.Type, im.Spec.Template.Type); err != nil && im.Spec.Template.Type != "" {
+		allErrs = append(allErrs, field.Invalid(tplPath.Child("type"), im.Spec.Template.Type, err.Error()))
+	}
+
+	switch im.Spec.Template.Type {
+	case v1alpha1.CheckTypeHTTP:
+		if im.Spec.Template.HTTP != nil && im.Spec.Template.HTTP.URL == "" {
+			allErrs = append(allErrs, field.Required(tplPath.Child("http", "url"), ""))
+		}
+	case v1alpha1.CheckTypeTCP:
+		tcp := im.Spec.Template.TCP
+		if tcp == nil || tcp.Host == "" || tcp.Port == 0 {
+			allErrs = append(allErrs, field.Required(tplPath.Child("tcp"), "the host and port are required for TCP checks"))
+		}
+	}
+
+	return allErrs
+}
+
+// ownedByMonitor reports whether the IngressMonitor is owned by a Monitor.
+func ownedByMonitor(im *v1alpha1.IngressMonitor) bool {
+	for _, ref := range im.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && gv.Group == v1alpha1.GroupName && ref.Kind == "Monitor" {
+			return true
+		}
+	}
+
+	return false
+}
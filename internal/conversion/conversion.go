@@ -0,0 +1,197 @@
+// Package conversion implements the conversion webhook for the IngressMonitor
+// CRDs. Objects are stored as v1alpha1, the API server calls the webhook to
+// serve them as v1beta1 and to store v1beta1 objects.
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxRequestSize is the maximum size of a ConversionReview we accept. The API
+// server sends lists in one review, which can be larger than a single object.
+const maxRequestSize = 32 << 20
+
+// The API server sends apiextensions.k8s.io/v1 ConversionReviews. These types
+// describe the parts of the review the webhook uses, so we don't depend on the
+// apiextensions apiserver.
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// converters convert a JSON encoded object of the given kind. When toBeta is
+// set, the object is converted from v1alpha1 to v1beta1, otherwise from
+// v1beta1 to v1alpha1.
+var converters = map[string]func(raw []byte, toBeta bool) (runtime.Object, error){
+	"Provider": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.Provider{}, &v1beta1.Provider{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+	"ClusterProvider": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.ClusterProvider{}, &v1beta1.ClusterProvider{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+	"MonitorTemplate": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.MonitorTemplate{}, &v1beta1.MonitorTemplate{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+	"ClusterMonitorTemplate": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.ClusterMonitorTemplate{}, &v1beta1.ClusterMonitorTemplate{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+	"Monitor": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.Monitor{}, &v1beta1.Monitor{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+	"IngressMonitor": func(raw []byte, toBeta bool) (runtime.Object, error) {
+		alpha, beta := &v1alpha1.IngressMonitor{}, &v1beta1.IngressMonitor{}
+		if toBeta {
+			return beta, decodeAndConvert(raw, alpha, func() error { return beta.ConvertFrom(alpha) })
+		}
+
+		return alpha, decodeAndConvert(raw, beta, func() error { return beta.ConvertTo(alpha) })
+	},
+}
+
+func decodeAndConvert(raw []byte, in interface{}, convert func() error) error {
+	if err := json.Unmarshal(raw, in); err != nil {
+		return fmt.Errorf("Could not decode object: %s", err)
+	}
+
+	return convert()
+}
+
+// Handler is a http.Handler which converts the objects in the
+// ConversionReviews it receives to the desired API version.
+type Handler struct{}
+
+// NewHandler creates a new conversion Handler.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// ServeHTTP decodes the ConversionReview, converts the objects in it and
+// writes the response back.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review conversionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("Could not decode ConversionReview: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, "The ConversionReview doesn't contain a request", http.StatusBadRequest)
+		return
+	}
+
+	resp := &conversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+
+	for _, obj := range review.Request.Objects {
+		converted, err := Convert(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			log.Printf("Could not convert object to %s: %s", review.Request.DesiredAPIVersion, err)
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	review.Request = nil
+	review.Response = resp
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Printf("Could not write ConversionReview response: %s", err)
+	}
+}
+
+// Convert converts the JSON encoded object to the desired API version.
+func Convert(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var tm metav1.TypeMeta
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return nil, fmt.Errorf("Could not decode object: %s", err)
+	}
+
+	if tm.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	convert, ok := converters[tm.Kind]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported kind '%s'", tm.Kind)
+	}
+
+	alpha, beta := v1alpha1.SchemeGroupVersion, v1beta1.SchemeGroupVersion
+
+	var desired schema.GroupVersion
+	switch {
+	case tm.APIVersion == alpha.String() && desiredAPIVersion == beta.String():
+		desired = beta
+	case tm.APIVersion == beta.String() && desiredAPIVersion == alpha.String():
+		desired = alpha
+	default:
+		return nil, fmt.Errorf("Unsupported conversion from %s to %s", tm.APIVersion, desiredAPIVersion)
+	}
+
+	out, err := convert(raw, desired == beta)
+	if err != nil {
+		return nil, fmt.Errorf("Could not convert %s: %s", tm.Kind, err)
+	}
+
+	out.GetObjectKind().SetGroupVersionKind(desired.WithKind(tm.Kind))
+	return json.Marshal(out)
+}
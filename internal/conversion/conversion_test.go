@@ -0,0 +1,232 @@
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	srv := httptest.NewServer(NewHandler())
+	defer srv.Close()
+
+	send := func(t *testing.T, desired string, objs ...interface{}) *conversionResponse {
+		review := conversionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+			Request:  &conversionRequest{UID: types.UID("review-uid"), DesiredAPIVersion: desired},
+		}
+
+		for _, obj := range objs {
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				t.Fatalf("Could not encode object: %s", err)
+			}
+
+			review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: raw})
+		}
+
+		body, err := json.Marshal(review)
+		if err != nil {
+			t.Fatalf("Could not encode ConversionReview: %s", err)
+		}
+
+		resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Could not send ConversionReview: %s", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+
+		var result conversionReview
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("Could not decode ConversionReview: %s", err)
+		}
+
+		if result.Response == nil || result.Response.UID != "review-uid" {
+			t.Fatalf("Expected a response for the request, got %#v", result.Response)
+		}
+
+		return result.Response
+	}
+
+	rate := "30s"
+	alpha := v1alpha1.MonitorTemplate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "MonitorTemplate"},
+		ObjectMeta: metav1.ObjectMeta{Name: "go-http", Namespace: "default"},
+		Spec: v1alpha1.MonitorTemplateSpec{
+			Name:      "{{.Host}}",
+			Type:      v1alpha1.CheckTypeHTTP,
+			CheckRate: &rate,
+			HTTP:      &v1alpha1.HTTPTemplate{CustomHeader: "X-Team: gophers"},
+		},
+	}
+
+	t.Run("from v1alpha1 to v1beta1", func(t *testing.T) {
+		resp := send(t, v1beta1.SchemeGroupVersion.String(), alpha)
+		if resp.Result.Status != metav1.StatusSuccess {
+			t.Fatalf("Expected the conversion to succeed, got %s", resp.Result.Message)
+		}
+
+		if len(resp.ConvertedObjects) != 1 {
+			t.Fatalf("Expected 1 converted object, got %d", len(resp.ConvertedObjects))
+		}
+
+		var beta v1beta1.MonitorTemplate
+		if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, &beta); err != nil {
+			t.Fatalf("Could not decode the converted object: %s", err)
+		}
+
+		if beta.APIVersion != v1beta1.SchemeGroupVersion.String() || beta.Kind != "MonitorTemplate" {
+			t.Errorf("Expected a v1beta1 MonitorTemplate, got %s %s", beta.APIVersion, beta.Kind)
+		}
+
+		if beta.Name != "go-http" || beta.Namespace != "default" {
+			t.Errorf("Expected the metadata to be kept, got %s/%s", beta.Namespace, beta.Name)
+		}
+
+		if beta.Spec.CheckRate == nil || beta.Spec.CheckRate.Duration != 30*time.Second {
+			t.Errorf("Expected the checkRate to be 30s, got %v", beta.Spec.CheckRate)
+		}
+
+		if beta.Spec.HTTP == nil || beta.Spec.HTTP.Headers["X-Team"] != "gophers" {
+			t.Errorf("Expected the headers to be converted, got %#v", beta.Spec.HTTP)
+		}
+	})
+
+	t.Run("from v1beta1 to v1alpha1", func(t *testing.T) {
+		beta := v1beta1.MonitorTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "MonitorTemplate"},
+			ObjectMeta: alpha.ObjectMeta,
+			Spec: v1beta1.MonitorTemplateSpec{
+				Name:      "{{.Host}}",
+				Type:      v1alpha1.CheckTypeHTTP,
+				CheckRate: &metav1.Duration{Duration: 30 * time.Second},
+				HTTP:      &v1beta1.HTTPTemplate{Headers: map[string]string{"X-Team": "gophers"}},
+			},
+		}
+
+		resp := send(t, v1alpha1.SchemeGroupVersion.String(), beta)
+		if resp.Result.Status != metav1.StatusSuccess {
+			t.Fatalf("Expected the conversion to succeed, got %s", resp.Result.Message)
+		}
+
+		var converted v1alpha1.MonitorTemplate
+		if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, &converted); err != nil {
+			t.Fatalf("Could not decode the converted object: %s", err)
+		}
+
+		if converted.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+			t.Errorf("Expected a v1alpha1 object, got %s", converted.APIVersion)
+		}
+
+		if *converted.Spec.CheckRate != "30s" || converted.Spec.HTTP.CustomHeader != "X-Team: gophers" {
+			t.Errorf("Expected the spec to be converted, got %#v", converted.Spec)
+		}
+	})
+
+	t.Run("with the desired version", func(t *testing.T) {
+		resp := send(t, v1alpha1.SchemeGroupVersion.String(), alpha)
+		if resp.Result.Status != metav1.StatusSuccess {
+			t.Fatalf("Expected the conversion to succeed, got %s", resp.Result.Message)
+		}
+
+		var converted v1alpha1.MonitorTemplate
+		if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, &converted); err != nil {
+			t.Fatalf("Could not decode the converted object: %s", err)
+		}
+
+		if *converted.Spec.CheckRate != rate {
+			t.Errorf("Expected the object to be returned as is, got %#v", converted.Spec)
+		}
+	})
+
+	t.Run("with a value which can't be represented in v1beta1", func(t *testing.T) {
+		invalid := "60"
+		legacy := alpha.DeepCopy()
+		legacy.Spec.CheckRate = &invalid
+
+		resp := send(t, v1beta1.SchemeGroupVersion.String(), legacy)
+		if resp.Result.Status != metav1.StatusSuccess {
+			t.Fatalf("Expected the conversion to succeed, got %s", resp.Result.Message)
+		}
+
+		var beta v1beta1.MonitorTemplate
+		if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, &beta); err != nil {
+			t.Fatalf("Could not decode the converted object: %s", err)
+		}
+
+		if beta.Spec.CheckRate != nil {
+			t.Errorf("Expected no checkRate, got %s", beta.Spec.CheckRate.Duration)
+		}
+
+		if !strings.Contains(beta.Annotations[v1beta1.ConversionAnnotation], `"checkRate":"60"`) {
+			t.Errorf("Expected the checkRate to be kept in an annotation, got %v", beta.Annotations)
+		}
+	})
+
+	t.Run("with an object which can't be converted", func(t *testing.T) {
+		beta := v1beta1.Provider{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "Provider"},
+			ObjectMeta: metav1.ObjectMeta{Name: "logger", Namespace: "default"},
+			Spec: v1beta1.ProviderSpec{
+				Type:   "Logger",
+				Config: &runtime.RawExtension{Raw: []byte(`{"level":"debug"}`)},
+			},
+		}
+
+		resp := send(t, v1alpha1.SchemeGroupVersion.String(), alpha, beta)
+		if resp.Result.Status != metav1.StatusFailure {
+			t.Fatalf("Expected the conversion to fail")
+		}
+
+		if len(resp.ConvertedObjects) != 0 {
+			t.Errorf("Expected no converted objects, got %d", len(resp.ConvertedObjects))
+		}
+
+		if !strings.Contains(resp.Result.Message, "Logger") {
+			t.Errorf("Expected the message to describe the error, got %s", resp.Result.Message)
+		}
+	})
+
+	t.Run("with an unknown kind", func(t *testing.T) {
+		obj := metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Secret"}
+
+		resp := send(t, v1beta1.SchemeGroupVersion.String(), obj)
+		if resp.Result.Status != metav1.StatusFailure {
+			t.Errorf("Expected unknown kinds to fail")
+		}
+	})
+
+	t.Run("with an unknown version", func(t *testing.T) {
+		resp := send(t, "ingressmonitor.sphc.io/v2", alpha)
+		if resp.Result.Status != metav1.StatusFailure {
+			t.Errorf("Expected unknown versions to fail")
+		}
+	})
+
+	t.Run("with a malformed body", func(t *testing.T) {
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader("{"))
+		if err != nil {
+			t.Fatalf("Could not send request: %s", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -14,6 +14,11 @@ type Server struct {
 
 	Addr string
 	Port int
+
+	// CertFile and KeyFile are the paths to the TLS certificate and key the
+	// Server should use. The Server serves plain HTTP when they're not set.
+	CertFile string
+	KeyFile  string
 }
 
 // Start starts the HTTP Server.
@@ -34,5 +39,9 @@ func (s *Server) Start(stopCh <-chan struct{}) error {
 		srv.Shutdown(ctx)
 	}()
 
+	if s.CertFile != "" || s.KeyFile != "" {
+		return srv.ListenAndServeTLS(s.CertFile, s.KeyFile)
+	}
+
 	return srv.ListenAndServe()
 }
@@ -0,0 +1,148 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// These are the annotations which can be set on a selected object to override
+// the MonitorTemplate for that object.
+const (
+	annotationPrefix = "ingressmonitor.sphc.io/"
+
+	// enabledAnnotation opts an object out of monitoring when set to false.
+	enabledAnnotation = annotationPrefix + "enabled"
+
+	// endpointAnnotation overrides the health endpoint of HTTP checks.
+	endpointAnnotation = annotationPrefix + "endpoint"
+
+	// shouldContainAnnotation overrides the string the response body of HTTP
+	// checks should contain.
+	shouldContainAnnotation = annotationPrefix + "should-contain"
+
+	// checkRateAnnotation overrides the duration between checks.
+	checkRateAnnotation = annotationPrefix + "check-rate"
+
+	// schemeAnnotation overrides the scheme Services are checked with, which
+	// is derived from the port otherwise.
+	schemeAnnotation = annotationPrefix + "scheme"
+)
+
+// monitoringEnabled returns whether or not the object should be monitored. An
+// object is monitored unless it's opted out through the enabled annotation.
+// Malformed values are reported by applyAnnotations and don't opt the object
+// out.
+func monitoringEnabled(obj metav1.Object) bool {
+	val, ok := obj.GetAnnotations()[enabledAnnotation]
+	if !ok {
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(val)
+	return err != nil || enabled
+}
+
+// applyAnnotations merges the overrides configured through annotations on the
+// object over the template spec. Overrides with a malformed value are left
+// out, an error is returned for each of them.
+func applyAnnotations(obj metav1.Object, spec *v1alpha1.MonitorTemplateSpec) []error {
+	annotations := obj.GetAnnotations()
+
+	// Go over the annotations in a stable order so the errors are reported
+	// in the same order on every reconcile.
+	var keys []string
+	for key := range annotations {
+		if strings.HasPrefix(key, annotationPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		val := annotations[key]
+
+		switch key {
+		case enabledAnnotation:
+			if _, err := strconv.ParseBool(val); err != nil {
+				errs = append(errs, fmt.Errorf("Invalid value '%s' for %s, expected a boolean", val, key))
+			}
+		case endpointAnnotation:
+			if err := requireHTTPCheck(key, spec); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			if !strings.HasPrefix(val, "/") {
+				errs = append(errs, fmt.Errorf("Invalid value '%s' for %s, expected a path starting with '/'", val, key))
+				continue
+			}
+
+			endpoint := val
+			spec.HTTP.Endpoint = &endpoint
+		case shouldContainAnnotation:
+			if err := requireHTTPCheck(key, spec); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			spec.HTTP.ShouldContain = val
+		case checkRateAnnotation:
+			if d, err := time.ParseDuration(val); err != nil || d <= 0 {
+				errs = append(errs, fmt.Errorf("Invalid value '%s' for %s, expected a positive duration", val, key))
+				continue
+			}
+
+			checkRate := val
+			spec.CheckRate = &checkRate
+		case schemeAnnotation:
+			// The scheme is applied when the targets of the Service are
+			// set up.
+			if _, ok := obj.(*v1.Service); !ok {
+				errs = append(errs, fmt.Errorf("The %s annotation is only supported on Services", key))
+				continue
+			}
+
+			if val != "http" && val != "https" {
+				errs = append(errs, fmt.Errorf("Invalid value '%s' for %s, expected http or https", val, key))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("Unknown annotation %s", key))
+		}
+	}
+
+	return errs
+}
+
+// requireHTTPCheck makes sure the template spec describes a HTTP check, which
+// the given annotation can be applied to.
+func requireHTTPCheck(key string, spec *v1alpha1.MonitorTemplateSpec) error {
+	if spec.Type == v1alpha1.CheckTypeTCP {
+		return fmt.Errorf("The %s annotation is only supported for %s checks", key, v1alpha1.CheckTypeHTTP)
+	}
+
+	if spec.HTTP == nil {
+		spec.HTTP = &v1alpha1.HTTPTemplate{}
+	}
+
+	return nil
+}
+
+// annotationWarnings returns a warning on the object for every malformed
+// annotation.
+func annotationWarnings(obj metav1.Object, errs []error) []objectWarning {
+	warnings := make([]objectWarning, len(errs))
+	for i, err := range errs {
+		warnings[i] = objectWarning{obj: obj, reason: eventReasonInvalidAnnotation, message: err.Error()}
+	}
+
+	return warnings
+}
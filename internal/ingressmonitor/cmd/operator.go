@@ -9,21 +9,30 @@ import (
 	"github.com/jelmersnoeck/ingress-monitor/internal/ingressmonitor"
 	"github.com/jelmersnoeck/ingress-monitor/internal/metrics"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/blackbox"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/datadog"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider/logger"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/pingdom"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider/statuscake"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/uptimerobot"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/webhook"
 	"github.com/jelmersnoeck/ingress-monitor/internal/signals"
 	"github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/spf13/cobra"
-	"k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 var operatorFlags struct {
-	Namespace    string
+	Namespaces        []string
+	NamespaceSelector string
+
+	ClusterResourceNamespace string
+
 	MasterURL    string
 	KubeConfig   string
 	ResyncPeriod string
@@ -62,10 +71,12 @@ func runOperator(cmd *cobra.Command, args []string) {
 		log.Fatalf("Error building IngressMonitor clientset: %s", err)
 	}
 
-	// register the available providers
-	fact := provider.NewFactory(kubeClient)
-	statuscake.Register(fact)
-	logger.Register(fact)
+	dynClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		log.Fatalf("Error building dynamic client: %s", err)
+	}
+
+	fact := newProviderFactory(kubeClient, dynClient)
 
 	// create new prometheus registry
 	registry := prometheus.NewRegistry()
@@ -83,9 +94,16 @@ func runOperator(cmd *cobra.Command, args []string) {
 	}
 	go metricssvc.Start(stopCh)
 
+	namespaces, err := ingressmonitor.ResolveNamespaces(
+		kubeClient, operatorFlags.Namespaces, operatorFlags.NamespaceSelector,
+	)
+	if err != nil {
+		log.Fatalf("Error resolving namespaces: %s", err)
+	}
+
 	op, err := ingressmonitor.NewOperator(
-		kubeClient, imClient, operatorFlags.Namespace,
-		resync, fact, mtrc,
+		kubeClient, imClient, dynClient, namespaces,
+		operatorFlags.ClusterResourceNamespace, resync, fact, mtrc,
 	)
 	if err != nil {
 		log.Fatalf("Error building IngressMonitor Operator: %s", err)
@@ -96,10 +114,27 @@ func runOperator(cmd *cobra.Command, args []string) {
 	}
 }
 
+// newProviderFactory creates a provider factory with all the available
+// providers registered.
+func newProviderFactory(kubeClient kubernetes.Interface, dynClient dynamic.Interface) *provider.SimpleFactory {
+	fact := provider.NewFactory(kubeClient)
+	statuscake.Register(fact)
+	pingdom.Register(fact)
+	uptimerobot.Register(fact)
+	blackbox.Register(fact, dynClient)
+	datadog.Register(fact)
+	webhook.Register(fact)
+	logger.Register(fact)
+
+	return fact
+}
+
 func init() {
 	rootCmd.AddCommand(operatorCmd)
 
-	operatorCmd.PersistentFlags().StringVarP(&operatorFlags.Namespace, "namespace", "n", v1.NamespaceAll, "The namespace to watch for installed CRDs.")
+	operatorCmd.PersistentFlags().StringSliceVarP(&operatorFlags.Namespaces, "namespace", "n", nil, "Comma separated list of namespaces to watch for installed CRDs. Defaults to all namespaces.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.NamespaceSelector, "namespace-selector", "", "Label selector for namespaces to watch for installed CRDs, combined with --namespace. The selector is resolved on startup, namespaces which are created or labelled later are only watched after a restart. Requires permission to list namespaces cluster wide.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.ClusterResourceNamespace, "cluster-resource-namespace", "", "Namespace the secrets of ClusterProviders are looked up in. ClusterProviders and ClusterMonitorTemplates are only watched when this is set.")
 	operatorCmd.PersistentFlags().StringVar(&operatorFlags.MasterURL, "master-url", "", "The URL of the master API.")
 	operatorCmd.PersistentFlags().StringVar(&operatorFlags.KubeConfig, "kubeconfig", "", "Kubeconfig which should be used to talk to the API.")
 	operatorCmd.PersistentFlags().StringVar(&operatorFlags.ResyncPeriod, "resync-period", "30s", "Resyncing period to ensure all monitors are up to date.")
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/jelmersnoeck/ingress-monitor/internal/admission"
+	"github.com/jelmersnoeck/ingress-monitor/internal/conversion"
+	"github.com/jelmersnoeck/ingress-monitor/internal/httpsvc"
+	"github.com/jelmersnoeck/ingress-monitor/internal/signals"
+
+	"github.com/spf13/cobra"
+)
+
+var webhookFlags struct {
+	Addr string
+	Port int
+
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// webhookCmd represents the webhook command
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Run the admission and conversion webhooks for the IngressMonitor CRDs",
+	Run:   runWebhook,
+}
+
+func runWebhook(cmd *cobra.Command, args []string) {
+	stopCh := signals.SetupSignalHandler()
+
+	if webhookFlags.TLSCertFile == "" || webhookFlags.TLSKeyFile == "" {
+		log.Fatalf("The API server only talks to webhooks over TLS, --tls-cert-file and --tls-private-key-file are required")
+	}
+
+	// The webhook only validates the configuration of Providers, it doesn't
+	// need to talk to the API to fetch credentials.
+	fact := newProviderFactory(nil, nil)
+
+	srv := httpsvc.Server{
+		Addr:     webhookFlags.Addr,
+		Port:     webhookFlags.Port,
+		CertFile: webhookFlags.TLSCertFile,
+		KeyFile:  webhookFlags.TLSKeyFile,
+	}
+	srv.Handle("/validate", admission.NewValidator(fact))
+	srv.Handle("/convert", conversion.NewHandler())
+
+	log.Printf("Starting the webhooks on %s:%d", webhookFlags.Addr, webhookFlags.Port)
+	if err := srv.Start(stopCh); err != nil {
+		log.Fatalf("Error running the webhooks: %s", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+
+	webhookCmd.PersistentFlags().StringVar(&webhookFlags.Addr, "addr", "0.0.0.0", "address the webhook server will bind to")
+	webhookCmd.PersistentFlags().IntVar(&webhookFlags.Port, "port", 8443, "port on which the webhook server is available")
+	webhookCmd.PersistentFlags().StringVar(&webhookFlags.TLSCertFile, "tls-cert-file", "", "File containing the TLS certificate the webhook server serves.")
+	webhookCmd.PersistentFlags().StringVar(&webhookFlags.TLSKeyFile, "tls-private-key-file", "", "File containing the TLS private key matching --tls-cert-file.")
+}
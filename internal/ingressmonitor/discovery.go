@@ -0,0 +1,31 @@
+package ingressmonitor
+
+import (
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// discoverGroupVersion returns the first of the given API versions the
+// cluster serves the resource under. The returned bool is false when the
+// resource isn't served under any of them.
+func discoverGroupVersion(dc discovery.DiscoveryInterface, resource string, gvs []schema.GroupVersion) (schema.GroupVersion, bool, error) {
+	for _, gv := range gvs {
+		resources, err := dc.ServerResourcesForGroupVersion(gv.String())
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return schema.GroupVersion{}, false, fmt.Errorf("Could not discover resources for %s: %s", gv, err)
+		}
+
+		for _, res := range resources.APIResources {
+			if res.Name == resource {
+				return gv, true, nil
+			}
+		}
+	}
+
+	return schema.GroupVersion{}, false, nil
+}
@@ -0,0 +1,141 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// eventComponent is the component which is used as the source of the Events
+// recorded by the Operator.
+const eventComponent = "ingress-monitor"
+
+// These are the reasons which are used for the Events recorded by the
+// Operator.
+const (
+	eventReasonCreated         = "Created"
+	eventReasonUpdated         = "Updated"
+	eventReasonRecreated       = "Recreated"
+	eventReasonDeleted         = "Deleted"
+	eventReasonSyncFailed      = "SyncFailed"
+	eventReasonDeleteFailed    = "DeleteFailed"
+	eventReasonReconcileFailed = "ReconcileFailed"
+
+	eventReasonInvalidAnnotation = "InvalidAnnotation"
+	eventReasonInvalidTemplate   = "InvalidTemplate"
+	eventReasonHostSkipped       = "HostSkipped"
+)
+
+// recordEvent records an Event on the IngressMonitor as well as on the Monitor
+// and the object it is set up for. This allows users to see what's happening to
+// their checks from the resources they've created themselves.
+func (o *Operator) recordEvent(im *v1alpha1.IngressMonitor, eventType, reason, messageFmt string, args ...interface{}) {
+	msg := fmt.Sprintf(messageFmt, args...)
+	o.recorder.Event(im, eventType, reason, msg)
+
+	// Give some context on which IngressMonitor this is about on the other
+	// objects.
+	msg = fmt.Sprintf("IngressMonitor %s: %s", im.Name, msg)
+	for _, obj := range o.linkedObjects(im) {
+		o.recorder.Event(obj, eventType, reason, msg)
+	}
+}
+
+// linkedObjects returns the Monitor and the selected object the IngressMonitor
+// is linked to, if they can be found.
+func (o *Operator) linkedObjects(im *v1alpha1.IngressMonitor) []runtime.Object {
+	var objs []runtime.Object
+
+	if name, ok := im.Labels[monitorLabel]; ok {
+		item, exists, err := o.mInformer.GetIndexer().GetByKey(namespacedIndexKey(im.Namespace, name))
+		if err == nil && exists {
+			objs = append(objs, item.(runtime.Object))
+		}
+	}
+
+	for _, kind := range v1alpha1.SourceKinds {
+		src, ok := o.sources[kind]
+		if !ok {
+			continue
+		}
+
+		if name, ok := im.Labels[src.label()]; ok {
+			if obj, exists := src.get(im.Namespace, name); exists {
+				objs = append(objs, obj)
+			}
+		}
+	}
+
+	return objs
+}
+
+// recordSyncEvent records the result of syncing the IngressMonitor with the
+// provider. Periodic resyncs which don't change anything don't result in an
+// Event.
+func (o *Operator) recordSyncEvent(im *v1alpha1.IngressMonitor, id string, err error) {
+	provType := im.Spec.Provider.Type
+
+	switch {
+	case err != nil:
+		o.recordEvent(im, v1.EventTypeWarning, eventReasonSyncFailed, "Could not sync check with provider %s: %s", provType, err)
+	case im.Status.ID == "":
+		o.recordEvent(im, v1.EventTypeNormal, eventReasonCreated, "Created check %s with provider %s", id, provType)
+	case im.Status.ID != id:
+		o.recordEvent(im, v1.EventTypeNormal, eventReasonRecreated, "Check %s went missing with provider %s, recreated it as %s", im.Status.ID, provType, id)
+	case im.Generation != im.Status.ObservedGeneration:
+		o.recordEvent(im, v1.EventTypeNormal, eventReasonUpdated, "Updated check %s with provider %s", id, provType)
+	}
+}
+
+// objectWarning is a problem with an object selected by a Monitor, like a rule
+// which can't be monitored or a malformed annotation.
+type objectWarning struct {
+	obj     metav1.Object
+	reason  string
+	message string
+}
+
+// recordWarnings sets the SelectionValid condition of the Monitor to the given
+// warnings, and records a Warning Event on the object of every warning which
+// isn't on the condition yet. Periodic resyncs which find the same warnings
+// don't result in an Event, like they don't for the lifecycle of the checks.
+func (o *Operator) recordWarnings(mon *v1alpha1.Monitor, status *v1alpha1.MonitorStatus, warnings []objectWarning) {
+	reported := map[string]bool{}
+	for _, cond := range status.Conditions {
+		if cond.Type == v1alpha1.MonitorSelectionValid && cond.Message != "" {
+			for _, line := range strings.Split(cond.Message, "\n") {
+				reported[line] = true
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	var lines []string
+	for _, w := range warnings {
+		// Objects with several targets result in the same warnings.
+		line := fmt.Sprintf("%s %s: %s", sourceKind(mon), w.obj.GetName(), w.message)
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+
+		if robj, ok := w.obj.(runtime.Object); ok && !reported[line] {
+			o.recorder.Event(robj, v1.EventTypeWarning, w.reason, w.message)
+		}
+	}
+
+	if len(lines) == 0 {
+		setMonitorCondition(status, v1alpha1.MonitorSelectionValid, v1.ConditionTrue, reasonSelectionValid, "")
+		return
+	}
+
+	sort.Strings(lines)
+	setMonitorCondition(status, v1alpha1.MonitorSelectionValid, v1.ConditionFalse, reasonSelectionWarnings, strings.Join(lines, "\n"))
+}
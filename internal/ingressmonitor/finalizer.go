@@ -0,0 +1,74 @@
+package ingressmonitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressMonitorFinalizer is the finalizer which is added to all
+// IngressMonitors. It ensures the monitor is removed from the provider before
+// the IngressMonitor is removed from the cluster.
+const ingressMonitorFinalizer = "ingressmonitor.sphc.io/provider-cleanup"
+
+// finalizeIngressMonitor removes the monitor linked to the IngressMonitor from
+// the provider. The finalizer is only removed once the provider confirms that
+// the monitor is deleted or has already been removed. Any other error is
+// returned so the IngressMonitor gets retried through the queue.
+func (o *Operator) finalizeIngressMonitor(obj *v1alpha1.IngressMonitor) error {
+	if !hasFinalizer(obj, ingressMonitorFinalizer) {
+		return nil
+	}
+
+	// If there's no ID, the monitor has never been created with the provider.
+	if obj.Status.ID != "" {
+		cl, err := o.providerFactory.From(obj.Spec.Provider)
+		if err != nil {
+			return fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
+		}
+
+		err = cl.Delete(obj.Status.ID)
+		switch err {
+		case nil:
+			o.recordEvent(obj, v1.EventTypeNormal, eventReasonDeleted, "Deleted check %s from provider %s", obj.Status.ID, obj.Spec.Provider.Type)
+		case provider.ErrMonitorNotFound:
+			o.recordEvent(obj, v1.EventTypeNormal, eventReasonDeleted, "Check %s was already removed from provider %s", obj.Status.ID, obj.Spec.Provider.Type)
+		default:
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonDeleteFailed, "Could not delete check %s from provider %s: %s", obj.Status.ID, obj.Spec.Provider.Type, err)
+			return fmt.Errorf("Could not delete monitor '%s' with provider '%s': %s", obj.Status.ID, obj.Spec.Provider.Type, err)
+		}
+	}
+
+	obj.Finalizers = removeString(obj.Finalizers, ingressMonitorFinalizer)
+	if _, err := o.imClient.IngressMonitors(obj.Namespace).Update(context.TODO(), obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Could not remove finalizer: %s", err)
+	}
+
+	return nil
+}
+
+func hasFinalizer(obj *v1alpha1.IngressMonitor, finalizer string) bool {
+	for _, f := range obj.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeString(list []string, str string) []string {
+	var result []string
+	for _, item := range list {
+		if item != str {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
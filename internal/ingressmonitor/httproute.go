@@ -0,0 +1,263 @@
+package ingressmonitor
+
+import (
+	"log"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	gatewayGroup = "gateway.networking.k8s.io"
+
+	httpRouteKind = "HTTPRoute"
+	gatewayKind   = "Gateway"
+)
+
+// gatewayGroupVersions are the API versions the Gateway API resources are
+// served under, in order of preference.
+var gatewayGroupVersions = []schema.GroupVersion{
+	{Group: gatewayGroup, Version: "v1"},
+	{Group: gatewayGroup, Version: "v1beta1"},
+}
+
+// The Gateway API isn't part of the Kubernetes API, the Operator talks to it
+// through the dynamic client. These types describe the parts of the HTTPRoute
+// and Gateway specs the Operator uses.
+type httpRouteSpec struct {
+	ParentRefs []parentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+	Port        *int32  `json:"port,omitempty"`
+}
+
+type gatewaySpec struct {
+	Listeners []gatewayListener `json:"listeners,omitempty"`
+}
+
+type gatewayListener struct {
+	Name     string                 `json:"name"`
+	Hostname *string                `json:"hostname,omitempty"`
+	Port     int32                  `json:"port"`
+	Protocol string                 `json:"protocol"`
+	TLS      map[string]interface{} `json:"tls,omitempty"`
+}
+
+// hasTLS returns whether or not the listener terminates TLS.
+func (l gatewayListener) hasTLS() bool {
+	return l.Protocol == "HTTPS" || l.TLS != nil
+}
+
+// matches returns whether or not the listener accepts requests for the given
+// host. A listener without a hostname accepts requests for all hosts.
+func (l gatewayListener) matches(host string) bool {
+	if l.Hostname == nil || *l.Hostname == "" {
+		return true
+	}
+
+	if strings.HasPrefix(*l.Hostname, "*.") {
+		return strings.HasSuffix(host, (*l.Hostname)[1:])
+	}
+
+	return *l.Hostname == host
+}
+
+// decodeSpec decodes the spec of the unstructured object into the given
+// value.
+func decodeSpec(obj *unstructured.Unstructured, spec interface{}) error {
+	raw, ok := obj.Object["spec"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(raw, spec)
+}
+
+// httpRouteSource selects Gateway API HTTPRoutes and sets up a target for
+// every hostname. The scheme and port are taken from the listener of the
+// parent Gateway which accepts the hostname, preferring listeners which
+// terminate TLS.
+type httpRouteSource struct {
+	routeInformer   cache.SharedIndexInformer
+	gatewayInformer cache.SharedIndexInformer
+
+	// gv is the API version the cluster serves the Gateway API under.
+	gv schema.GroupVersion
+}
+
+func (s *httpRouteSource) label() string {
+	return httpRouteLabel
+}
+
+func (s *httpRouteSource) list(namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	return informerObjects(s.routeInformer, namespace, selector)
+}
+
+func (s *httpRouteSource) get(namespace, name string) (runtime.Object, bool) {
+	return informerObject(s.routeInformer, namespace, name)
+}
+
+func (s *httpRouteSource) selects(mon *v1alpha1.Monitor, obj metav1.Object) bool {
+	return true
+}
+
+func (s *httpRouteSource) targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget {
+	route := obj.(*unstructured.Unstructured)
+
+	var spec httpRouteSpec
+	if err := decodeSpec(route, &spec); err != nil {
+		log.Printf("Could not decode HTTPRoute %s:%s: %s", route.GetNamespace(), route.GetName(), err)
+		return nil
+	}
+
+	listeners := s.parentListeners(route, spec)
+
+	// A route without hostnames accepts the hostnames of the listeners it's
+	// attached to.
+	hosts := spec.Hostnames
+	if len(hosts) == 0 {
+		for _, l := range listeners {
+			if l.Hostname != nil {
+				hosts = append(hosts, *l.Hostname)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	var targets []monitorTarget
+	for _, host := range hosts {
+		if !isMonitorableHost(host) || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		target := newHostTarget(route, s.gv.WithKind(httpRouteKind), httpRouteLabel, host, false)
+
+		var matched bool
+		for _, l := range listeners {
+			if !l.matches(host) {
+				continue
+			}
+
+			if !matched || (l.hasTLS() && target.scheme != "https") {
+				target.port = l.Port
+				target.scheme = "http"
+				if l.hasTLS() {
+					target.scheme = "https"
+				}
+			}
+			matched = true
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// related returns the HTTPRoute itself, or the HTTPRoutes which are attached
+// to a Gateway.
+func (s *httpRouteSource) related(obj interface{}) []metav1.Object {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || u.GroupVersionKind().Group != gatewayGroup {
+		return nil
+	}
+
+	switch u.GetKind() {
+	case httpRouteKind:
+		return []metav1.Object{u}
+	case gatewayKind:
+		var routes []metav1.Object
+		for _, item := range s.routeInformer.GetIndexer().List() {
+			route := item.(*unstructured.Unstructured)
+
+			var spec httpRouteSpec
+			if err := decodeSpec(route, &spec); err != nil {
+				continue
+			}
+
+			for _, ref := range spec.ParentRefs {
+				if ns, ok := parentGateway(route, ref); ok && ns == u.GetNamespace() && ref.Name == u.GetName() {
+					routes = append(routes, route)
+					break
+				}
+			}
+		}
+
+		return routes
+	}
+
+	return nil
+}
+
+// parentListeners returns the listeners of the Gateways the HTTPRoute is
+// attached to. Gateways which can't be found, for example because they live
+// in a namespace the Operator doesn't watch, are skipped.
+func (s *httpRouteSource) parentListeners(route *unstructured.Unstructured, spec httpRouteSpec) []gatewayListener {
+	var listeners []gatewayListener
+	for _, ref := range spec.ParentRefs {
+		ns, ok := parentGateway(route, ref)
+		if !ok {
+			continue
+		}
+
+		item, exists := informerObject(s.gatewayInformer, ns, ref.Name)
+		if !exists {
+			log.Printf("Could not find Gateway %s:%s for HTTPRoute %s:%s", ns, ref.Name, route.GetNamespace(), route.GetName())
+			continue
+		}
+
+		var gwSpec gatewaySpec
+		if err := decodeSpec(item.(*unstructured.Unstructured), &gwSpec); err != nil {
+			log.Printf("Could not decode Gateway %s:%s: %s", ns, ref.Name, err)
+			continue
+		}
+
+		for _, l := range gwSpec.Listeners {
+			if ref.SectionName != nil && *ref.SectionName != l.Name {
+				continue
+			}
+
+			if ref.Port != nil && *ref.Port != l.Port {
+				continue
+			}
+
+			listeners = append(listeners, l)
+		}
+	}
+
+	return listeners
+}
+
+// parentGateway returns the namespace of the Gateway the parent reference
+// points to. The returned bool is false when the reference doesn't point to a
+// Gateway.
+func parentGateway(route *unstructured.Unstructured, ref parentReference) (string, bool) {
+	if ref.Group != nil && *ref.Group != gatewayGroup {
+		return "", false
+	}
+
+	if ref.Kind != nil && *ref.Kind != gatewayKind {
+		return "", false
+	}
+
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return *ref.Namespace, true
+	}
+
+	return route.GetNamespace(), true
+}
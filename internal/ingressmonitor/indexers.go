@@ -0,0 +1,174 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// monitorSelectorIndex is the name of the index which links Monitors to
+	// the labels they select Ingresses on.
+	monitorSelectorIndex = "monitorSelector"
+
+	// monitorProviderIndex is the name of the index which links Monitors to
+	// the Provider or ClusterProvider they reference.
+	monitorProviderIndex = "monitorProvider"
+
+	// monitorTemplateIndex is the name of the index which links Monitors to
+	// the MonitorTemplate or ClusterMonitorTemplate they reference.
+	monitorTemplateIndex = "monitorTemplate"
+
+	// selectorWildcard is used to index Monitors which don't have any
+	// MatchLabels configured. These could select any object in their
+	// namespace through MatchExpressions.
+	selectorWildcard = "*"
+)
+
+// monitorSelectorIndexFunc indexes Monitors by each `key=value` pair in the
+// MatchLabels of their selector. An Ingress or Service can only be selected by
+// a Monitor if it has all of these labels, so looking up the object labels in
+// this index gives us all candidate Monitors.
+func monitorSelectorIndexFunc(obj interface{}) ([]string, error) {
+	mon, ok := obj.(*v1alpha1.Monitor)
+	if !ok {
+		return nil, nil
+	}
+
+	if mon.Spec.Selector == nil || len(mon.Spec.Selector.MatchLabels) == 0 {
+		return []string{namespacedIndexKey(mon.Namespace, selectorWildcard)}, nil
+	}
+
+	keys := make([]string, 0, len(mon.Spec.Selector.MatchLabels))
+	for k, v := range mon.Spec.Selector.MatchLabels {
+		keys = append(keys, namespacedIndexKey(mon.Namespace, labelPair(k, v)))
+	}
+
+	return keys, nil
+}
+
+// monitorProviderIndexFunc indexes Monitors by the namespaced names of the
+// Providers they reference, or the names of the ClusterProviders. These match
+// the cache keys of the referenced objects.
+func monitorProviderIndexFunc(obj interface{}) ([]string, error) {
+	mon, ok := obj.(*v1alpha1.Monitor)
+	if !ok {
+		return nil, nil
+	}
+
+	refs := monitorProviders(mon)
+	keys := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if providerKind(ref) == v1alpha1.ClusterProviderKind {
+			keys = append(keys, ref.Name)
+			continue
+		}
+
+		keys = append(keys, namespacedIndexKey(mon.Namespace, ref.Name))
+	}
+
+	return keys, nil
+}
+
+// monitorTemplateIndexFunc indexes Monitors by the namespaced name of the
+// MonitorTemplate they reference, or the name of the ClusterMonitorTemplate.
+func monitorTemplateIndexFunc(obj interface{}) ([]string, error) {
+	mon, ok := obj.(*v1alpha1.Monitor)
+	if !ok {
+		return nil, nil
+	}
+
+	if templateKind(mon) == v1alpha1.ClusterMonitorTemplateKind {
+		return []string{mon.Spec.Template.Name}, nil
+	}
+
+	return []string{namespacedIndexKey(mon.Namespace, mon.Spec.Template.Name)}, nil
+}
+
+func namespacedIndexKey(namespace, value string) string {
+	return fmt.Sprintf("%s/%s", namespace, value)
+}
+
+func labelPair(key, value string) string {
+	return fmt.Sprintf("%s=%s", key, value)
+}
+
+// monitorsFor returns all Monitors of the given source kind which select the
+// given object.
+func (o *Operator) monitorsFor(kind string, obj metav1.Object) []*v1alpha1.Monitor {
+	src, ok := o.sources[kind]
+	if !ok {
+		return nil
+	}
+
+	keys := []string{namespacedIndexKey(obj.GetNamespace(), selectorWildcard)}
+	for k, v := range obj.GetLabels() {
+		keys = append(keys, namespacedIndexKey(obj.GetNamespace(), labelPair(k, v)))
+	}
+
+	seen := map[string]bool{}
+	var monitors []*v1alpha1.Monitor
+	for _, key := range keys {
+		objs, err := o.mInformer.GetIndexer().ByIndex(monitorSelectorIndex, key)
+		if err != nil {
+			log.Printf("Could not look up Monitors for %s %s:%s: %s", kind, obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+
+		for _, item := range objs {
+			mon := item.(*v1alpha1.Monitor)
+			if seen[mon.Name] {
+				continue
+			}
+			seen[mon.Name] = true
+
+			if sourceKind(mon) != kind {
+				continue
+			}
+
+			// The index only tells us a Monitor could select this object,
+			// validate the full selector including MatchExpressions.
+			sel, err := metav1.LabelSelectorAsSelector(mon.Spec.Selector)
+			if err != nil || !sel.Matches(labels.Set(obj.GetLabels())) {
+				continue
+			}
+
+			// Sources can narrow down the selection further, like the
+			// IngressClass of an Ingress.
+			if !src.selects(mon, obj) {
+				continue
+			}
+
+			monitors = append(monitors, mon)
+		}
+	}
+
+	return monitors
+}
+
+// monitorsReferencing returns all Monitors which reference the given object
+// through the specified index.
+func (o *Operator) monitorsReferencing(index string, obj interface{}) []*v1alpha1.Monitor {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil
+	}
+
+	objs, err := o.mInformer.GetIndexer().ByIndex(index, key)
+	if err != nil {
+		log.Printf("Could not look up Monitors referencing %s: %s", key, err)
+		return nil
+	}
+
+	monitors := make([]*v1alpha1.Monitor, 0, len(objs))
+	for _, obj := range objs {
+		monitors = append(monitors, obj.(*v1alpha1.Monitor))
+	}
+
+	return monitors
+}
@@ -0,0 +1,324 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+// multiNamespaceInformer combines the informers for a set of namespaces into a
+// single SharedIndexInformer. This allows the Operator to watch a group of
+// namespaces without needing cluster wide permissions, whilst the rest of the
+// Operator can keep using a single informer per resource.
+type multiNamespaceInformer struct {
+	informers map[string]cache.SharedIndexInformer
+	indexer   multiNamespaceIndexer
+}
+
+// newNamespacedInformer sets up an informer for every given namespace through
+// newInformer. When only one namespace is given, the informer for that
+// namespace is returned as is.
+func newNamespacedInformer(namespaces []string, newInformer func(string) cache.SharedIndexInformer) cache.SharedIndexInformer {
+	if len(namespaces) == 1 {
+		return newInformer(namespaces[0])
+	}
+
+	inf := &multiNamespaceInformer{
+		informers: map[string]cache.SharedIndexInformer{},
+		indexer:   multiNamespaceIndexer{},
+	}
+
+	for _, ns := range namespaces {
+		inf.informers[ns] = newInformer(ns)
+		inf.indexer[ns] = inf.informers[ns].GetIndexer()
+	}
+
+	return inf
+}
+
+// AddEventHandler adds the handler to the informers of all namespaces.
+func (m *multiNamespaceInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	for _, inf := range m.informers {
+		inf.AddEventHandler(handler)
+	}
+}
+
+// AddEventHandlerWithResyncPeriod adds the handler to the informers of all
+// namespaces with the given resync period.
+func (m *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	for _, inf := range m.informers {
+		inf.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+// GetStore returns a Store which spans all namespaces.
+func (m *multiNamespaceInformer) GetStore() cache.Store {
+	return m.indexer
+}
+
+// GetController returns the informer itself, as it controls running all the
+// namespaced informers.
+func (m *multiNamespaceInformer) GetController() cache.Controller {
+	return m
+}
+
+// Run runs the informers of all namespaces and blocks until stopCh is closed.
+func (m *multiNamespaceInformer) Run(stopCh <-chan struct{}) {
+	for _, inf := range m.informers {
+		go inf.Run(stopCh)
+	}
+
+	<-stopCh
+}
+
+// HasSynced returns true when the informers of all namespaces have synced.
+func (m *multiNamespaceInformer) HasSynced() bool {
+	for _, inf := range m.informers {
+		if !inf.HasSynced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LastSyncResourceVersion returns an empty string, ResourceVersions of the
+// different namespaced informers can't be compared to each other.
+func (m *multiNamespaceInformer) LastSyncResourceVersion() string {
+	return ""
+}
+
+// SetWatchErrorHandler sets the handler on the informers of all namespaces.
+func (m *multiNamespaceInformer) SetWatchErrorHandler(handler cache.WatchErrorHandler) error {
+	for _, inf := range m.informers {
+		if err := inf.SetWatchErrorHandler(handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddIndexers adds the indexers to the informers of all namespaces.
+func (m *multiNamespaceInformer) AddIndexers(indexers cache.Indexers) error {
+	for _, inf := range m.informers {
+		if err := inf.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetIndexer returns an Indexer which spans all namespaces.
+func (m *multiNamespaceInformer) GetIndexer() cache.Indexer {
+	return m.indexer
+}
+
+// multiNamespaceIndexer is an Indexer which routes calls for a single object to
+// the Indexer of the namespace the object lives in. Calls which span multiple
+// objects are performed on every namespace and the results are combined.
+type multiNamespaceIndexer map[string]cache.Indexer
+
+func (m multiNamespaceIndexer) indexerFor(obj interface{}) (cache.Indexer, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.indexerForNamespace(objMeta.GetNamespace())
+}
+
+func (m multiNamespaceIndexer) indexerForNamespace(ns string) (cache.Indexer, error) {
+	indexer, ok := m[ns]
+	if !ok {
+		return nil, fmt.Errorf("namespace `%s` is not watched by the operator", ns)
+	}
+
+	return indexer, nil
+}
+
+// Add adds the object to the Indexer of its namespace.
+func (m multiNamespaceIndexer) Add(obj interface{}) error {
+	indexer, err := m.indexerFor(obj)
+	if err != nil {
+		return err
+	}
+
+	return indexer.Add(obj)
+}
+
+// Update updates the object in the Indexer of its namespace.
+func (m multiNamespaceIndexer) Update(obj interface{}) error {
+	indexer, err := m.indexerFor(obj)
+	if err != nil {
+		return err
+	}
+
+	return indexer.Update(obj)
+}
+
+// Delete deletes the object from the Indexer of its namespace.
+func (m multiNamespaceIndexer) Delete(obj interface{}) error {
+	indexer, err := m.indexerFor(obj)
+	if err != nil {
+		return err
+	}
+
+	return indexer.Delete(obj)
+}
+
+// List lists the objects of all namespaces.
+func (m multiNamespaceIndexer) List() []interface{} {
+	var objs []interface{}
+	for _, indexer := range m {
+		objs = append(objs, indexer.List()...)
+	}
+
+	return objs
+}
+
+// ListKeys lists the keys of all namespaces.
+func (m multiNamespaceIndexer) ListKeys() []string {
+	var keys []string
+	for _, indexer := range m {
+		keys = append(keys, indexer.ListKeys()...)
+	}
+
+	return keys
+}
+
+// Get gets the object from the Indexer of its namespace.
+func (m multiNamespaceIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	indexer, err := m.indexerFor(obj)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	return indexer.Get(obj)
+}
+
+// GetByKey gets the object linked to the key from the Indexer of the namespace
+// which is part of the key.
+func (m multiNamespaceIndexer) GetByKey(key string) (interface{}, bool, error) {
+	ns, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	indexer, err := m.indexerForNamespace(ns)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	return indexer.GetByKey(key)
+}
+
+// Replace replaces the contents of every namespaced Indexer with the objects
+// which live in that namespace.
+func (m multiNamespaceIndexer) Replace(objs []interface{}, resourceVersion string) error {
+	perNamespace := map[string][]interface{}{}
+	for _, obj := range objs {
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+
+		ns := objMeta.GetNamespace()
+		perNamespace[ns] = append(perNamespace[ns], obj)
+	}
+
+	for ns, indexer := range m {
+		if err := indexer.Replace(perNamespace[ns], resourceVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resync resyncs the Indexers of all namespaces.
+func (m multiNamespaceIndexer) Resync() error {
+	for _, indexer := range m {
+		if err := indexer.Resync(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Index returns the objects that match the index values of the given object
+// from the Indexer of its namespace.
+func (m multiNamespaceIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	indexer, err := m.indexerFor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexer.Index(indexName, obj)
+}
+
+// IndexKeys returns the keys of the objects matching the index value in all
+// namespaces.
+func (m multiNamespaceIndexer) IndexKeys(indexName, indexKey string) ([]string, error) {
+	var keys []string
+	for _, indexer := range m {
+		nsKeys, err := indexer.IndexKeys(indexName, indexKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, nsKeys...)
+	}
+
+	return keys, nil
+}
+
+// ListIndexFuncValues lists the index values of all namespaces.
+func (m multiNamespaceIndexer) ListIndexFuncValues(indexName string) []string {
+	var values []string
+	for _, indexer := range m {
+		values = append(values, indexer.ListIndexFuncValues(indexName)...)
+	}
+
+	return values
+}
+
+// ByIndex returns the objects matching the index value in all namespaces.
+func (m multiNamespaceIndexer) ByIndex(indexName, indexKey string) ([]interface{}, error) {
+	var objs []interface{}
+	for _, indexer := range m {
+		nsObjs, err := indexer.ByIndex(indexName, indexKey)
+		if err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, nsObjs...)
+	}
+
+	return objs, nil
+}
+
+// GetIndexers returns the indexers which are configured. These are the same for
+// every namespace.
+func (m multiNamespaceIndexer) GetIndexers() cache.Indexers {
+	for _, indexer := range m {
+		return indexer.GetIndexers()
+	}
+
+	return cache.Indexers{}
+}
+
+// AddIndexers adds the indexers to the Indexers of all namespaces.
+func (m multiNamespaceIndexer) AddIndexers(indexers cache.Indexers) error {
+	for _, indexer := range m {
+		if err := indexer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
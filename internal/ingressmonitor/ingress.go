@@ -0,0 +1,414 @@
+package ingressmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	nlv1 "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ingressClassAnnotation is the annotation which was used to specify the
+// IngressClass before `spec.ingressClassName` was introduced.
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// ingressGroupVersions are the API versions the Ingress resource is served
+// under, in order of preference.
+var ingressGroupVersions = []schema.GroupVersion{
+	networkingv1.SchemeGroupVersion,
+	networkingv1beta1.SchemeGroupVersion,
+	extensionsv1beta1.SchemeGroupVersion,
+}
+
+// discoverIngressGroupVersion asks the cluster which API versions it serves the
+// Ingress resource under and returns the preferred one.
+func discoverIngressGroupVersion(dc discovery.DiscoveryInterface) (schema.GroupVersion, error) {
+	gv, ok, err := discoverGroupVersion(dc, "ingresses", ingressGroupVersions)
+	if err != nil {
+		return schema.GroupVersion{}, err
+	}
+
+	if !ok {
+		return schema.GroupVersion{}, fmt.Errorf("The cluster doesn't serve Ingresses under any of the supported API versions")
+	}
+
+	return gv, nil
+}
+
+// newIngressInformer sets up an informer for the Ingresses in the given
+// namespace. The Ingresses in the cache are always networking.k8s.io/v1
+// Ingresses, Ingresses served under older API versions are converted when
+// they're received from the cluster.
+func newIngressInformer(kc kubernetes.Interface, factory informers.SharedInformerFactory, gv schema.GroupVersion, ns string, resync time.Duration) cache.SharedIndexInformer {
+	var listFunc cache.ListFunc
+	var watchFunc cache.WatchFunc
+
+	switch gv {
+	case networkingv1beta1.SchemeGroupVersion:
+		cl := kc.NetworkingV1beta1().Ingresses(ns)
+		listFunc = func(opts metav1.ListOptions) (runtime.Object, error) {
+			return cl.List(context.TODO(), opts)
+		}
+		watchFunc = func(opts metav1.ListOptions) (watch.Interface, error) {
+			return cl.Watch(context.TODO(), opts)
+		}
+	case extensionsv1beta1.SchemeGroupVersion:
+		cl := kc.ExtensionsV1beta1().Ingresses(ns)
+		listFunc = func(opts metav1.ListOptions) (runtime.Object, error) {
+			return cl.List(context.TODO(), opts)
+		}
+		watchFunc = func(opts metav1.ListOptions) (watch.Interface, error) {
+			return cl.Watch(context.TODO(), opts)
+		}
+	default:
+		return factory.Networking().V1().Ingresses().Informer()
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			list, err := listFunc(opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return convertIngressList(list)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			w, err := watchFunc(opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return watch.Filter(w, convertIngressEvent), nil
+		},
+	}
+
+	return cache.NewSharedIndexInformer(
+		lw,
+		&networkingv1.Ingress{},
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// convertIngressList converts a list of Ingresses served under an older API
+// version to a networking.k8s.io/v1 IngressList.
+func convertIngressList(obj runtime.Object) (runtime.Object, error) {
+	var items []runtime.Object
+	var listMeta metav1.ListMeta
+
+	switch list := obj.(type) {
+	case *networkingv1beta1.IngressList:
+		listMeta = list.ListMeta
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+	case *extensionsv1beta1.IngressList:
+		listMeta = list.ListMeta
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("Unexpected Ingress list type %T", obj)
+	}
+
+	v1List := &networkingv1.IngressList{ListMeta: listMeta}
+	for _, item := range items {
+		ing, err := convertIngress(item)
+		if err != nil {
+			return nil, err
+		}
+
+		v1List.Items = append(v1List.Items, *ing)
+	}
+
+	return v1List, nil
+}
+
+// convertIngressEvent converts the Ingress in a watch Event to a
+// networking.k8s.io/v1 Ingress. Events which don't contain an Ingress, like
+// errors, are passed along as they are.
+func convertIngressEvent(e watch.Event) (watch.Event, bool) {
+	switch e.Object.(type) {
+	case *networkingv1beta1.Ingress, *extensionsv1beta1.Ingress:
+		ing, err := convertIngress(e.Object)
+		if err != nil {
+			log.Printf("Could not convert Ingress: %s", err)
+			return e, false
+		}
+
+		e.Object = ing
+	}
+
+	return e, true
+}
+
+// convertIngress converts an Ingress served under an older API version to a
+// networking.k8s.io/v1 Ingress.
+func convertIngress(obj runtime.Object) (*networkingv1.Ingress, error) {
+	var ing *networkingv1beta1.Ingress
+
+	switch obj := obj.(type) {
+	case *networkingv1.Ingress:
+		return obj, nil
+	case *networkingv1beta1.Ingress:
+		ing = obj
+	case *extensionsv1beta1.Ingress:
+		// The extensions/v1beta1 Ingress is served with the exact same
+		// representation as the networking.k8s.io/v1beta1 Ingress.
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		ing = new(networkingv1beta1.Ingress)
+		if err := json.Unmarshal(data, ing); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Unexpected Ingress type %T", obj)
+	}
+
+	v1Ing := &networkingv1.Ingress{
+		ObjectMeta: ing.ObjectMeta,
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ing.Spec.IngressClassName,
+			DefaultBackend:   convertIngressBackend(ing.Spec.Backend),
+		},
+		Status: networkingv1.IngressStatus{
+			LoadBalancer: ing.Status.LoadBalancer,
+		},
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		v1Ing.Spec.TLS = append(v1Ing.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		v1Rule := networkingv1.IngressRule{Host: rule.Host}
+
+		if rule.HTTP != nil {
+			v1Rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				v1Path := networkingv1.HTTPIngressPath{
+					Path:    path.Path,
+					Backend: *convertIngressBackend(&path.Backend),
+				}
+
+				if path.PathType != nil {
+					pathType := networkingv1.PathType(*path.PathType)
+					v1Path.PathType = &pathType
+				}
+
+				v1Rule.HTTP.Paths = append(v1Rule.HTTP.Paths, v1Path)
+			}
+		}
+
+		v1Ing.Spec.Rules = append(v1Ing.Spec.Rules, v1Rule)
+	}
+
+	return v1Ing, nil
+}
+
+func convertIngressBackend(backend *networkingv1beta1.IngressBackend) *networkingv1.IngressBackend {
+	if backend == nil {
+		return nil
+	}
+
+	v1Backend := &networkingv1.IngressBackend{
+		Resource: backend.Resource,
+	}
+
+	if backend.ServiceName != "" {
+		v1Backend.Service = &networkingv1.IngressServiceBackend{
+			Name: backend.ServiceName,
+		}
+
+		if backend.ServicePort.Type == intstr.Int {
+			v1Backend.Service.Port.Number = backend.ServicePort.IntVal
+		} else {
+			v1Backend.Service.Port.Name = backend.ServicePort.StrVal
+		}
+	}
+
+	return v1Backend
+}
+
+// isMonitorableHost returns whether or not a check can be set up for the host
+// of an Ingress rule. Rules without a host match all incoming requests and
+// wildcard hosts don't point to a single URL.
+func isMonitorableHost(host string) bool {
+	return host != "" && !strings.HasPrefix(host, "*")
+}
+
+// skippedHosts returns a warning for the hosts of the rules of the Ingress
+// which can't be monitored, so it's visible why these rules don't get an
+// IngressMonitor. Other kinds of objects are ignored.
+func skippedHosts(obj metav1.Object) []objectWarning {
+	ing, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var warnings []objectWarning
+	for _, rule := range ing.Spec.Rules {
+		if isMonitorableHost(rule.Host) || seen[rule.Host] {
+			continue
+		}
+		seen[rule.Host] = true
+
+		msg := "Skipping the rule without a host, there's no URL to check"
+		if rule.Host != "" {
+			msg = fmt.Sprintf("Skipping the rule for wildcard host %s, there's no URL to check", rule.Host)
+		}
+
+		warnings = append(warnings, objectWarning{obj: ing, reason: eventReasonHostSkipped, message: msg})
+	}
+
+	return warnings
+}
+
+// ingressClass returns the IngressClass of the given Ingress. This is either
+// configured through `spec.ingressClassName` or the legacy annotation.
+func ingressClass(ing *networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+
+	return ing.Annotations[ingressClassAnnotation]
+}
+
+// ingressSource selects Ingresses and sets up a target for every host in
+// their rules.
+type ingressSource struct {
+	informer cache.SharedIndexInformer
+	lister   nlv1.IngressLister
+
+	// gvk is the GroupVersionKind the cluster serves Ingresses under. This is
+	// used to set up the OwnerReferences to the Ingresses.
+	gvk schema.GroupVersionKind
+}
+
+func (s *ingressSource) label() string {
+	return ingressLabel
+}
+
+func (s *ingressSource) list(namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	ingressList, err := s.lister.Ingresses(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]metav1.Object, len(ingressList))
+	for i, ing := range ingressList {
+		objs[i] = ing
+	}
+
+	return objs, nil
+}
+
+func (s *ingressSource) get(namespace, name string) (runtime.Object, bool) {
+	return informerObject(s.informer, namespace, name)
+}
+
+// selects limits the selected Ingresses to the configured IngressClass.
+func (s *ingressSource) selects(mon *v1alpha1.Monitor, obj metav1.Object) bool {
+	return mon.Spec.IngressClassName == "" || mon.Spec.IngressClassName == ingressClass(obj.(*networkingv1.Ingress))
+}
+
+func (s *ingressSource) targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget {
+	ing := obj.(*networkingv1.Ingress)
+
+	seen := map[string]bool{}
+	var targets []monitorTarget
+	for _, rule := range ing.Spec.Rules {
+		// Rules without a host or with a wildcard host can't be monitored as
+		// there's no fully qualified URL to check.
+		if !isMonitorableHost(rule.Host) {
+			continue
+		}
+
+		var tls bool
+	TLSLoop:
+		for _, tlsList := range ing.Spec.TLS {
+			for _, host := range tlsList.Hosts {
+				if host == rule.Host {
+					tls = true
+					break TLSLoop
+				}
+			}
+		}
+
+		target := newHostTarget(ing, s.gvk, ingressLabel, rule.Host, tls)
+		if !mon.Spec.PerPath || rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			if !seen[target.name()] {
+				seen[target.name()] = true
+				targets = append(targets, target)
+			}
+			continue
+		}
+
+		var skipped bool
+		for _, path := range rule.HTTP.Paths {
+			pathTarget := target
+			pathTarget.path = path.Path
+			if pathTarget.path == "" {
+				pathTarget.path = "/"
+			}
+
+			// Paths which don't start with a slash can't be appended to the
+			// host, these are left to a monitor for the host itself.
+			if !strings.HasPrefix(pathTarget.path, "/") {
+				skipped = true
+				continue
+			}
+
+			pathTarget.exact = path.PathType != nil && *path.PathType == networkingv1.PathTypeExact
+
+			// Several backends can be configured for the same path.
+			if seen[pathTarget.name()] {
+				continue
+			}
+			seen[pathTarget.name()] = true
+
+			targets = append(targets, pathTarget)
+		}
+
+		if skipped && !seen[target.name()] {
+			seen[target.name()] = true
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
+
+func (s *ingressSource) related(obj interface{}) []metav1.Object {
+	if ing, ok := obj.(*networkingv1.Ingress); ok {
+		return []metav1.Object{ing}
+	}
+
+	return nil
+}
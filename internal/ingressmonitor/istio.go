@@ -0,0 +1,248 @@
+package ingressmonitor
+
+import (
+	"log"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	istioGroup = "networking.istio.io"
+
+	virtualServiceKind = "VirtualService"
+	istioGatewayKind   = "Gateway"
+
+	// meshGateway is the reserved gateway name Istio uses for the sidecars in
+	// the mesh. Hosts which are only exposed to the mesh can't be monitored
+	// from the outside.
+	meshGateway = "mesh"
+)
+
+// istioGroupVersions are the API versions the Istio networking resources are
+// served under, in order of preference.
+var istioGroupVersions = []schema.GroupVersion{
+	{Group: istioGroup, Version: "v1"},
+	{Group: istioGroup, Version: "v1beta1"},
+	{Group: istioGroup, Version: "v1alpha3"},
+}
+
+// Istio isn't part of the Kubernetes API, the Operator talks to it through the
+// dynamic client. These types describe the parts of the VirtualService and
+// Gateway specs the Operator uses.
+type virtualServiceSpec struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Gateways []string `json:"gateways,omitempty"`
+}
+
+type istioGatewaySpec struct {
+	Servers []istioServer `json:"servers,omitempty"`
+}
+
+type istioServer struct {
+	Port  istioPort `json:"port"`
+	Hosts []string  `json:"hosts,omitempty"`
+}
+
+type istioPort struct {
+	Number   int32  `json:"number"`
+	Protocol string `json:"protocol"`
+}
+
+// hasTLS returns whether or not the server terminates TLS. A plain HTTP server
+// can configure TLS to redirect to HTTPS, which is why the protocol is used.
+func (s istioServer) hasTLS() bool {
+	protocol := strings.ToUpper(s.Port.Protocol)
+	return protocol == "HTTPS" || protocol == "TLS"
+}
+
+// matches returns whether or not the server accepts requests for the given
+// host. Server hosts can be prefixed with the namespace they're exposed to and
+// can use wildcards.
+func (s istioServer) matches(host string) bool {
+	for _, h := range s.Hosts {
+		if i := strings.Index(h, "/"); i >= 0 {
+			h = h[i+1:]
+		}
+
+		switch {
+		case h == "*":
+			return true
+		case strings.HasPrefix(h, "*."):
+			if strings.HasSuffix(host, h[1:]) {
+				return true
+			}
+		case h == host:
+			return true
+		}
+	}
+
+	return false
+}
+
+// virtualServiceSource selects Istio VirtualServices and sets up a target for
+// every host. The scheme and port are taken from the server of the Istio
+// Gateway which accepts the host, preferring servers which terminate TLS.
+// VirtualServices which are only bound to the mesh aren't monitored.
+type virtualServiceSource struct {
+	informer        cache.SharedIndexInformer
+	gatewayInformer cache.SharedIndexInformer
+
+	// gv is the API version the cluster serves the Istio networking
+	// resources under.
+	gv schema.GroupVersion
+}
+
+func (s *virtualServiceSource) label() string {
+	return virtualServiceLabel
+}
+
+func (s *virtualServiceSource) list(namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	return informerObjects(s.informer, namespace, selector)
+}
+
+func (s *virtualServiceSource) get(namespace, name string) (runtime.Object, bool) {
+	return informerObject(s.informer, namespace, name)
+}
+
+func (s *virtualServiceSource) selects(mon *v1alpha1.Monitor, obj metav1.Object) bool {
+	return true
+}
+
+func (s *virtualServiceSource) targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget {
+	vs := obj.(*unstructured.Unstructured)
+
+	var spec virtualServiceSpec
+	if err := decodeSpec(vs, &spec); err != nil {
+		log.Printf("Could not decode VirtualService %s:%s: %s", vs.GetNamespace(), vs.GetName(), err)
+		return nil
+	}
+
+	gateways := gatewayKeys(vs, spec)
+	if len(gateways) == 0 {
+		log.Printf("VirtualService %s:%s isn't bound to a Gateway, skipping", vs.GetNamespace(), vs.GetName())
+		return nil
+	}
+
+	servers := s.gatewayServers(vs, gateways)
+
+	seen := map[string]bool{}
+	var targets []monitorTarget
+	for _, host := range spec.Hosts {
+		if !isMonitorableHost(host) || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		target := newHostTarget(vs, s.gv.WithKind(virtualServiceKind), virtualServiceLabel, host, false)
+
+		var matched bool
+		for _, srv := range servers {
+			if !srv.matches(host) {
+				continue
+			}
+
+			if !matched || (srv.hasTLS() && target.scheme != "https") {
+				target.port = srv.Port.Number
+				target.scheme = "http"
+				if srv.hasTLS() {
+					target.scheme = "https"
+				}
+			}
+			matched = true
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// related returns the VirtualService itself, or the VirtualServices which are
+// bound to an Istio Gateway.
+func (s *virtualServiceSource) related(obj interface{}) []metav1.Object {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || u.GroupVersionKind().Group != istioGroup {
+		return nil
+	}
+
+	switch u.GetKind() {
+	case virtualServiceKind:
+		return []metav1.Object{u}
+	case istioGatewayKind:
+		key := namespacedIndexKey(u.GetNamespace(), u.GetName())
+
+		var services []metav1.Object
+		for _, item := range s.informer.GetIndexer().List() {
+			vs := item.(*unstructured.Unstructured)
+
+			var spec virtualServiceSpec
+			if err := decodeSpec(vs, &spec); err != nil {
+				continue
+			}
+
+			for _, gw := range gatewayKeys(vs, spec) {
+				if gw == key {
+					services = append(services, vs)
+					break
+				}
+			}
+		}
+
+		return services
+	}
+
+	return nil
+}
+
+// gatewayServers returns the servers of the Istio Gateways with the given
+// keys. Gateways which can't be found, for example because they live in a
+// namespace the Operator doesn't watch, are skipped.
+func (s *virtualServiceSource) gatewayServers(vs *unstructured.Unstructured, keys []string) []istioServer {
+	var servers []istioServer
+	for _, key := range keys {
+		item, exists, err := s.gatewayInformer.GetIndexer().GetByKey(key)
+		if err != nil || !exists {
+			log.Printf("Could not find Gateway %s for VirtualService %s:%s", key, vs.GetNamespace(), vs.GetName())
+			continue
+		}
+
+		var spec istioGatewaySpec
+		if err := decodeSpec(item.(*unstructured.Unstructured), &spec); err != nil {
+			log.Printf("Could not decode Gateway %s: %s", key, err)
+			continue
+		}
+
+		servers = append(servers, spec.Servers...)
+	}
+
+	return servers
+}
+
+// gatewayKeys returns the cache keys of the Istio Gateways the VirtualService
+// is bound to. Gateways are referenced as `namespace/name`, or by their name
+// when they live in the namespace of the VirtualService. The mesh gateway is
+// left out.
+func gatewayKeys(vs *unstructured.Unstructured, spec virtualServiceSpec) []string {
+	var keys []string
+	for _, gw := range spec.Gateways {
+		if gw == meshGateway {
+			continue
+		}
+
+		if !strings.Contains(gw, "/") {
+			gw = namespacedIndexKey(vs.GetNamespace(), gw)
+		}
+
+		keys = append(keys, gw)
+	}
+
+	return keys
+}
@@ -0,0 +1,63 @@
+package ingressmonitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResolveNamespaces returns the list of namespaces the Operator should watch.
+// This is the combination of the explicitly listed namespaces and the
+// namespaces which match the given label selector. When no namespaces are
+// given and the selector is empty, all namespaces are watched.
+//
+// Namespaces matching the selector are resolved once, namespaces which are
+// labelled after the Operator has started will be picked up on restart.
+func ResolveNamespaces(kc kubernetes.Interface, namespaces []string, selector string) ([]string, error) {
+	set := map[string]bool{}
+	for _, ns := range namespaces {
+		ns = strings.TrimSpace(ns)
+		if ns == v1.NamespaceAll {
+			continue
+		}
+
+		set[ns] = true
+	}
+
+	if selector != "" {
+		if _, err := labels.Parse(selector); err != nil {
+			return nil, fmt.Errorf("Invalid namespace selector `%s`: %s", selector, err)
+		}
+
+		nsList, err := kc.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("Could not list namespaces for selector `%s`: %s", selector, err)
+		}
+
+		if len(nsList.Items) == 0 && len(set) == 0 {
+			return nil, fmt.Errorf("No namespaces match selector `%s`", selector)
+		}
+
+		for _, ns := range nsList.Items {
+			set[ns.Name] = true
+		}
+	}
+
+	if len(set) == 0 {
+		return []string{v1.NamespaceAll}, nil
+	}
+
+	resolved := make([]string, 0, len(set))
+	for ns := range set {
+		resolved = append(resolved, ns)
+	}
+	sort.Strings(resolved)
+
+	return resolved, nil
+}
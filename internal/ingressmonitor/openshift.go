@@ -0,0 +1,110 @@
+package ingressmonitor
+
+import (
+	"log"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	routeGroup = "route.openshift.io"
+	routeKind  = "Route"
+)
+
+// routeGroupVersions are the API versions OpenShift Routes are served under,
+// in order of preference.
+var routeGroupVersions = []schema.GroupVersion{
+	{Group: routeGroup, Version: "v1"},
+}
+
+// Routes aren't part of the Kubernetes API, the Operator talks to them
+// through the dynamic client. These types describe the parts of the Route the
+// Operator uses.
+type routeSpec struct {
+	Host string                 `json:"host,omitempty"`
+	TLS  map[string]interface{} `json:"tls,omitempty"`
+}
+
+type routeStatus struct {
+	Ingress []routeIngress `json:"ingress,omitempty"`
+}
+
+type routeIngress struct {
+	Host string `json:"host,omitempty"`
+}
+
+// routeSource selects OpenShift Routes and sets up a target for their host.
+// A Route which configures TLS is served over HTTPS.
+type routeSource struct {
+	informer cache.SharedIndexInformer
+
+	// gv is the API version the cluster serves Routes under.
+	gv schema.GroupVersion
+}
+
+func (s *routeSource) label() string {
+	return routeLabel
+}
+
+func (s *routeSource) list(namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	return informerObjects(s.informer, namespace, selector)
+}
+
+func (s *routeSource) get(namespace, name string) (runtime.Object, bool) {
+	return informerObject(s.informer, namespace, name)
+}
+
+func (s *routeSource) selects(mon *v1alpha1.Monitor, obj metav1.Object) bool {
+	return true
+}
+
+func (s *routeSource) targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget {
+	route := obj.(*unstructured.Unstructured)
+
+	var spec routeSpec
+	if err := decodeSpec(route, &spec); err != nil {
+		log.Printf("Could not decode Route %s:%s: %s", route.GetNamespace(), route.GetName(), err)
+		return nil
+	}
+
+	// When no host is configured, the router generates one and reports it in
+	// the status.
+	host := spec.Host
+	if host == "" {
+		var status routeStatus
+		if raw, ok := route.Object["status"].(map[string]interface{}); ok {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &status); err != nil {
+				log.Printf("Could not decode status of Route %s:%s: %s", route.GetNamespace(), route.GetName(), err)
+				return nil
+			}
+		}
+
+		if len(status.Ingress) > 0 {
+			host = status.Ingress[0].Host
+		}
+	}
+
+	if !isMonitorableHost(host) {
+		return nil
+	}
+
+	return []monitorTarget{
+		newHostTarget(route, s.gv.WithKind(routeKind), routeLabel, host, spec.TLS != nil),
+	}
+}
+
+func (s *routeSource) related(obj interface{}) []metav1.Object {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || u.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: routeGroup, Kind: routeKind}) {
+		return nil
+	}
+
+	return []metav1.Object{u}
+}
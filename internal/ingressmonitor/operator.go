@@ -1,12 +1,12 @@
 package ingressmonitor
 
 import (
-	"bytes"
+	"context"
 	"encoding/base32"
 	"errors"
 	"fmt"
-	"html/template"
 	"log"
+	"reflect"
 	"strings"
 	"time"
 
@@ -17,28 +17,42 @@ import (
 	crdscheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
 	tv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/informers/externalversions"
+	imv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/informers/externalversions/ingressmonitor/v1alpha1"
 	lv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/listers/ingressmonitor/v1alpha1"
 
-	"k8s.io/api/extensions/v1beta1"
+	"k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
-	ev1beta1 "k8s.io/client-go/listers/extensions/v1beta1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	nlv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
-	"k8s.io/kubernetes/pkg/apis/extensions"
 
 	"github.com/dchest/blake2b"
 )
 
 const (
-	monitorLabel     = "ingressmonitor.sphc.io/monitor"
-	ingressLabel     = "ingressmonitor.sphc.io/ingress"
-	ingressHostLabel = "ingressmonitor.sphc.io/ingress-path"
+	monitorLabel        = "ingressmonitor.sphc.io/monitor"
+	ingressLabel        = "ingressmonitor.sphc.io/ingress"
+	serviceLabel        = "ingressmonitor.sphc.io/service"
+	httpRouteLabel      = "ingressmonitor.sphc.io/httproute"
+	routeLabel          = "ingressmonitor.sphc.io/route"
+	virtualServiceLabel = "ingressmonitor.sphc.io/virtualservice"
+	ingressHostLabel    = "ingressmonitor.sphc.io/ingress-path"
+	providerLabel       = "ingressmonitor.sphc.io/provider"
 )
 
 var (
@@ -51,21 +65,46 @@ type Operator struct {
 	kubeClient kubernetes.Interface
 	imClient   tv1alpha1.IngressmonitorV1alpha1Interface
 	metrics    *metrics.Metrics
+	recorder   record.EventRecorder
 
 	providerFactory provider.FactoryInterface
 
 	imInformer   cache.SharedIndexInformer
 	mInformer    cache.SharedIndexInformer
 	ingInformer  cache.SharedIndexInformer
+	svcInformer  cache.SharedIndexInformer
 	provInformer cache.SharedIndexInformer
 	mtInformer   cache.SharedIndexInformer
 
+	// clusterNamespace is the namespace the secrets of ClusterProviders are
+	// looked up in. ClusterProviders and ClusterMonitorTemplates aren't
+	// watched when it's empty.
+	clusterNamespace string
+
+	cprovInformer cache.SharedIndexInformer
+	cmtInformer   cache.SharedIndexInformer
+	nsInformer    cache.SharedIndexInformer
+
+	// ingressGVK is the GroupVersionKind the cluster serves Ingresses under.
+	// This is used to set up the OwnerReferences to the Ingresses.
+	ingressGVK schema.GroupVersionKind
+
 	informers []namedInformer
 
-	ingLister  ev1beta1.IngressLister
+	// sources are the adapters for the kinds of objects a Monitor can
+	// select, keyed by their SourceKind. Sources which are backed by
+	// optional APIs are only set up when the cluster serves them.
+	sources map[string]sourceAdapter
+
+	ingLister  nlv1.IngressLister
+	svcLister  corelisters.ServiceLister
 	provLister lv1alpha1.ProviderLister
 	mtLister   lv1alpha1.MonitorTemplateLister
 
+	cprovLister lv1alpha1.ClusterProviderLister
+	cmtLister   lv1alpha1.ClusterMonitorTemplateLister
+	nsLister    corelisters.NamespaceLister
+
 	monitorQueue        workqueue.RateLimitingInterface
 	ingressMonitorQueue workqueue.RateLimitingInterface
 }
@@ -76,52 +115,206 @@ type namedInformer struct {
 }
 
 // NewOperator sets up a new IngressMonitor Operator which will watch for
-// providers and monitors.
+// providers and monitors. The Operator only watches the given namespaces, when
+// no namespaces are given it watches the entire cluster. The dynamic client is
+// used to watch resources which aren't part of the Kubernetes API, like the
+// Gateway API, OpenShift Routes and Istio VirtualServices.
+//
+// ClusterProviders and ClusterMonitorTemplates are watched when a
+// clusterNamespace is given, the secrets of ClusterProviders are looked up in
+// that namespace.
 func NewOperator(
-	kc kubernetes.Interface, imc versioned.Interface,
-	namespace string, resync time.Duration,
+	kc kubernetes.Interface, imc versioned.Interface, dc dynamic.Interface,
+	namespaces []string, clusterNamespace string, resync time.Duration,
 	providerFactory provider.FactoryInterface,
 	mtrcs *metrics.Metrics) (*Operator, error) {
 
 	// Register the scheme with the client so we can use it through the API
 	crdscheme.AddToScheme(scheme.Scheme)
 
-	imInformer := externalversions.NewSharedInformerFactory(imc, resync).Ingressmonitor().V1alpha1()
-	k8sInformer := informers.NewSharedInformerFactory(kc, resync)
+	if len(namespaces) == 0 {
+		namespaces = []string{v1.NamespaceAll}
+	}
+
+	// Older clusters don't serve networking.k8s.io/v1 Ingresses yet, fall
+	// back to the API version they do serve.
+	ingressGV, err := discoverIngressGroupVersion(kc.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Using %s Ingresses", ingressGV)
+
+	// The Gateway API, OpenShift Routes and Istio are optional, only watch
+	// their resources when they're installed.
+	gatewayGV, hasGatewayAPI, err := discoverGroupVersion(kc.Discovery(), "httproutes", gatewayGroupVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	routeGV, hasRoutes, err := discoverGroupVersion(kc.Discovery(), "routes", routeGroupVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	istioGV, hasIstio, err := discoverGroupVersion(kc.Discovery(), "virtualservices", istioGroupVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set up namespaced informer factories so we only need permissions for
+	// the namespaces we're watching.
+	imFactories := map[string]imv1alpha1.Interface{}
+	k8sFactories := map[string]informers.SharedInformerFactory{}
+	dynFactories := map[string]dynamicinformer.DynamicSharedInformerFactory{}
+	for _, ns := range namespaces {
+		imFactories[ns] = externalversions.NewFilteredSharedInformerFactory(imc, resync, ns, nil).Ingressmonitor().V1alpha1()
+		k8sFactories[ns] = informers.NewFilteredSharedInformerFactory(kc, resync, ns, nil)
+		dynFactories[ns] = dynamicinformer.NewFilteredDynamicSharedInformerFactory(dc, resync, ns, nil)
+	}
+
+	// Record Events so users can see what's happening with their checks
+	// through `kubectl describe`.
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kc.CoreV1().Events("")})
 
 	op := &Operator{
 		kubeClient:          kc,
-		imClient:            imc.Ingressmonitor(),
+		recorder:            eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: eventComponent}),
+		imClient:            imc.IngressmonitorV1alpha1(),
 		providerFactory:     providerFactory,
 		monitorQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Monitors"),
 		ingressMonitorQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "IngressMonitors"),
 		metrics:             mtrcs,
-
-		imInformer:   imInformer.IngressMonitors().Informer(),
-		mInformer:    imInformer.Monitors().Informer(),
-		provInformer: imInformer.Providers().Informer(),
-		mtInformer:   imInformer.MonitorTemplates().Informer(),
-
-		ingInformer: k8sInformer.Extensions().V1beta1().Ingresses().Informer(),
+		ingressGVK:          ingressGV.WithKind("Ingress"),
+		clusterNamespace:    clusterNamespace,
+
+		imInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return imFactories[ns].IngressMonitors().Informer()
+		}),
+		mInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return imFactories[ns].Monitors().Informer()
+		}),
+		provInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return imFactories[ns].Providers().Informer()
+		}),
+		mtInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return imFactories[ns].MonitorTemplates().Informer()
+		}),
+
+		ingInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return newIngressInformer(kc, k8sFactories[ns], ingressGV, ns, resync)
+		}),
+		svcInformer: newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return k8sFactories[ns].Core().V1().Services().Informer()
+		}),
 	}
 
-	// Add EventHandlers for all objects we want to track
-	op.imInformer.AddEventHandler(op)
-	op.mInformer.AddEventHandler(op)
-
-	// set up listers
-	op.ingLister = ev1beta1.NewIngressLister(op.ingInformer.GetIndexer())
-	op.provLister = lv1alpha1.NewProviderLister(op.provInformer.GetIndexer())
-	op.mtLister = lv1alpha1.NewMonitorTemplateLister(op.mtInformer.GetIndexer())
-
 	op.informers = []namedInformer{
 		{"IngressMonitor", op.imInformer},
 		{"Monitor", op.mInformer},
 		{"Ingress", op.ingInformer},
+		{"Service", op.svcInformer},
 		{"Provider", op.provInformer},
 		{"MonitorTemplate", op.mtInformer},
 	}
 
+	// dynamicInformer sets up an informer for a resource which is watched
+	// through the dynamic client and makes sure it gets started.
+	dynamicInformer := func(name string, gvr schema.GroupVersionResource) cache.SharedIndexInformer {
+		informer := newNamespacedInformer(namespaces, func(ns string) cache.SharedIndexInformer {
+			return dynFactories[ns].ForResource(gvr).Informer()
+		})
+
+		op.informers = append(op.informers, namedInformer{name, informer})
+		return informer
+	}
+
+	// set up listers
+	op.ingLister = nlv1.NewIngressLister(op.ingInformer.GetIndexer())
+	op.svcLister = corelisters.NewServiceLister(op.svcInformer.GetIndexer())
+	op.provLister = lv1alpha1.NewProviderLister(op.provInformer.GetIndexer())
+	op.mtLister = lv1alpha1.NewMonitorTemplateLister(op.mtInformer.GetIndexer())
+
+	// Cluster resources are watched across the cluster, regardless of the
+	// namespaces the Operator is limited to.
+	if clusterNamespace != "" {
+		log.Printf("Using ClusterProviders with secrets from namespace %s", clusterNamespace)
+
+		imFactory := externalversions.NewSharedInformerFactory(imc, resync).Ingressmonitor().V1alpha1()
+		k8sFactory := informers.NewSharedInformerFactory(kc, resync)
+
+		op.cprovInformer = imFactory.ClusterProviders().Informer()
+		op.cmtInformer = imFactory.ClusterMonitorTemplates().Informer()
+		op.nsInformer = k8sFactory.Core().V1().Namespaces().Informer()
+
+		op.informers = append(op.informers,
+			namedInformer{"ClusterProvider", op.cprovInformer},
+			namedInformer{"ClusterMonitorTemplate", op.cmtInformer},
+			namedInformer{"Namespace", op.nsInformer},
+		)
+
+		op.cprovLister = lv1alpha1.NewClusterProviderLister(op.cprovInformer.GetIndexer())
+		op.cmtLister = lv1alpha1.NewClusterMonitorTemplateLister(op.cmtInformer.GetIndexer())
+		op.nsLister = corelisters.NewNamespaceLister(op.nsInformer.GetIndexer())
+	}
+
+	op.sources = map[string]sourceAdapter{
+		v1alpha1.SourceKindIngress: &ingressSource{
+			informer: op.ingInformer,
+			lister:   op.ingLister,
+			gvk:      op.ingressGVK,
+		},
+		v1alpha1.SourceKindService: &serviceSource{
+			informer: op.svcInformer,
+			lister:   op.svcLister,
+		},
+	}
+
+	if hasGatewayAPI {
+		log.Printf("Using %s HTTPRoutes", gatewayGV)
+
+		op.sources[v1alpha1.SourceKindHTTPRoute] = &httpRouteSource{
+			routeInformer:   dynamicInformer("HTTPRoute", gatewayGV.WithResource("httproutes")),
+			gatewayInformer: dynamicInformer("Gateway", gatewayGV.WithResource("gateways")),
+			gv:              gatewayGV,
+		}
+	}
+
+	if hasRoutes {
+		log.Printf("Using %s Routes", routeGV)
+
+		op.sources[v1alpha1.SourceKindRoute] = &routeSource{
+			informer: dynamicInformer("Route", routeGV.WithResource("routes")),
+			gv:       routeGV,
+		}
+	}
+
+	if hasIstio {
+		log.Printf("Using %s VirtualServices", istioGV)
+
+		op.sources[v1alpha1.SourceKindVirtualService] = &virtualServiceSource{
+			informer:        dynamicInformer("VirtualService", istioGV.WithResource("virtualservices")),
+			gatewayInformer: dynamicInformer("Istio Gateway", istioGV.WithResource("gateways")),
+			gv:              istioGV,
+		}
+	}
+
+	// Add EventHandlers for all objects we want to track
+	for _, inf := range op.informers {
+		inf.informer.AddEventHandler(op)
+	}
+
+	// Index the Monitors by their selector and references so we can find the
+	// Monitors which are affected by a change to a selected object, a
+	// Provider or a MonitorTemplate.
+	if err := op.mInformer.AddIndexers(cache.Indexers{
+		monitorSelectorIndex: monitorSelectorIndexFunc,
+		monitorProviderIndex: monitorProviderIndexFunc,
+		monitorTemplateIndex: monitorTemplateIndexFunc,
+	}); err != nil {
+		return nil, err
+	}
+
 	return op, nil
 }
 
@@ -246,6 +439,9 @@ func (o *Operator) handleNextItem(name string, queue workqueue.RateLimitingInter
 		}
 
 		if err := handlerFunc(key); err != nil {
+			// Put the item back on the queue so it gets retried with an
+			// exponential backoff.
+			queue.AddRateLimited(key)
 			return fmt.Errorf("Error handling '%s' in %s workqueue: %s", key, name, err)
 		}
 
@@ -279,7 +475,41 @@ func (o *Operator) enqueueMonitor(m *v1alpha1.Monitor) {
 	o.enqueueItem(o.monitorQueue, m)
 }
 
-// OnAdd handles adding of IngressMonitors and Ingresses and sets up the
+// enqueueOwningMonitor enqueues the Monitor which manages the given
+// IngressMonitor, so the status of the Monitor can be updated.
+func (o *Operator) enqueueOwningMonitor(im *v1alpha1.IngressMonitor) {
+	name, ok := im.Labels[monitorLabel]
+	if !ok {
+		return
+	}
+
+	o.monitorQueue.AddRateLimited(namespacedIndexKey(im.Namespace, name))
+}
+
+// enqueueMonitorsReferencing enqueues all the Monitors which reference the given
+// object through the specified index. This propagates Provider and
+// MonitorTemplate changes to the IngressMonitors which are built from them.
+func (o *Operator) enqueueMonitorsReferencing(index string, obj interface{}) {
+	for _, mon := range o.monitorsReferencing(index, obj) {
+		o.enqueueMonitor(mon)
+	}
+}
+
+// enqueueMonitorsUsingClusterProviders enqueues all the Monitors in the given
+// namespace which reference a ClusterProvider.
+func (o *Operator) enqueueMonitorsUsingClusterProviders(namespace string) {
+	cache.ListAllByNamespace(o.mInformer.GetIndexer(), namespace, labels.Everything(), func(obj interface{}) {
+		mon := obj.(*v1alpha1.Monitor)
+		for _, ref := range monitorProviders(mon) {
+			if providerKind(ref) == v1alpha1.ClusterProviderKind {
+				o.enqueueMonitor(mon)
+				return
+			}
+		}
+	})
+}
+
+// OnAdd handles adding of IngressMonitors and selected objects and sets up the
 // appropriate monitor with the configured providers.
 func (o *Operator) OnAdd(obj interface{}) {
 	switch obj := obj.(type) {
@@ -289,40 +519,133 @@ func (o *Operator) OnAdd(obj interface{}) {
 		o.enqueueIngressMonitor(obj)
 	case *v1alpha1.Monitor:
 		o.enqueueMonitor(obj)
+	case *v1alpha1.Provider:
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.MonitorTemplate:
+		o.validateMonitorTemplate(obj, obj.Spec)
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1alpha1.ClusterProvider:
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.ClusterMonitorTemplate:
+		o.validateMonitorTemplate(obj, obj.Spec)
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1.Namespace:
+		// Monitors are reconciled when they're added to the namespace.
+	default:
+		o.enqueueMonitorsForSourceObject(obj)
 	}
 }
 
-// OnUpdate handles updates of IngressMonitors anad Ingresses and configures the
+// OnUpdate handles updates of IngressMonitors and selected objects and configures the
 // checks with the configured providers.
 func (o *Operator) OnUpdate(old, new interface{}) {
 	switch obj := new.(type) {
 	case *v1alpha1.IngressMonitor:
+		// Don't resync when only the status or metadata has been updated,
+		// this is usually the operator writing the result of a sync.
+		oldIM := old.(*v1alpha1.IngressMonitor)
+		if !ingressMonitorChanged(oldIM, obj) {
+			// The Monitor keeps track of how many IngressMonitors are ready.
+			if readyChanged(oldIM, obj) {
+				o.enqueueOwningMonitor(obj)
+			}
+			return
+		}
+
 		o.enqueueIngressMonitor(obj)
 	case *v1alpha1.Monitor:
+		// Don't reconcile when only the status has been updated.
+		if !monitorChanged(old.(*v1alpha1.Monitor), obj) {
+			return
+		}
+
 		o.enqueueMonitor(obj)
+	case *v1alpha1.Provider:
+		if old.(*v1alpha1.Provider).ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.MonitorTemplate:
+		if old.(*v1alpha1.MonitorTemplate).ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		o.validateMonitorTemplate(obj, obj.Spec)
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1alpha1.ClusterProvider:
+		if old.(*v1alpha1.ClusterProvider).ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.ClusterMonitorTemplate:
+		if old.(*v1alpha1.ClusterMonitorTemplate).ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		o.validateMonitorTemplate(obj, obj.Spec)
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1.Namespace:
+		// The labels of a namespace decide whether or not its Monitors may
+		// use a ClusterProvider.
+		if reflect.DeepEqual(old.(*v1.Namespace).Labels, obj.Labels) {
+			return
+		}
+
+		o.enqueueMonitorsUsingClusterProviders(obj.Name)
+	default:
+		oldMeta, err := meta.Accessor(old)
+		if err != nil {
+			return
+		}
+
+		newMeta, err := meta.Accessor(new)
+		if err != nil {
+			return
+		}
+
+		// This is a periodic resync, the Monitors resync themselves.
+		if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+			return
+		}
+
+		// The labels might have changed, which means the Monitors selecting
+		// the old object need to garbage collect their IngressMonitors.
+		o.enqueueMonitorsForSourceObject(old)
+		o.enqueueMonitorsForSourceObject(new)
 	}
 }
 
-// OnDelete handles deletion of IngressMonitors and Ingresses and deletes
+// OnDelete handles deletion of IngressMonitors and selected objects and deletes
 // monitors from the configured providers.
 func (o *Operator) OnDelete(obj interface{}) {
+	// We might have missed the delete event, in which case the informer
+	// passes us the last known state of the object.
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
 	switch obj := obj.(type) {
+	case *v1alpha1.Provider:
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.MonitorTemplate:
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1alpha1.ClusterProvider:
+		o.enqueueMonitorsReferencing(monitorProviderIndex, obj)
+	case *v1alpha1.ClusterMonitorTemplate:
+		o.enqueueMonitorsReferencing(monitorTemplateIndex, obj)
+	case *v1.Namespace:
+		// The Monitors in the namespace are deleted with it.
 	case *v1alpha1.IngressMonitor:
+		// The monitor has been removed from the provider by the finalizer
+		// before the IngressMonitor got deleted.
 		o.metrics.DeleteIngressMonitor(ingressMonitorMetric(obj, nil))
 
-		cl, err := o.providerFactory.From(obj.Spec.Provider)
-		if err != nil {
-			log.Printf("Could not get provider for IngressMonitor %s:%s: %s", obj.Namespace, obj.Name, err)
-			return
-		}
-
-		if err := cl.Delete(obj.Status.ID); err != nil {
-			log.Printf("Could not delete IngressMonitor %s:%s: %s", obj.Namespace, obj.Name, err)
-			return
-		}
+		o.enqueueOwningMonitor(obj)
 	case *v1alpha1.Monitor:
 		imList, err := o.imClient.IngressMonitors(obj.Namespace).
-			List(listOptions(map[string]string{monitorLabel: obj.Name}))
+			List(context.TODO(), listOptions(map[string]string{monitorLabel: obj.Name}))
 		if err != nil {
 			log.Printf("Could not list IngressMonitors for Monitors %s:%s: %s", obj.Namespace, obj.Name, err)
 			return
@@ -331,10 +654,12 @@ func (o *Operator) OnDelete(obj interface{}) {
 		for _, im := range imList.Items {
 			log.Printf("Deleting IngressMonitor `%s:%s` associated with deleted Monitor `%s:%s`", im.Namespace, im.Name, obj.Namespace, obj.Name)
 			if err := o.imClient.IngressMonitors(obj.Namespace).
-				Delete(im.Name, &metav1.DeleteOptions{}); err != nil {
+				Delete(context.TODO(), im.Name, metav1.DeleteOptions{}); err != nil {
 				log.Printf("Could not delete IngressMonitor %s for Monitors %s:%s: %s", im.Name, obj.Namespace, obj.Name, err)
 			}
 		}
+	default:
+		o.enqueueMonitorsForSourceObject(obj)
 	}
 }
 
@@ -351,93 +676,126 @@ func (o *Operator) handleIngressMonitor(key string) (err error) {
 		return nil
 	}
 
-	obj := item.(*v1alpha1.IngressMonitor)
+	// don't modify the object in the cache
+	obj := item.(*v1alpha1.IngressMonitor).DeepCopy()
 
 	// XXX handle indexer errors
 	defer func() {
 		o.metrics.SyncIngressMonitor(ingressMonitorMetric(obj, err))
 	}()
 
+	if obj.DeletionTimestamp != nil {
+		return o.finalizeIngressMonitor(obj)
+	}
+
+	// Make sure we get the chance to remove the monitor from the provider
+	// before the IngressMonitor is removed from the cluster.
+	if !hasFinalizer(obj, ingressMonitorFinalizer) {
+		obj.Finalizers = append(obj.Finalizers, ingressMonitorFinalizer)
+
+		updated, err := o.imClient.IngressMonitors(obj.Namespace).Update(context.TODO(), obj, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("Could not add finalizer: %s", err)
+		}
+		obj = updated
+	}
+
+	status := obj.Status.DeepCopy()
+	if ingName, ok := obj.Labels[ingressLabel]; ok {
+		status.IngressName = ingName
+	}
+
+	reason, err := o.syncIngressMonitor(obj, status)
+	o.recordSyncEvent(obj, status.ID, err)
+	setSyncStatus(status, obj.Status, obj.Generation, reason, err)
+
+	// Always write the status so a failed sync is visible on the object.
+	if serr := o.updateIngressMonitorStatus(obj, status); serr != nil && err == nil {
+		err = serr
+	}
+
+	return err
+}
+
+// syncIngressMonitor creates or updates the monitor with the provider and
+// stores the details of the monitor in the given status. The returned reason
+// describes the outcome of the sync and is used for the status conditions.
+func (o *Operator) syncIngressMonitor(obj *v1alpha1.IngressMonitor, status *v1alpha1.IngressMonitorStatus) (string, error) {
+	if reason, err := o.authorizeProvider(obj); err != nil {
+		return reason, err
+	}
+
 	cl, err := o.providerFactory.From(obj.Spec.Provider)
 	if err != nil {
-		return fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
+		return reasonProviderUnavailable, fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
 	}
 
 	var id string
-	if obj.Status.ID != "" {
-		// This object hasn't been created yet, do so!
-		id, err = cl.Update(obj.Status.ID, obj.Spec.Template)
+	if status.ID != "" {
+		id, err = cl.Update(status.ID, obj.Spec.Template)
+		if err != nil {
+			return reasonUpdateFailed, err
+		}
 	} else {
+		// This object hasn't been created yet, do so!
 		id, err = cl.Create(obj.Spec.Template)
+		if err != nil {
+			return reasonCreateFailed, err
+		}
 	}
 
-	if err != nil {
-		return err
-	}
-
-	// The ID has changed, update the status. This could happen when the test
-	// has been removed from the provider. The operator ensures that the test
-	// will be present, and thus create a new one.
-	if obj.Status.ID != id {
-		obj.Status.ID = id
-		_, err = o.imClient.IngressMonitors(obj.Namespace).Update(obj)
+	// The ID could have changed when the test has been removed from the
+	// provider. The operator ensures that the test will be present, and thus
+	// create a new one.
+	status.ID = id
+	if u, ok := cl.(provider.URLer); ok {
+		status.ProviderURL = u.URL(id)
 	}
 
-	return err
+	return reasonSynced, nil
 }
 
 // garbgageCollectMonitors finds all IngressMonitors that are linked to a
 // specific Monitor which shouldn't be configured in the cluster anymore.
-// It does this by fetching all Ingresses which should currently be set up for
+// It does this by fetching all targets which should currently be set up for
 // the monitor and then fetching the IngressMonitors which are linked to the
 // specified Monitor.
-// If one of the monitors isn't linked to the Ingress, it gets marked for
+// If one of the monitors isn't linked to a target, it gets marked for
 // deletion.
 func (o *Operator) garbageCollectMonitors(obj *v1alpha1.Monitor) error {
-	ingLabels, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+	targets, _, err := o.monitorTargets(obj)
 	if err != nil {
-		return fmt.Errorf("Could not create label selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+		return err
 	}
 
-	ingressList, err := o.ingLister.Ingresses(obj.Namespace).List(ingLabels)
-	if err != nil {
-		return fmt.Errorf("Could not list Ingresses: %s", err)
+	// The name of an IngressMonitor is derived from the object and host it's
+	// set up for, and the provider it's set up with. Selected objects might
+	// change which means a specific host can be dropped, as can a provider
+	// which is removed from the Monitor. We need to GC that.
+	active := map[string]bool{}
+	for _, target := range targets {
+		for _, ref := range monitorProviders(obj) {
+			active[ingressMonitorName(obj, target, ref)] = true
+		}
 	}
 
 	// We'll calculate all the IngressMonitors that shouldn't be tracked
 	// anymore and delete them. We can do this by fetching all
 	// IngressMonitors where the owner is this Monitor, go over them all and
-	// see if there are any where the Ingress Owner isn't in the new Ingress
-	// List.
+	// see if there are any which aren't linked to a target anymore.
 	imLabels := labels.SelectorFromSet(map[string]string{monitorLabel: obj.Name})
 	cache.ListAllByNamespace(o.imInformer.GetIndexer(), obj.Namespace, imLabels, func(imObj interface{}) {
 		im := imObj.(*v1alpha1.IngressMonitor)
-		var isActive bool
-
-		// Go through all newly selected Ingresses and see if this
-		// IngressMonitor is active for any of them. We do this by first
-		// validating if it's controlled by the Ingress, and if so we check if
-		// it matches any of the rules. Ingresses might change which means a
-		// specific rule can be dropped. We need to GC that.
-		for _, ing := range ingressList {
-			if metav1.IsControlledBy(im, ing) {
-				for _, rule := range ing.Spec.Rules {
-					if rule.Host == im.Labels[ingressHostLabel] {
-						isActive = true
-					}
-				}
-			}
-		}
 
-		// The IngressMonitor doesn't appear in any newly selected Ingress
+		// The IngressMonitor doesn't appear in any newly selected object
 		// anymore, which means it's ready for GarbageCollection. Delete the
 		// IngressMonitor Resource from the server, which will then trigger a
 		// reconciliation to take care of actually removing the monitor with the
 		// provider.
-		if !isActive {
+		if !active[im.Name] {
 			log.Printf("Deleting IngressMonitor %s:%s with GC", im.Namespace, im.Name)
 			if err := o.imClient.IngressMonitors(im.Namespace).
-				Delete(im.Name, &metav1.DeleteOptions{}); err != nil {
+				Delete(context.TODO(), im.Name, metav1.DeleteOptions{}); err != nil {
 
 				log.Printf("Could not delete IngressMonitor %s:%s: %s", im.Namespace, im.Name, err)
 			}
@@ -458,133 +816,227 @@ func (o *Operator) handleMonitor(key string) error {
 		return nil
 	}
 
-	obj := item.(*v1alpha1.Monitor)
-	if err := o.garbageCollectMonitors(obj); err != nil {
-		return fmt.Errorf("Error doing garbage collection for %s:%s: %s", obj.Namespace, obj.Name, err)
+	// don't modify the object in the cache
+	obj := item.(*v1alpha1.Monitor).DeepCopy()
+
+	// The status is calculated from scratch on every reconcile, only the
+	// conditions are kept to track their transition times.
+	status := &v1alpha1.MonitorStatus{
+		Conditions: obj.Status.DeepCopy().Conditions,
 	}
 
-	ingLabels, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+	err = o.reconcileMonitor(obj, status)
 	if err != nil {
-		return fmt.Errorf("Could not create label selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+		o.recorder.Eventf(obj, v1.EventTypeWarning, eventReasonReconcileFailed, "Could not reconcile Monitor: %s", err)
+	}
+	o.setMonitorStatus(obj, status, err)
+
+	if serr := o.updateMonitorStatus(obj, status); serr != nil && err == nil {
+		err = serr
 	}
 
-	ingressList, err := o.ingLister.Ingresses(obj.Namespace).List(ingLabels)
+	return err
+}
+
+// reconcileMonitor ensures that there is an IngressMonitor for every target of
+// the objects selected by the Monitor. The given status is updated with the
+// result of resolving the references and selecting the objects.
+func (o *Operator) reconcileMonitor(obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus) error {
+	if err := o.garbageCollectMonitors(obj); err != nil {
+		return fmt.Errorf("Error doing garbage collection for %s:%s: %s", obj.Namespace, obj.Name, err)
+	}
+
+	targets, selected, err := o.monitorTargets(obj)
 	if err != nil {
-		return fmt.Errorf("Could not list Ingresses: %s", err)
+		return err
 	}
 
-	if len(ingressList) == 0 {
-		log.Printf("No ingresses selected for %s:%s", obj.Namespace, obj.Name)
+	// Problems with the selected objects are reported once they've been
+	// looked at, even when the reconcile fails later on.
+	var warnings []objectWarning
+	defer func() { o.recordWarnings(obj, status, warnings) }()
+
+	status.SelectedIngresses = len(selected)
+	if len(selected) == 0 {
+		log.Printf("No %s objects selected for %s:%s", sourceKind(obj), obj.Namespace, obj.Name)
 		return nil
 	}
 
-	prov, err := o.provLister.Providers(obj.Namespace).Get(obj.Spec.Provider.Name)
-	if err != nil {
-		return fmt.Errorf("Could not get Provider %s:%s: %s", obj.Namespace, obj.Spec.Provider.Name, err)
+	for _, sel := range selected {
+		warnings = append(warnings, skippedHosts(sel)...)
+	}
+
+	refs := monitorProviders(obj)
+	if len(refs) == 0 {
+		err := fmt.Errorf("No provider configured for Monitor %s:%s", obj.Namespace, obj.Name)
+		setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonProviderNotFound, err.Error())
+		return err
+	}
+
+	provs := make([]v1alpha1.NamespacedProvider, len(refs))
+	for i, ref := range refs {
+		prov, reason, err := o.resolveProvider(obj, ref)
+		if err != nil {
+			setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reason, err.Error())
+			return err
+		}
+
+		provs[i] = prov
 	}
 
-	tmpl, err := o.mtLister.MonitorTemplates(obj.Namespace).Get(obj.Spec.Template.Name)
+	tmpl, reason, err := o.resolveTemplate(obj)
 	if err != nil {
-		return fmt.Errorf("Could not get MonitorTemplate %s: %s", obj.Spec.Template.Name, err)
-	}
-
-	// reconcile the newly selected Ingresses. We'll create new IngressMonitors
-	// for each Ingress and it's subsequent rules. If it already exists, we
-	// update it.
-	for _, ing := range ingressList {
-		for _, rule := range ing.Spec.Rules {
-			name := fmt.Sprintf("%s-%s", ing.Name, shortHash(rule.Host, 16))
-
-			// we can only assign one reference that controls the object, ensure
-			// that it's the Ingress so that we can still perform garbage
-			// collection.
-			monitorReference := *metav1.NewControllerRef(
-				obj,
-				v1alpha1.SchemeGroupVersion.WithKind("Monitor"),
-			)
-			monitorReference.Controller = nil
-
-			templateSpec := tmpl.Spec
-			tplName, err := templatedName(ing, templateSpec)
-			if err != nil {
-				return fmt.Errorf("Could not get templated name: %s", err)
-			}
-			templateSpec.Name = tplName
-
-			healthPath := "/_healthz"
-
-			scheme := "http://"
-		TLSLoop:
-			for _, tlsList := range ing.Spec.TLS {
-				for _, host := range tlsList.Hosts {
-					if host == rule.Host {
-						scheme = "https://"
-						break TLSLoop
-					}
-				}
-			}
+		setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reason, err.Error())
+		return err
+	}
 
-			if templateSpec.HTTP.Endpoint != nil {
-				healthPath = *templateSpec.HTTP.Endpoint
-			}
-			monitorURL := fmt.Sprintf("%s%s%s", scheme, rule.Host, healthPath)
-			templateSpec.HTTP.URL = monitorURL
-
-			im := &v1alpha1.IngressMonitor{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      name,
-					Namespace: ing.Namespace,
-					// Add OwnerReferences to the IngressMonitor so we can
-					// automatically Garbage Collect when either a Monitor is
-					// removed or when the Ingress is removed. This way we don't
-					// have to set this up ourselves.
-					OwnerReferences: []metav1.OwnerReference{
-						*metav1.NewControllerRef(
-							ing,
-							extensions.SchemeGroupVersion.WithKind("Ingress"),
-						),
-						monitorReference,
-					},
-					// Set some labels so it's easier to filter later on
-					Labels: map[string]string{
-						monitorLabel:     obj.Name,
-						ingressLabel:     ing.Name,
-						ingressHostLabel: rule.Host,
-					},
-				},
-				Spec: v1alpha1.IngressMonitorSpec{
-					Provider: v1alpha1.NamespacedProvider{
-						Namespace:    obj.Namespace,
-						ProviderSpec: prov.Spec,
-					},
-					Template: templateSpec,
-				},
+	// Don't set up any IngressMonitors with a template which can't be
+	// rendered.
+	if err := ValidateTemplateSpec(*tmpl); err != nil {
+		err = fmt.Errorf("Invalid %s %s: %s", templateKind(obj), obj.Spec.Template.Name, err)
+		setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonTemplateInvalid, err.Error())
+		return err
+	}
+
+	// Don't set up any IngressMonitors which a provider can't sync.
+	for _, prov := range provs {
+		if err := o.providerFactory.ValidateCheckType(prov.Type, tmpl.Type); err != nil {
+			err = fmt.Errorf("Can't use %s %s with the provider: %s", templateKind(obj), obj.Spec.Template.Name, err)
+			setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonCheckTypeNotSupported, err.Error())
+			return err
+		}
+	}
+
+	setMonitorCondition(status, v1alpha1.MonitorReferencesResolved, v1.ConditionTrue, reasonResolved, "")
+
+	// reconcile the newly selected targets. We'll create new IngressMonitors
+	// for each target and provider. If it already exists, we update it.
+	for _, target := range targets {
+		for i, ref := range refs {
+			// don't modify the template in the cache
+			templateSpec := *tmpl.DeepCopy()
+			if err := renderTemplateSpec(&templateSpec, newTemplateData(target, obj, ref.Name)); err != nil {
+				return fmt.Errorf("Could not render %s %s: %s", templateKind(obj), obj.Spec.Template.Name, err)
 			}
 
-			gIM, err := o.imClient.IngressMonitors(im.Namespace).
-				Get(im.Name, metav1.GetOptions{})
-			if kerrors.IsNotFound(err) {
-				_, err = o.imClient.IngressMonitors(im.Namespace).Create(im)
-			} else if err == nil {
-				im.ObjectMeta = gIM.ObjectMeta
-				im.TypeMeta = gIM.TypeMeta
-				im.Status = gIM.Status
-				im.Status.IngressName = ing.Name
-
-				_, err = o.imClient.IngressMonitors(im.Namespace).Update(im)
+			// Annotations on the selected object can override the template
+			// for that object.
+			errs := applyAnnotations(target.owner, &templateSpec)
+			warnings = append(warnings, annotationWarnings(target.owner, errs)...)
+
+			templateSpecFor(target, &templateSpec)
+
+			if err := o.ensureIngressMonitor(obj, target, ref, provs[i], templateSpec); err != nil {
+				return err
 			}
+		}
+	}
 
-			if err != nil {
-				return fmt.Errorf("Could not ensure IngressMonitor: %s", err)
+	return nil
+}
+
+// ensureIngressMonitor creates or updates the IngressMonitor for the target of
+// the Monitor with the referenced provider.
+func (o *Operator) ensureIngressMonitor(obj *v1alpha1.Monitor, target monitorTarget, ref v1alpha1.ProviderReference, prov v1alpha1.NamespacedProvider, templateSpec v1alpha1.MonitorTemplateSpec) error {
+	// we can only assign one reference that controls the object, ensure
+	// that it's the selected object so that we can still perform garbage
+	// collection.
+	monitorReference := *metav1.NewControllerRef(
+		obj,
+		v1alpha1.SchemeGroupVersion.WithKind("Monitor"),
+	)
+	monitorReference.Controller = nil
+
+	controllerReference := *metav1.NewControllerRef(
+		target.owner,
+		target.ownerGVK,
+	)
+
+	// Set some labels so it's easier to filter later on
+	imLabels := map[string]string{
+		monitorLabel:      obj.Name,
+		target.ownerLabel: target.owner.GetName(),
+	}
+	// Load balancer hostnames can be too long to be used as a label, as can
+	// provider names.
+	if len(validation.IsValidLabelValue(target.host)) == 0 {
+		imLabels[ingressHostLabel] = target.host
+	}
+	if len(validation.IsValidLabelValue(ref.Name)) == 0 {
+		imLabels[providerLabel] = ref.Name
+	}
+
+	im := &v1alpha1.IngressMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressMonitorName(obj, target, ref),
+			Namespace: obj.Namespace,
+			// Make sure the monitor gets removed from the provider before the
+			// IngressMonitor gets deleted.
+			Finalizers: []string{ingressMonitorFinalizer},
+			// Add OwnerReferences to the IngressMonitor so we can
+			// automatically Garbage Collect when either a Monitor is removed
+			// or when the selected object is removed. This way we don't have
+			// to set this up ourselves.
+			OwnerReferences: []metav1.OwnerReference{
+				controllerReference,
+				monitorReference,
+			},
+			Labels: imLabels,
+		},
+		Spec: v1alpha1.IngressMonitorSpec{
+			Provider: prov,
+			Template: templateSpec,
+		},
+	}
+
+	gIM, err := o.imClient.IngressMonitors(im.Namespace).
+		Get(context.TODO(), im.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = o.imClient.IngressMonitors(im.Namespace).Create(context.TODO(), im, metav1.CreateOptions{})
+	} else if err == nil {
+		im.ObjectMeta = gIM.ObjectMeta
+		im.TypeMeta = gIM.TypeMeta
+		im.Status = gIM.Status
+
+		// IngressMonitors which were set up before the selected object was
+		// watched through another API version, like Ingresses moving from
+		// extensions/v1beta1 to networking.k8s.io/v1, reference it through
+		// the old version. Point them at the version which is watched now.
+		for i, ref := range im.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller && ref.UID == controllerReference.UID {
+				im.OwnerReferences[i] = controllerReference
 			}
+		}
 
-			log.Printf("Successfully synced IngressMonitor %s:%s", im.Namespace, im.Name)
+		// IngressMonitors which were set up before they were labelled with
+		// their provider don't have the label yet.
+		if name, ok := imLabels[providerLabel]; ok && im.Labels[providerLabel] != name {
+			if im.Labels == nil {
+				im.Labels = map[string]string{}
+			}
+			im.Labels[providerLabel] = name
 		}
+
+		_, err = o.imClient.IngressMonitors(im.Namespace).Update(context.TODO(), im, metav1.UpdateOptions{})
+	}
+
+	if err != nil {
+		return fmt.Errorf("Could not ensure IngressMonitor: %s", err)
 	}
 
+	log.Printf("Successfully synced IngressMonitor %s:%s", im.Namespace, im.Name)
 	return nil
 }
 
+// validateMonitorTemplate records an Event on the MonitorTemplate or
+// ClusterMonitorTemplate when its templates can't be rendered, so the error is
+// visible where it has to be fixed.
+func (o *Operator) validateMonitorTemplate(tmpl runtime.Object, spec v1alpha1.MonitorTemplateSpec) {
+	if err := ValidateTemplateSpec(spec); err != nil {
+		o.recorder.Eventf(tmpl, v1.EventTypeWarning, eventReasonInvalidTemplate, "Invalid template: %s", err)
+	}
+}
+
 func listOptions(lbls map[string]string) metav1.ListOptions {
 	return metav1.ListOptions{
 		LabelSelector: labels.FormatLabels(lbls),
@@ -600,27 +1052,6 @@ func shortHash(data string, len int) string {
 	return strings.ToLower(encoder.EncodeToString(b2b.Sum(nil)))
 }
 
-func templatedName(ing *v1beta1.Ingress, sp v1alpha1.MonitorTemplateSpec) (string, error) {
-	tpl, err := template.New("im-name").Parse(sp.Name)
-	if err != nil {
-		return "", err
-	}
-
-	data := struct {
-		IngressName      string
-		IngressNamespace string
-	}{
-		IngressName:      ing.Name,
-		IngressNamespace: ing.Namespace,
-	}
-
-	buf := bytes.NewBufferString("")
-	if err := tpl.Execute(buf, data); err != nil {
-		return "", err
-	}
-	return buf.String(), nil
-}
-
 func ingressMonitorMetric(obj *v1alpha1.IngressMonitor, err error) metrics.IngressMonitorMetric {
 	var success bool
 	if err == nil {
@@ -1,8 +1,12 @@
 package ingressmonitor
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,11 +18,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -54,30 +65,273 @@ func TestOperator_RunShutdown(t *testing.T) {
 	})
 }
 
-func TestOperator_DeleteIngressMonitor(t *testing.T) {
-	t.Run("delete the monitor with the provider", func(t *testing.T) {
-		im := newIngressMonitor()
-		im.Status.ID = "12345"
+func TestOperator_Namespaces(t *testing.T) {
+	t.Run("with a single namespace", func(t *testing.T) {
+		op := newOperator(t, withNamespaces("testing"))
+
+		if _, ok := op.op.ingInformer.(*multiNamespaceInformer); ok {
+			t.Errorf("Expected a single namespace to use the namespaced informer directly")
+		}
+	})
+
+	t.Run("with multiple namespaces", func(t *testing.T) {
+		otherMonitor := newMonitor()
+		otherMonitor.Namespace = "other-namespace"
+
 		op := newOperator(t,
-			withIngressMonitors(im),
+			withNamespaces("testing", "other-namespace"),
 			withIngresses(newIngress()),
 			withProviders(newProvider()),
 			withTemplates(newTemplate()),
+			withMonitors(otherMonitor),
 		)
 
-		prov := new(fake.SimpleProvider)
+		t.Run("routing events to the right namespace", func(t *testing.T) {
+			op.op.OnAdd(newIngress())
+			queueEquals(t, op.op.monitorQueue)
+
+			ing := newIngress()
+			ing.Namespace = "other-namespace"
+			op.op.OnAdd(ing)
+			queueEquals(t, op.op.monitorQueue, "other-namespace/test-monitor")
+		})
+
+		t.Run("creating IngressMonitors", func(t *testing.T) {
+			mon := newMonitor()
+			errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+
+			if len(imList.Items) != 1 {
+				t.Errorf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+			}
+		})
+
+		t.Run("with an object in a namespace which isn't watched", func(t *testing.T) {
+			ing := newIngress()
+			ing.Namespace = "not-watched"
+
+			if err := op.op.ingInformer.GetIndexer().Add(ing); err == nil {
+				t.Errorf("Expected an error adding an Ingress to a namespace which isn't watched")
+			}
+
+			_, exists, err := op.op.ingInformer.GetIndexer().GetByKey(getKey(t, ing))
+			errEquals(t, nil, err, "getting the Ingress")
+
+			if exists {
+				t.Errorf("Expected the Ingress not to exist")
+			}
+		})
+
+		t.Run("syncing the caches", func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
+		})
+	})
+}
+
+func TestResolveNamespaces(t *testing.T) {
+	newNamespace := func(name string, lbls map[string]string) *v1.Namespace {
+		return &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: lbls,
+			},
+		}
+	}
+
+	k8sClient := k8sfake.NewSimpleClientset(
+		newNamespace("gophers-prod", map[string]string{"team": "gophers"}),
+		newNamespace("gophers-staging", map[string]string{"team": "gophers"}),
+		newNamespace("rustaceans", map[string]string{"team": "rustaceans"}),
+	)
+
+	tcs := []struct {
+		name       string
+		namespaces []string
+		selector   string
+		expected   []string
+		err        error
+	}{
+		{
+			"without namespaces or selector",
+			nil,
+			"",
+			[]string{v1.NamespaceAll},
+			nil,
+		},
+		{
+			"with a list of namespaces",
+			[]string{"websites", " apis", "websites"},
+			"",
+			[]string{"apis", "websites"},
+			nil,
+		},
+		{
+			"with a namespace selector",
+			nil,
+			"team=gophers",
+			[]string{"gophers-prod", "gophers-staging"},
+			nil,
+		},
+		{
+			"with namespaces and a selector",
+			[]string{"websites"},
+			"team=rustaceans",
+			[]string{"rustaceans", "websites"},
+			nil,
+		},
+		{
+			"with a selector which doesn't match",
+			nil,
+			"team=pythonistas",
+			nil,
+			errors.New("No namespaces match selector `team=pythonistas`"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			namespaces, err := ResolveNamespaces(k8sClient, tc.namespaces, tc.selector)
+			errEquals(t, tc.err, err)
+
+			if !reflect.DeepEqual(tc.expected, namespaces) {
+				t.Errorf("Expected namespaces %v, got %v", tc.expected, namespaces)
+			}
+		})
+	}
+}
+
+func TestOperator_DeleteIngressMonitor(t *testing.T) {
+	var op *operatorWrapper
+	var prov *fake.SimpleProvider
+
+	setup := func() {
+		op = newOperator(t)
+		prov = new(fake.SimpleProvider)
 		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+	}
+
+	deletedIngressMonitor := func(id string) *v1alpha1.IngressMonitor {
+		now := metav1.Now()
+
+		im := newIngressMonitor()
+		im.Status.ID = id
+		im.DeletionTimestamp = &now
+		im.Finalizers = []string{ingressMonitorFinalizer}
+		return im
+	}
+
+	finalizers := func(im *v1alpha1.IngressMonitor) []string {
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		return im.Finalizers
+	}
+
+	t.Run("adds the finalizer when syncing", func(t *testing.T) {
+		setup()
+
+		prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "12345", nil
+		}
+
+		im := newIngressMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "syncing the IngressMonitor")
+
+		if fnl := finalizers(im); !reflect.DeepEqual(fnl, []string{ingressMonitorFinalizer}) {
+			t.Errorf("Expected finalizer to be set, got %v", fnl)
+		}
+	})
+
+	t.Run("delete the monitor with the provider", func(t *testing.T) {
+		setup()
 
 		prov.DeleteFunc = func(id string) error {
 			strEquals(t, "12345", id, "deleting the IngressMonitor")
 			return nil
 		}
 
-		op.op.OnDelete(im)
+		im := deletedIngressMonitor("12345")
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "finalizing the IngressMonitor")
 
 		if prov.DeleteCount != 1 {
 			t.Errorf("Expected the delete action to be called")
 		}
+
+		if fnl := finalizers(im); len(fnl) != 0 {
+			t.Errorf("Expected finalizer to be removed, got %v", fnl)
+		}
+	})
+
+	t.Run("monitor already removed from the provider", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(id string) error {
+			return provider.ErrMonitorNotFound
+		}
+
+		im := deletedIngressMonitor("12345")
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "finalizing the IngressMonitor")
+
+		if fnl := finalizers(im); len(fnl) != 0 {
+			t.Errorf("Expected finalizer to be removed, got %v", fnl)
+		}
+	})
+
+	t.Run("monitor never created with the provider", func(t *testing.T) {
+		setup()
+
+		im := deletedIngressMonitor("")
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "finalizing the IngressMonitor")
+
+		if prov.DeleteCount != 0 {
+			t.Errorf("Expected the delete action not to be called")
+		}
+
+		if fnl := finalizers(im); len(fnl) != 0 {
+			t.Errorf("Expected finalizer to be removed, got %v", fnl)
+		}
+	})
+
+	t.Run("provider error keeps the finalizer and retries", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(id string) error {
+			return errors.New("provider unavailable")
+		}
+
+		im := deletedIngressMonitor("12345")
+		op.op.imInformer.GetIndexer().Add(im)
+		op.op.imClient.IngressMonitors(im.Namespace).Create(context.TODO(), im, metav1.CreateOptions{})
+		op.op.enqueueIngressMonitor(im)
+
+		if !op.op.processNextIngressMonitor() {
+			t.Fatalf("Expected the queue to keep processing items")
+		}
+
+		if fnl := finalizers(im); !reflect.DeepEqual(fnl, []string{ingressMonitorFinalizer}) {
+			t.Errorf("Expected finalizer to be kept, got %v", fnl)
+		}
+
+		if l := op.op.ingressMonitorQueue.Len(); l != 1 {
+			t.Errorf("Expected IngressMonitor to be requeued, got %d items", l)
+		}
+	})
+
+	t.Run("removing the IngressMonitor doesn't call the provider", func(t *testing.T) {
+		setup()
+
+		im := newIngressMonitor()
+		im.Status.ID = "12345"
+		op.op.OnDelete(im)
+
+		if prov.DeleteCount != 0 {
+			t.Errorf("Expected the delete action not to be called")
+		}
 	})
 }
 
@@ -92,7 +346,7 @@ func TestOperator_DeleteMonitor(t *testing.T) {
 		mon := newMonitor()
 		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
 
-		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 		errEquals(t, nil, err, "listing the IngressMonitors")
 
 		if len(imList.Items) != 1 {
@@ -101,7 +355,7 @@ func TestOperator_DeleteMonitor(t *testing.T) {
 
 		op.op.OnDelete(mon)
 
-		imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+		imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 		errEquals(t, nil, err, "listing the IngressMonitors")
 
 		if len(imList.Items) != 0 {
@@ -149,7 +403,7 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 				im := newIngressMonitor()
 				errEquals(t, nil, op.handleIngressMonitor(t, im), "adding an ingress monitor")
 
-				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(im.Name, metav1.GetOptions{})
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
 				errEquals(t, nil, err, "getting updated IngressMonitor")
 
 				strEquals(t, "12345", im.Status.ID, "status should be the same")
@@ -182,7 +436,7 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 				im.Status.ID = "12345"
 				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
 
-				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(im.Name, metav1.GetOptions{})
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
 				errEquals(t, nil, err, "getting updated IngressMonitor")
 
 				strEquals(t, "123456", im.Status.ID, "status should be the same")
@@ -198,142 +452,2777 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 					return id, expErr
 				}
 
-				im := newIngressMonitor()
-				im.Status.ID = "12345"
-				errEquals(t, expErr, op.handleIngressMonitor(t, im), "updating an ingress monitor")
-			})
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, expErr, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+			})
+		})
+	})
+}
+
+func TestOperator_IngressMonitorStatus(t *testing.T) {
+	var op *operatorWrapper
+	var prov *fake.SimpleProvider
+
+	setup := func() {
+		op = newOperator(t)
+		prov = new(fake.SimpleProvider)
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+	}
+
+	getStatus := func(im *v1alpha1.IngressMonitor) v1alpha1.IngressMonitorStatus {
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		return im.Status
+	}
+
+	condEquals := func(status v1alpha1.IngressMonitorStatus, tp v1alpha1.IngressMonitorConditionType, exp v1.ConditionStatus, reason string) {
+		cond := getCondition(status, tp)
+		if cond == nil {
+			t.Fatalf("Expected condition %s to be set", tp)
+		}
+
+		if cond.Status != exp {
+			t.Errorf("Expected condition %s to be %s, got %s", tp, exp, cond.Status)
+		}
+
+		strEquals(t, reason, cond.Reason, string(tp), "reason")
+	}
+
+	t.Run("successful sync", func(t *testing.T) {
+		setup()
+
+		prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "12345", nil
+		}
+		prov.URLFunc = func(id string) string {
+			return "https://example.com/checks/" + id
+		}
+
+		im := newIngressMonitor()
+		im.Generation = 3
+		im.Labels = map[string]string{ingressLabel: "my-ingress"}
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "syncing the IngressMonitor")
+
+		status := getStatus(im)
+		strEquals(t, "12345", status.ID, "ID")
+		strEquals(t, "my-ingress", status.IngressName, "ingress name")
+		strEquals(t, "https://example.com/checks/12345", status.ProviderURL, "provider URL")
+		strEquals(t, "", status.LastError, "last error")
+
+		if status.ObservedGeneration != 3 {
+			t.Errorf("Expected observed generation to be 3, got %d", status.ObservedGeneration)
+		}
+
+		if status.LastSyncTime == nil {
+			t.Errorf("Expected last sync time to be set")
+		}
+
+		condEquals(status, v1alpha1.IngressMonitorReady, v1.ConditionTrue, reasonSynced)
+		condEquals(status, v1alpha1.IngressMonitorProviderSynced, v1.ConditionTrue, reasonSynced)
+		condEquals(status, v1alpha1.IngressMonitorDegraded, v1.ConditionFalse, reasonSynced)
+	})
+
+	t.Run("without configured provider", func(t *testing.T) {
+		op = newOperator(t)
+
+		im := newIngressMonitor()
+		if err := op.handleIngressMonitor(t, im); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+
+		status := getStatus(im)
+		strEquals(t, "Error fetching provider 'simple': the specified provider can't be found", status.LastError, "last error")
+
+		if status.LastSyncTime != nil {
+			t.Errorf("Expected last sync time not to be set")
+		}
+
+		condEquals(status, v1alpha1.IngressMonitorReady, v1.ConditionFalse, reasonProviderUnavailable)
+		condEquals(status, v1alpha1.IngressMonitorProviderSynced, v1.ConditionFalse, reasonProviderUnavailable)
+		condEquals(status, v1alpha1.IngressMonitorDegraded, v1.ConditionFalse, reasonProviderUnavailable)
+	})
+
+	t.Run("failing create", func(t *testing.T) {
+		setup()
+
+		prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "", errors.New("can't create monitor")
+		}
+
+		im := newIngressMonitor()
+		errEquals(t, errors.New("can't create monitor"), op.handleIngressMonitor(t, im))
+
+		status := getStatus(im)
+		strEquals(t, "can't create monitor", status.LastError, "last error")
+		condEquals(status, v1alpha1.IngressMonitorReady, v1.ConditionFalse, reasonCreateFailed)
+		condEquals(status, v1alpha1.IngressMonitorDegraded, v1.ConditionFalse, reasonCreateFailed)
+	})
+
+	t.Run("failing update of an existing monitor", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, errors.New("can't update monitor")
+		}
+
+		transition := metav1.NewTime(time.Now().Add(-time.Hour))
+		im := newIngressMonitor()
+		im.Status.ID = "12345"
+		im.Status.Conditions = []v1alpha1.IngressMonitorCondition{
+			{Type: v1alpha1.IngressMonitorReady, Status: v1.ConditionTrue, LastTransitionTime: transition},
+			{Type: v1alpha1.IngressMonitorDegraded, Status: v1.ConditionFalse, LastTransitionTime: transition},
+		}
+		errEquals(t, errors.New("can't update monitor"), op.handleIngressMonitor(t, im))
+
+		status := getStatus(im)
+		strEquals(t, "12345", status.ID, "ID")
+		condEquals(status, v1alpha1.IngressMonitorReady, v1.ConditionFalse, reasonUpdateFailed)
+		condEquals(status, v1alpha1.IngressMonitorDegraded, v1.ConditionTrue, reasonUpdateFailed)
+
+		if cond := getCondition(status, v1alpha1.IngressMonitorReady); cond.LastTransitionTime.Equal(&transition) {
+			t.Errorf("Expected the transition time to be updated")
+		}
+	})
+
+	t.Run("keeps the transition time when the status doesn't change", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, nil
+		}
+
+		transition := metav1.NewTime(time.Now().Add(-time.Hour))
+		im := newIngressMonitor()
+		im.Status.ID = "12345"
+		im.Status.Conditions = []v1alpha1.IngressMonitorCondition{
+			{Type: v1alpha1.IngressMonitorReady, Status: v1.ConditionTrue, LastTransitionTime: transition},
+		}
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		cond := getCondition(getStatus(im), v1alpha1.IngressMonitorReady)
+		if !cond.LastTransitionTime.Equal(&transition) {
+			t.Errorf("Expected the transition time to be kept, got %s", cond.LastTransitionTime)
+		}
+	})
+
+	t.Run("failed syncs don't mark the generation as observed", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, errors.New("can't update monitor")
+		}
+
+		im := newIngressMonitor()
+		im.Generation = 2
+		im.Status.ID = "12345"
+		im.Status.ObservedGeneration = 1
+		errEquals(t, errors.New("can't update monitor"), op.handleIngressMonitor(t, im))
+
+		if status := getStatus(im); status.ObservedGeneration != 1 {
+			t.Errorf("Expected observed generation to be 1, got %d", status.ObservedGeneration)
+		}
+	})
+
+	t.Run("keeps the sync time when nothing changed", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, nil
+		}
+
+		synced := metav1.NewTime(time.Now().Add(-time.Hour))
+		im := newIngressMonitor()
+		im.Generation = 1
+		im.Finalizers = []string{ingressMonitorFinalizer}
+		im.Status = v1alpha1.IngressMonitorStatus{
+			ID:                 "12345",
+			ObservedGeneration: 1,
+			LastSyncTime:       &synced,
+			Conditions: []v1alpha1.IngressMonitorCondition{
+				{Type: v1alpha1.IngressMonitorProviderSynced, Status: v1.ConditionTrue, Reason: reasonSynced, LastTransitionTime: synced},
+				{Type: v1alpha1.IngressMonitorReady, Status: v1.ConditionTrue, Reason: reasonSynced, LastTransitionTime: synced},
+				{Type: v1alpha1.IngressMonitorDegraded, Status: v1.ConditionFalse, Reason: reasonSynced, LastTransitionTime: synced},
+			},
+		}
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		if status := getStatus(im); !status.LastSyncTime.Equal(&synced) {
+			t.Errorf("Expected the sync time to be kept, got %s", status.LastSyncTime)
+		}
+
+		// a new generation is a change
+		im.Generation = 2
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		if status := getStatus(im); status.LastSyncTime.Equal(&synced) {
+			t.Errorf("Expected the sync time to be updated")
+		}
+	})
+
+	t.Run("status updates don't trigger a resync", func(t *testing.T) {
+		setup()
+
+		old := newIngressMonitor()
+		old.ResourceVersion = "1"
+
+		updated := old.DeepCopy()
+		updated.ResourceVersion = "2"
+		updated.Status.ID = "12345"
+
+		op.op.OnUpdate(old, updated)
+		queueEquals(t, op.op.ingressMonitorQueue)
+
+		updated.Spec.Template.Name = "new-name"
+		op.op.OnUpdate(old, updated)
+		queueEquals(t, op.op.ingressMonitorQueue, getKey(t, updated))
+
+		now := metav1.Now()
+		deleted := old.DeepCopy()
+		deleted.ResourceVersion = "3"
+		deleted.DeletionTimestamp = &now
+		op.op.OnUpdate(old, deleted)
+		queueEquals(t, op.op.ingressMonitorQueue, getKey(t, deleted))
+
+		// periodic resync
+		op.op.OnUpdate(old, old)
+		queueEquals(t, op.op.ingressMonitorQueue, getKey(t, old))
+	})
+}
+
+func TestOperator_SyncMonitor(t *testing.T) {
+	t.Run("without matching ingresses", func(t *testing.T) {
+		op := newOperator(t)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+	})
+
+	t.Run("without existing provider", func(t *testing.T) {
+		op := newOperator(t, withIngresses(newIngress()))
+
+		mon := newMonitor()
+		expError := fmt.Errorf("Could not get Provider testing:test-provider: provider.ingressmonitor.sphc.io \"test-provider\" not found")
+		errEquals(t, expError, op.handleMonitor(t, mon))
+	})
+
+	t.Run("without existing template", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+		)
+
+		mon := newMonitor()
+		expError := fmt.Errorf("Could not get MonitorTemplate test-template: monitortemplate.ingressmonitor.sphc.io \"test-template\" not found")
+		errEquals(t, expError, op.handleMonitor(t, mon))
+	})
+
+	t.Run("with an ingress provider and template should create an IngressMonitor", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		stopCh := make(chan struct{})
+		op.op.startInformers(stopCh)
+
+		defer func() {
+			stopCh <- struct{}{}
+		}()
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 1 {
+			t.Errorf("Expected 1 IngressMonitor to be created")
+		}
+
+		im := imList.Items[0]
+
+		expName := "test-go-ingress-testing"
+		strEquals(t, expName, im.Spec.Template.Name)
+
+		expURL := "https://api.example.com/test-healthz"
+		strEquals(t, expURL, im.Spec.Template.HTTP.URL)
+	})
+
+	t.Run("updating an existing monitor", func(t *testing.T) {
+		var op *operatorWrapper
+		var stopCh chan struct{}
+		setup := func() {
+			op = newOperator(t,
+				withIngresses(newIngress()),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+
+			// ensure that the monitor is added corectly
+			errEquals(t, nil, op.handleMonitor(t, newMonitor()))
+
+			stopCh = make(chan struct{})
+			op.op.startInformers(stopCh)
+		}
+
+		cleanup := func() {
+			stopCh <- struct{}{}
+		}
+
+		t.Run("changing labels", func(t *testing.T) {
+			setup()
+			defer cleanup()
+
+			mon := newMonitor()
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err)
+
+			if len(imList.Items) != 1 {
+				t.Errorf("Expected 1 IngressMonitor to be available, got %d", len(imList.Items))
+			}
+
+			mon.Spec.Selector.MatchLabels["non-existing-key"] = "fake-value"
+			errEquals(t, nil, op.handleMonitor(t, mon))
+
+			imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err)
+
+			if len(imList.Items) != 0 {
+				t.Errorf("Expected 0 IngressMonitor to be available, got %d", len(imList.Items))
+			}
+		})
+
+		t.Run("adding an ingress and resyncing", func(t *testing.T) {
+			setup()
+			defer cleanup()
+
+			ing := newIngress()
+			ing.Name = "new-ingress"
+			newRule := networkingv1.IngressRule{
+				Host: "new.api.example.com",
+			}
+			ing.Spec.Rules = append(ing.Spec.Rules, newRule)
+			op.addIngress(ing)
+
+			// trigger resync
+			mon := newMonitor()
+			errEquals(t, nil, op.handleMonitor(t, mon))
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err)
+
+			if len(imList.Items) != 3 {
+				t.Errorf("Expected 3 IngressMonitors to be available, got %d", len(imList.Items))
+			}
+		})
+	})
+}
+
+func TestOperator_MonitorStatus(t *testing.T) {
+	getStatus := func(op *operatorWrapper, mon *v1alpha1.Monitor) v1alpha1.MonitorStatus {
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		return mon.Status
+	}
+
+	condEquals := func(status v1alpha1.MonitorStatus, tp v1alpha1.MonitorConditionType, exp v1.ConditionStatus, reason string) {
+		cond := getMonitorCondition(status, tp)
+		if cond == nil {
+			t.Fatalf("Expected condition %s to be set", tp)
+		}
+
+		if cond.Status != exp {
+			t.Errorf("Expected condition %s to be %s, got %s", tp, exp, cond.Status)
+		}
+
+		strEquals(t, reason, cond.Reason, string(tp), "reason")
+	}
+
+	// managedIngressMonitor sets up an IngressMonitor which is managed by the
+	// test Monitor for the test Ingress with the given Ready status.
+	managedIngressMonitor := func(name string, ready v1.ConditionStatus) *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Name = name
+		im.Labels = map[string]string{
+			monitorLabel:     "test-monitor",
+			ingressLabel:     "go-ingress",
+			ingressHostLabel: "api.example.com",
+		}
+		im.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(newIngress(), networkingv1.SchemeGroupVersion.WithKind("Ingress")),
+		}
+
+		if ready != "" {
+			im.Status.Conditions = []v1alpha1.IngressMonitorCondition{
+				{Type: v1alpha1.IngressMonitorReady, Status: ready},
+			}
+		}
+
+		return im
+	}
+
+	t.Run("without matching ingresses", func(t *testing.T) {
+		op := newOperator(t)
+
+		mon := newMonitor()
+		mon.Generation = 2
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		status := getStatus(op, mon)
+		if status.SelectedIngresses != 0 {
+			t.Errorf("Expected no selected Ingresses, got %d", status.SelectedIngresses)
+		}
+
+		if status.ObservedGeneration != 2 {
+			t.Errorf("Expected observed generation to be 2, got %d", status.ObservedGeneration)
+		}
+
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionTrue, reasonReconciled)
+	})
+
+	t.Run("without provider", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		if err := op.handleMonitor(t, mon); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+
+		status := getStatus(op, mon)
+		if status.SelectedIngresses != 1 {
+			t.Errorf("Expected 1 selected Ingress, got %d", status.SelectedIngresses)
+		}
+
+		condEquals(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonProviderNotFound)
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonReconcileFailed)
+	})
+
+	t.Run("without template", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+		)
+
+		mon := newMonitor()
+		if err := op.handleMonitor(t, mon); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+
+		status := getStatus(op, mon)
+		condEquals(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonTemplateNotFound)
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonReconcileFailed)
+	})
+
+	t.Run("with an invalid template", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Name = "{{.Host | upper}}"
+
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		mon := newMonitor()
+		if err := op.handleMonitor(t, mon); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+
+		status := getStatus(op, mon)
+		condEquals(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonTemplateInvalid)
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonReconcileFailed)
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 0 {
+			t.Errorf("Expected no IngressMonitors, got %d", len(imList.Items))
+		}
+	})
+
+	t.Run("with a check type the provider doesn't support", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Type = v1alpha1.CheckTypeTCP
+		tmpl.Spec.HTTP = nil
+		tmpl.Spec.TCP = &v1alpha1.TCPTemplate{Port: 443}
+
+		prov := newProvider()
+		prov.Spec.Type = "simple"
+
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(prov),
+			withTemplates(tmpl),
+		)
+		op.op.providerFactory.RegisterCheckTypes("simple", v1alpha1.CheckTypeHTTP)
+
+		mon := newMonitor()
+		if err := op.handleMonitor(t, mon); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+
+		status := getStatus(op, mon)
+		condEquals(status, v1alpha1.MonitorReferencesResolved, v1.ConditionFalse, reasonCheckTypeNotSupported)
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 0 {
+			t.Errorf("Expected no IngressMonitors, got %d", len(imList.Items))
+		}
+	})
+
+	t.Run("with managed IngressMonitors", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+			withIngressMonitors(
+				managedIngressMonitor("im-ready", v1.ConditionTrue),
+				managedIngressMonitor("im-failed", v1.ConditionFalse),
+				managedIngressMonitor("im-pending", ""),
+			),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		status := getStatus(op, mon)
+		if status.ReadyIngressMonitors != 1 {
+			t.Errorf("Expected 1 ready IngressMonitor, got %d", status.ReadyIngressMonitors)
+		}
+
+		if status.FailedIngressMonitors != 1 {
+			t.Errorf("Expected 1 failed IngressMonitor, got %d", status.FailedIngressMonitors)
+		}
+
+		expNames := []string{"im-failed", "im-pending", "im-ready"}
+		if !reflect.DeepEqual(expNames, status.IngressMonitors) {
+			t.Errorf("Expected IngressMonitors %v, got %v", expNames, status.IngressMonitors)
+		}
+
+		condEquals(status, v1alpha1.MonitorReferencesResolved, v1.ConditionTrue, reasonResolved)
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonIngressMonitorsFailed)
+	})
+
+	t.Run("with all IngressMonitors ready", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+			withIngressMonitors(managedIngressMonitor("im-ready", v1.ConditionTrue)),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		status := getStatus(op, mon)
+		condEquals(status, v1alpha1.MonitorReady, v1.ConditionTrue, reasonReconciled)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		op := newOperator(t)
+		mon := newMonitor()
+
+		t.Run("IngressMonitor becoming ready", func(t *testing.T) {
+			old := managedIngressMonitor("im", "")
+			old.ResourceVersion = "1"
+
+			updated := managedIngressMonitor("im", v1.ConditionTrue)
+			updated.ResourceVersion = "2"
+
+			op.op.OnUpdate(old, updated)
+			queueEquals(t, op.op.monitorQueue, getKey(t, mon))
+		})
+
+		t.Run("IngressMonitor deleted", func(t *testing.T) {
+			op.op.OnDelete(managedIngressMonitor("im", v1.ConditionTrue))
+			queueEquals(t, op.op.monitorQueue, getKey(t, mon))
+		})
+
+		t.Run("Monitor status update", func(t *testing.T) {
+			old := newMonitor()
+			old.ResourceVersion = "1"
+
+			updated := newMonitor()
+			updated.ResourceVersion = "2"
+			updated.Status.SelectedIngresses = 1
+
+			op.op.OnUpdate(old, updated)
+			queueEquals(t, op.op.monitorQueue)
+		})
+	})
+}
+
+func TestOperator_RecordEvents(t *testing.T) {
+	var op *operatorWrapper
+	var prov *fake.SimpleProvider
+
+	setup := func() {
+		op = newOperator(t,
+			withIngresses(newIngress()),
+			withMonitors(newMonitor()),
+		)
+		prov = new(fake.SimpleProvider)
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+	}
+
+	linkedIngressMonitor := func() *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Labels = map[string]string{
+			monitorLabel: "test-monitor",
+			ingressLabel: "go-ingress",
+		}
+		return im
+	}
+
+	t.Run("creating a check", func(t *testing.T) {
+		setup()
+
+		prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "12345", nil
+		}
+
+		errEquals(t, nil, op.handleIngressMonitor(t, linkedIngressMonitor()))
+		eventsEqual(t, op.op.recorder,
+			"Normal Created Created check 12345 with provider simple",
+			"Normal Created IngressMonitor test-im: Created check 12345 with provider simple",
+			"Normal Created IngressMonitor test-im: Created check 12345 with provider simple",
+		)
+	})
+
+	t.Run("updating a check", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, nil
+		}
+
+		im := newIngressMonitor()
+		im.Generation = 2
+		im.Status.ID = "12345"
+		im.Status.ObservedGeneration = 1
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+		eventsEqual(t, op.op.recorder, "Normal Updated Updated check 12345 with provider simple")
+
+		// resyncing without changes doesn't record an event
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+		op.op.imInformer.GetIndexer().Update(im)
+
+		errEquals(t, nil, op.op.handleIngressMonitor(getKey(t, im)))
+		eventsEqual(t, op.op.recorder)
+	})
+
+	t.Run("updating a check after a failed sync", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, errors.New("can't update monitor")
+		}
+
+		im := newIngressMonitor()
+		im.Generation = 2
+		im.Status.ID = "12345"
+		im.Status.ObservedGeneration = 1
+		errEquals(t, errors.New("can't update monitor"), op.handleIngressMonitor(t, im))
+		eventsEqual(t, op.op.recorder, "Warning SyncFailed Could not sync check with provider simple: can't update monitor")
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return id, nil
+		}
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+		op.op.imInformer.GetIndexer().Update(im)
+
+		errEquals(t, nil, op.op.handleIngressMonitor(getKey(t, im)))
+		eventsEqual(t, op.op.recorder, "Normal Updated Updated check 12345 with provider simple")
+	})
+
+	t.Run("recreating a check", func(t *testing.T) {
+		setup()
+
+		prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "54321", nil
+		}
+
+		im := newIngressMonitor()
+		im.Status.ID = "12345"
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+		eventsEqual(t, op.op.recorder, "Normal Recreated Check 12345 went missing with provider simple, recreated it as 54321")
+	})
+
+	t.Run("failing to sync a check", func(t *testing.T) {
+		setup()
+
+		prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+			return "", errors.New("can't create monitor")
+		}
+
+		errEquals(t, errors.New("can't create monitor"), op.handleIngressMonitor(t, newIngressMonitor()))
+		eventsEqual(t, op.op.recorder, "Warning SyncFailed Could not sync check with provider simple: can't create monitor")
+	})
+
+	t.Run("deleting a check", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(id string) error {
+			return nil
+		}
+
+		now := metav1.Now()
+		im := linkedIngressMonitor()
+		im.Status.ID = "12345"
+		im.DeletionTimestamp = &now
+		im.Finalizers = []string{ingressMonitorFinalizer}
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+		eventsEqual(t, op.op.recorder,
+			"Normal Deleted Deleted check 12345 from provider simple",
+			"Normal Deleted IngressMonitor test-im: Deleted check 12345 from provider simple",
+			"Normal Deleted IngressMonitor test-im: Deleted check 12345 from provider simple",
+		)
+	})
+
+	t.Run("failing to delete a check", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(id string) error {
+			return errors.New("provider unavailable")
+		}
+
+		now := metav1.Now()
+		im := newIngressMonitor()
+		im.Status.ID = "12345"
+		im.DeletionTimestamp = &now
+		im.Finalizers = []string{ingressMonitorFinalizer}
+		if err := op.handleIngressMonitor(t, im); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+		eventsEqual(t, op.op.recorder, "Warning DeleteFailed Could not delete check 12345 from provider simple: provider unavailable")
+	})
+
+	t.Run("failing to reconcile a Monitor", func(t *testing.T) {
+		setup()
+
+		if err := op.handleMonitor(t, newMonitor()); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+		eventsEqual(t, op.op.recorder, "Warning ReconcileFailed Could not reconcile Monitor: Could not get Provider testing:test-provider: provider.ingressmonitor.sphc.io \"test-provider\" not found")
+	})
+}
+
+func TestOperator_IngressAPIs(t *testing.T) {
+	t.Run("prefers networking.k8s.io/v1", func(t *testing.T) {
+		op := newOperator(t, withIngressAPIs("extensions/v1beta1", "networking.k8s.io/v1beta1", "networking.k8s.io/v1"))
+
+		strEquals(t, "networking.k8s.io/v1, Kind=Ingress", op.op.ingressGVK.String())
+	})
+
+	t.Run("without any served Ingress API", func(t *testing.T) {
+		k8sClient := k8sfake.NewSimpleClientset()
+		_, err := NewOperator(k8sClient, imfake.NewSimpleClientset(), newDynamicClient(), nil, "", 0, provider.NewFactory(nil), metrics.New(prometheus.NewRegistry()))
+		if err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+	})
+
+	for _, gv := range []string{"extensions/v1beta1", "networking.k8s.io/v1beta1"} {
+		t.Run("falls back to "+gv, func(t *testing.T) {
+			var legacy runtime.Object
+			if gv == "extensions/v1beta1" {
+				legacy = &extensionsv1beta1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: "go-ingress", Namespace: "testing", Labels: map[string]string{"team": "gophers"}},
+					Spec: extensionsv1beta1.IngressSpec{
+						TLS:   []extensionsv1beta1.IngressTLS{{Hosts: []string{"api.example.com"}}},
+						Rules: []extensionsv1beta1.IngressRule{{Host: "api.example.com"}},
+					},
+				}
+			} else {
+				legacy = &networkingv1beta1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: "go-ingress", Namespace: "testing", Labels: map[string]string{"team": "gophers"}},
+					Spec: networkingv1beta1.IngressSpec{
+						TLS:   []networkingv1beta1.IngressTLS{{Hosts: []string{"api.example.com"}}},
+						Rules: []networkingv1beta1.IngressRule{{Host: "api.example.com"}},
+					},
+				}
+			}
+
+			op := newOperator(t,
+				withIngressAPIs(gv),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+			op.kubeClient.Tracker().Add(legacy)
+
+			strEquals(t, gv+", Kind=Ingress", op.op.ingressGVK.String())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			go op.op.ingInformer.Run(stopCh)
+			if !cache.WaitForCacheSync(stopCh, op.op.ingInformer.HasSynced) {
+				t.Fatalf("Could not sync the Ingress cache")
+			}
+
+			ing, err := op.op.ingLister.Ingresses("testing").Get("go-ingress")
+			errEquals(t, nil, err, "getting the converted Ingress")
+			strEquals(t, "api.example.com", ing.Spec.Rules[0].Host, "rule host")
+			strEquals(t, "api.example.com", ing.Spec.TLS[0].Hosts[0], "tls host")
+
+			mon := newMonitor()
+			errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+
+			if len(imList.Items) != 1 {
+				t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+			}
+
+			owner := metav1.GetControllerOf(&imList.Items[0])
+			strEquals(t, gv, owner.APIVersion, "owner API version")
+			strEquals(t, "https://api.example.com/test-healthz", imList.Items[0].Spec.Template.HTTP.URL, "monitor URL")
+		})
+	}
+
+	t.Run("moves the owner of existing IngressMonitors to the watched API", func(t *testing.T) {
+		ing := newIngress()
+		ing.UID = "ingress-uid"
+
+		op := newOperator(t,
+			withIngresses(ing),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		// The IngressMonitor was set up while Ingresses were watched
+		// through extensions/v1beta1.
+		target := newHostTarget(ing, networkingv1.SchemeGroupVersion.WithKind("Ingress"), ingressLabel, "api.example.com", true)
+		legacyOwner := *metav1.NewControllerRef(ing, extensionsv1beta1.SchemeGroupVersion.WithKind("Ingress"))
+
+		im := newIngressMonitor()
+		im.Name = target.name()
+		im.OwnerReferences = []metav1.OwnerReference{legacyOwner}
+		_, err := op.op.imClient.IngressMonitors(im.Namespace).Create(context.TODO(), im, metav1.CreateOptions{})
+		errEquals(t, nil, err, "creating the IngressMonitor")
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		im, err = op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the updated IngressMonitor")
+
+		if len(im.OwnerReferences) != 1 {
+			t.Fatalf("Expected 1 owner reference, got %d", len(im.OwnerReferences))
+		}
+
+		owner := metav1.GetControllerOf(im)
+		strEquals(t, "networking.k8s.io/v1", owner.APIVersion, "owner API version")
+		strEquals(t, "ingress-uid", string(owner.UID), "owner UID")
+	})
+}
+
+func TestConvertIngress(t *testing.T) {
+	prefix := extensionsv1beta1.PathTypePrefix
+	className := "nginx"
+
+	ing := &extensionsv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "go-ingress", Namespace: "testing"},
+		Spec: extensionsv1beta1.IngressSpec{
+			IngressClassName: &className,
+			Backend: &extensionsv1beta1.IngressBackend{
+				ServiceName: "default",
+				ServicePort: intstr.FromString("http"),
+			},
+			TLS: []extensionsv1beta1.IngressTLS{{Hosts: []string{"api.example.com"}, SecretName: "tls"}},
+			Rules: []extensionsv1beta1.IngressRule{
+				{
+					Host: "api.example.com",
+					IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+						HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+							Paths: []extensionsv1beta1.HTTPIngressPath{
+								{
+									Path:     "/api",
+									PathType: &prefix,
+									Backend: extensionsv1beta1.IngressBackend{
+										ServiceName: "api",
+										ServicePort: intstr.FromInt(8080),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v1PathType := networkingv1.PathTypePrefix
+	exp := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "go-ingress", Namespace: "testing"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &className,
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: "default",
+					Port: networkingv1.ServiceBackendPort{Name: "http"},
+				},
+			},
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"api.example.com"}, SecretName: "tls"}},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "api.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/api",
+									PathType: &v1PathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "api",
+											Port: networkingv1.ServiceBackendPort{Number: 8080},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	act, err := convertIngress(ing)
+	errEquals(t, nil, err, "converting the Ingress")
+
+	if !reflect.DeepEqual(exp, act) {
+		t.Errorf("Expected %#v, got %#v", exp, act)
+	}
+}
+
+func TestOperator_IngressClass(t *testing.T) {
+	className := "nginx"
+
+	tcs := []struct {
+		name     string
+		ingress  func(*networkingv1.Ingress)
+		selected bool
+	}{
+		{"matching spec.ingressClassName", func(ing *networkingv1.Ingress) { ing.Spec.IngressClassName = &className }, true},
+		{"matching annotation", func(ing *networkingv1.Ingress) {
+			ing.Annotations = map[string]string{ingressClassAnnotation: "nginx"}
+		}, true},
+		{"different class", func(ing *networkingv1.Ingress) {
+			ing.Annotations = map[string]string{ingressClassAnnotation: "traefik"}
+		}, false},
+		{"without class", func(ing *networkingv1.Ingress) {}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := newIngress()
+			tc.ingress(ing)
+
+			mon := newMonitor()
+			mon.Spec.IngressClassName = "nginx"
+
+			op := newOperator(t,
+				withIngresses(ing),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+				withMonitors(mon),
+			)
+
+			errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+
+			if tc.selected != (len(imList.Items) == 1) {
+				t.Errorf("Expected Ingress selected to be %t, got %d IngressMonitors", tc.selected, len(imList.Items))
+			}
+
+			if tc.selected != (len(op.op.monitorsFor(v1alpha1.SourceKindIngress, ing)) == 1) {
+				t.Errorf("Expected Monitor to be affected by the Ingress to be %t", tc.selected)
+			}
+		})
+	}
+}
+
+func TestOperator_UnmonitorableHosts(t *testing.T) {
+	ing := newIngress()
+	ing.Spec.Rules = []networkingv1.IngressRule{
+		{Host: ""},
+		{Host: "*.example.com"},
+		{Host: "api.example.com"},
+	}
+
+	op := newOperator(t,
+		withIngresses(ing),
+		withProviders(newProvider()),
+		withTemplates(newTemplate()),
+	)
+
+	mon := newMonitor()
+	errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+	imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+	errEquals(t, nil, err, "listing the IngressMonitors")
+
+	if len(imList.Items) != 1 {
+		t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+	}
+
+	strEquals(t, "api.example.com", imList.Items[0].Labels[ingressHostLabel])
+
+	eventsEqual(t, op.op.recorder,
+		"Warning HostSkipped Skipping the rule without a host, there's no URL to check",
+		"Warning HostSkipped Skipping the rule for wildcard host *.example.com, there's no URL to check",
+	)
+
+	// resync fetches the Monitor with the status written by the previous
+	// reconcile and reconciles it again.
+	resync := func(t *testing.T) *v1alpha1.Monitor {
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		op.op.mInformer.GetIndexer().Update(mon)
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+		return mon
+	}
+
+	t.Run("sets the SelectionValid condition", func(t *testing.T) {
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		cond := getMonitorCondition(mon.Status, v1alpha1.MonitorSelectionValid)
+		if cond == nil || cond.Status != v1.ConditionFalse {
+			t.Fatalf("Expected condition %s to be False, got %v", v1alpha1.MonitorSelectionValid, cond)
+		}
+
+		strEquals(t, reasonSelectionWarnings, cond.Reason, "condition reason")
+		strEquals(t, "Ingress go-ingress: Skipping the rule for wildcard host *.example.com, there's no URL to check\n"+
+			"Ingress go-ingress: Skipping the rule without a host, there's no URL to check", cond.Message, "condition message")
+	})
+
+	t.Run("doesn't record the same Events on a resync", func(t *testing.T) {
+		resync(t)
+		eventsEqual(t, op.op.recorder)
+	})
+
+	t.Run("records Events for new skipped rules", func(t *testing.T) {
+		updated := ing.DeepCopy()
+		updated.Spec.Rules = append(updated.Spec.Rules, networkingv1.IngressRule{Host: "*.example.org"})
+		op.op.ingInformer.GetIndexer().Update(updated)
+
+		resync(t)
+		eventsEqual(t, op.op.recorder,
+			"Warning HostSkipped Skipping the rule for wildcard host *.example.org, there's no URL to check",
+		)
+	})
+
+	t.Run("clears the condition once the rules are fixed", func(t *testing.T) {
+		updated := ing.DeepCopy()
+		updated.Spec.Rules = []networkingv1.IngressRule{{Host: "api.example.com"}}
+		op.op.ingInformer.GetIndexer().Update(updated)
+
+		resync(t)
+		eventsEqual(t, op.op.recorder)
+
+		mon := resync(t)
+		cond := getMonitorCondition(mon.Status, v1alpha1.MonitorSelectionValid)
+		if cond == nil || cond.Status != v1.ConditionTrue {
+			t.Fatalf("Expected condition %s to be True, got %v", v1alpha1.MonitorSelectionValid, cond)
+		}
+	})
+}
+
+func TestOperator_PerPath(t *testing.T) {
+	prefix := networkingv1.PathTypePrefix
+	exact := networkingv1.PathTypeExact
+
+	newPathIngress := func(paths ...networkingv1.HTTPIngressPath) *networkingv1.Ingress {
+		ing := newIngress()
+		ing.Spec.Rules = []networkingv1.IngressRule{
+			{
+				Host: "api.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+				},
+			},
+		}
+		return ing
+	}
+
+	newPathMonitor := func() *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.PerPath = true
+		return mon
+	}
+
+	urls := func(t *testing.T, op *operatorWrapper) map[string]string {
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		urls := map[string]string{}
+		for _, im := range imList.Items {
+			urls[im.Name] = im.Spec.Template.HTTP.URL
+		}
+		return urls
+	}
+
+	t.Run("creates an IngressMonitor per path", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newPathIngress(
+				networkingv1.HTTPIngressPath{Path: "/", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/auth/", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/auth/", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/status", PathType: &exact},
+			)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newPathMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-ingress-" + shortHash("api.example.com", 16):        "https://api.example.com/test-healthz",
+			"go-ingress-" + shortHash("api.example.com/api", 16):    "https://api.example.com/api/test-healthz",
+			"go-ingress-" + shortHash("api.example.com/auth/", 16):  "https://api.example.com/auth/test-healthz",
+			"go-ingress-" + shortHash("api.example.com/status", 16): "https://api.example.com/status",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("uses the path in the templated name", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Name = "{{.Host}}{{.Path}}"
+
+		op := newOperator(t,
+			withIngresses(newPathIngress(networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix})),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newPathMonitor()), "creating a new monitor")
+
+		im, err := op.op.imClient.IngressMonitors("testing").Get(context.TODO(), "go-ingress-"+shortHash("api.example.com/api", 16), metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the IngressMonitor")
+		strEquals(t, "api.example.com/api", im.Spec.Template.Name)
+	})
+
+	t.Run("monitors the host for paths without a leading slash", func(t *testing.T) {
+		implementationSpecific := networkingv1.PathTypeImplementationSpecific
+		op := newOperator(t,
+			withIngresses(newPathIngress(
+				networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "static.*", PathType: &implementationSpecific},
+			)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newPathMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-ingress-" + shortHash("api.example.com", 16):     "https://api.example.com/test-healthz",
+			"go-ingress-" + shortHash("api.example.com/api", 16): "https://api.example.com/api/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("garbage collects removed paths", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newPathIngress(
+				networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/static", PathType: &prefix},
+			)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newPathMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
+
+		op.op.ingInformer.GetIndexer().Update(newPathIngress(networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix}))
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+
+		exp := map[string]string{
+			"go-ingress-" + shortHash("api.example.com/api", 16): "https://api.example.com/api/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("without per path monitors", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newPathIngress(
+				networkingv1.HTTPIngressPath{Path: "/api", PathType: &prefix},
+				networkingv1.HTTPIngressPath{Path: "/static", PathType: &prefix},
+			)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-ingress-" + shortHash("api.example.com", 16): "https://api.example.com/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+}
+
+func TestOperator_AnnotationOverrides(t *testing.T) {
+	tcs := []struct {
+		name          string
+		annotations   map[string]string
+		monitors      int
+		url           string
+		shouldContain string
+		checkRate     *string
+		events        []string
+	}{
+		{
+			name:     "without annotations",
+			monitors: 1,
+			url:      "https://api.example.com/test-healthz",
+		},
+		{
+			name: "with overrides",
+			annotations: map[string]string{
+				endpointAnnotation:      "/ping",
+				shouldContainAnnotation: "pong",
+				checkRateAnnotation:     "30s",
+			},
+			monitors:      1,
+			url:           "https://api.example.com/ping",
+			shouldContain: "pong",
+			checkRate:     ptrString("30s"),
+		},
+		{
+			name:        "opted out",
+			annotations: map[string]string{enabledAnnotation: "false"},
+		},
+		{
+			name:        "explicitly enabled",
+			annotations: map[string]string{enabledAnnotation: "true"},
+			monitors:    1,
+			url:         "https://api.example.com/test-healthz",
+		},
+		{
+			name: "with malformed values",
+			annotations: map[string]string{
+				enabledAnnotation:                  "nope",
+				endpointAnnotation:                 "ping",
+				checkRateAnnotation:                "often",
+				annotationPrefix + "should-contan": "pong",
+			},
+			monitors: 1,
+			url:      "https://api.example.com/test-healthz",
+			events: []string{
+				"Warning InvalidAnnotation Invalid value 'often' for ingressmonitor.sphc.io/check-rate, expected a positive duration",
+				"Warning InvalidAnnotation Invalid value 'nope' for ingressmonitor.sphc.io/enabled, expected a boolean",
+				"Warning InvalidAnnotation Invalid value 'ping' for ingressmonitor.sphc.io/endpoint, expected a path starting with '/'",
+				"Warning InvalidAnnotation Unknown annotation ingressmonitor.sphc.io/should-contan",
+			},
+		},
+		{
+			name:        "with a scheme on an Ingress",
+			annotations: map[string]string{schemeAnnotation: "http"},
+			monitors:    1,
+			url:         "https://api.example.com/test-healthz",
+			events: []string{
+				"Warning InvalidAnnotation The ingressmonitor.sphc.io/scheme annotation is only supported on Services",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := newIngress()
+			ing.Annotations = tc.annotations
+
+			op := newOperator(t,
+				withIngresses(ing),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+
+			errEquals(t, nil, op.handleMonitor(t, newMonitor()), "creating a new monitor")
+			eventsEqual(t, op.op.recorder, tc.events...)
+
+			imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+
+			if len(imList.Items) != tc.monitors {
+				t.Fatalf("Expected %d IngressMonitors, got %d", tc.monitors, len(imList.Items))
+			}
+
+			if tc.monitors == 0 {
+				return
+			}
+
+			tpl := imList.Items[0].Spec.Template
+			strEquals(t, tc.url, tpl.HTTP.URL, "URL")
+			strEquals(t, tc.shouldContain, tpl.HTTP.ShouldContain, "shouldContain")
+			if !reflect.DeepEqual(tc.checkRate, tpl.CheckRate) {
+				t.Errorf("Expected checkRate %v, got %v", tc.checkRate, tpl.CheckRate)
+			}
+		})
+	}
+
+	t.Run("garbage collects opted out Ingresses", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
+
+		ing := newIngress()
+		ing.Annotations = map[string]string{enabledAnnotation: "false"}
+		op.op.ingInformer.GetIndexer().Update(ing)
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 0 {
+			t.Errorf("Expected the IngressMonitors to be garbage collected, got %d", len(imList.Items))
+		}
+	})
+}
+
+func TestRenderTemplateSpec(t *testing.T) {
+	ing := newIngress()
+	ing.Annotations = map[string]string{"owner": "gophers@example.com"}
+
+	target := newHostTarget(ing, networkingv1.SchemeGroupVersion.WithKind("Ingress"), ingressLabel, "api.example.com", true)
+	target.path = "/api"
+	data := newTemplateData(target, newMonitor(), "test-provider")
+
+	tcs := []struct {
+		name string
+		tpl  string
+		exp  string
+		err  bool
+	}{
+		{"legacy fields", "{{.IngressName}}-{{.IngressNamespace}}", "go-ingress-testing", false},
+		{"object", "{{.Name}}.{{.Namespace}}", "go-ingress.testing", false},
+		{"url", "{{.Scheme}}://{{.Host}}{{.Path}}", "https://api.example.com/api", false},
+		{"labels", "{{.Host}} ({{.Labels.team}})", "api.example.com (gophers)", false},
+		{"annotations", "{{index .Annotations \"owner\"}}", "gophers@example.com", false},
+		{"references", "{{.Monitor}}/{{.Provider}}", "test-monitor/test-provider", false},
+		{"lower", "{{.Labels.team | lower}}", "gophers", false},
+		{"replace", "{{.Host | replace \".\" \"-\"}}", "api-example-com", false},
+		{"trimSuffix", "{{.Host | trimSuffix \".example.com\"}}", "api", false},
+		{"default", "{{index .Labels \"env\" | default \"production\"}}", "production", false},
+		{"default with a value", "{{.Labels.team | default \"nobody\"}}", "gophers", false},
+		{"plain text", "{{.Name}} & \"auth\" <{{.Labels.team}}>", "go-ingress & \"auth\" <gophers>", false},
+		{"missing label", "{{.Labels.env}}", "", true},
+		{"misspelled label with a default", "{{.Labels.tema | default \"nobody\"}}", "", true},
+		{"syntax error", "{{.Host", "", true},
+		{"unknown function", "{{.Host | upper}}", "", true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := v1alpha1.MonitorTemplateSpec{
+				Name: tc.tpl,
+				HTTP: &v1alpha1.HTTPTemplate{
+					Endpoint:         ptrString(tc.tpl),
+					CustomHeader:     tc.tpl,
+					UserAgent:        tc.tpl,
+					ShouldContain:    tc.tpl,
+					ShouldNotContain: tc.tpl,
+				},
+			}
+
+			err := renderTemplateSpec(&spec, data)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error rendering %s", tc.tpl)
+				}
+				return
+			}
+			errEquals(t, nil, err, "rendering the template")
+
+			for name, act := range map[string]string{
+				"name":             spec.Name,
+				"endpoint":         *spec.HTTP.Endpoint,
+				"customHeader":     spec.HTTP.CustomHeader,
+				"userAgent":        spec.HTTP.UserAgent,
+				"shouldContain":    spec.HTTP.ShouldContain,
+				"shouldNotContain": spec.HTTP.ShouldNotContain,
+			} {
+				strEquals(t, tc.exp, act, name)
+			}
+		})
+	}
+}
+
+func TestValidateTemplateSpec(t *testing.T) {
+	tcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+		err  error
+	}{
+		{
+			name: "valid templates",
+			spec: v1alpha1.MonitorTemplateSpec{
+				Name: "{{.Host}} ({{.Labels.team}})",
+				HTTP: &v1alpha1.HTTPTemplate{
+					Endpoint:  ptrString("{{.Path}}_healthz"),
+					UserAgent: "{{.Monitor | lower}}",
+				},
+			},
+		},
+		{
+			name: "syntax error in the name",
+			spec: v1alpha1.MonitorTemplateSpec{Name: "{{.Host"},
+			err:  errors.New("Could not parse name: template: name:1: unclosed action"),
+		},
+		{
+			name: "unknown value in a HTTP field",
+			spec: v1alpha1.MonitorTemplateSpec{
+				Name: "{{.Host}}",
+				HTTP: &v1alpha1.HTTPTemplate{ShouldContain: "{{.Hots}}"},
+			},
+			err: errors.New("Could not render http.shouldContain: template: http.shouldContain:1:2: executing \"http.shouldContain\" at <.Hots>: can't evaluate field Hots in type ingressmonitor.templateData"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errEquals(t, tc.err, ValidateTemplateSpec(tc.spec))
+		})
+	}
+}
+
+func TestOperator_InvalidTemplateEvents(t *testing.T) {
+	op := newOperator(t)
+
+	tmpl := newTemplate()
+	op.op.OnAdd(tmpl)
+	eventsEqual(t, op.op.recorder)
+
+	invalid := newTemplate()
+	invalid.ResourceVersion = "2"
+	invalid.Spec.Name = "{{.Host"
+	op.op.OnUpdate(tmpl, invalid)
+	eventsEqual(t, op.op.recorder,
+		"Warning InvalidTemplate Invalid template: Could not parse name: template: name:1: unclosed action",
+	)
+}
+
+func TestOperator_ServiceSource(t *testing.T) {
+	listIMs := func(t *testing.T, op *operatorWrapper) []v1alpha1.IngressMonitor {
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		return imList.Items
+	}
+
+	t.Run("with a load balancer hostname", func(t *testing.T) {
+		op := newOperator(t,
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("https")), "creating a new monitor")
+
+		ims := listIMs(t, op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(ims))
+		}
+
+		im := ims[0]
+		strEquals(t, "https://lb.example.com/test-healthz", im.Spec.Template.HTTP.URL, "monitor URL")
+		strEquals(t, "test-go-service-testing", im.Spec.Template.Name, "template name")
+		strEquals(t, "go-service", im.Labels[serviceLabel], "service label")
+
+		owner := metav1.GetControllerOf(&im)
+		strEquals(t, "v1", owner.APIVersion, "owner API version")
+		strEquals(t, "Service", owner.Kind, "owner kind")
+		strEquals(t, "go-service", owner.Name, "owner name")
+	})
+
+	t.Run("with a load balancer IP and a non default port", func(t *testing.T) {
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+
+		op := newOperator(t,
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("http")), "creating a new monitor")
+
+		ims := listIMs(t, op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(ims))
+		}
+
+		strEquals(t, "http://203.0.113.10:8080/test-healthz", ims[0].Spec.Template.HTTP.URL, "monitor URL")
+	})
+
+	t.Run("with a load balancer IPv6 address", func(t *testing.T) {
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "2001:db8::1"}}
+
+		for port, exp := range map[string]string{
+			"https": "https://[2001:db8::1]/test-healthz",
+			"http":  "http://[2001:db8::1]:8080/test-healthz",
+		} {
+			op := newOperator(t,
+				withServices(svc),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+
+			errEquals(t, nil, op.handleMonitor(t, newServiceMonitor(port)), "creating a new monitor")
+
+			ims := listIMs(t, op)
+			if len(ims) != 1 {
+				t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(ims))
+			}
+
+			strEquals(t, exp, ims[0].Spec.Template.HTTP.URL, "monitor URL")
+		}
+	})
+
+	t.Run("with a scheme annotation", func(t *testing.T) {
+		svc := newService()
+		svc.Annotations = map[string]string{schemeAnnotation: "https"}
+
+		op := newOperator(t,
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("http")), "creating a new monitor")
+		eventsEqual(t, op.op.recorder)
+
+		ims := listIMs(t, op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(ims))
+		}
+
+		strEquals(t, "https://lb.example.com:8080/test-healthz", ims[0].Spec.Template.HTTP.URL, "monitor URL")
+	})
+
+	t.Run("with a TCP check", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Type = v1alpha1.CheckTypeTCP
+
+		op := newOperator(t,
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("postgres")), "creating a new monitor")
+
+		ims := listIMs(t, op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(ims))
+		}
+
+		tcp := ims[0].Spec.Template.TCP
+		if tcp == nil {
+			t.Fatalf("Expected a TCP template to be configured")
+		}
+
+		strEquals(t, "lb.example.com", tcp.Host, "TCP host")
+		if tcp.Port != 5432 {
+			t.Errorf("Expected TCP port 5432, got %d", tcp.Port)
+		}
+	})
+
+	t.Run("without a matching port", func(t *testing.T) {
+		op := newOperator(t,
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("grpc")), "creating a new monitor")
+
+		if ims := listIMs(t, op); len(ims) != 0 {
+			t.Errorf("Expected no IngressMonitors to be created, got %d", len(ims))
+		}
+	})
+
+	t.Run("without an assigned load balancer address", func(t *testing.T) {
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = nil
+
+		op := newOperator(t,
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newServiceMonitor("https")
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		if ims := listIMs(t, op); len(ims) != 0 {
+			t.Errorf("Expected no IngressMonitors to be created, got %d", len(ims))
+		}
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+		if mon.Status.SelectedIngresses != 1 {
+			t.Errorf("Expected 1 selected Service, got %d", mon.Status.SelectedIngresses)
+		}
+	})
+
+	t.Run("ignores Services which aren't of type LoadBalancer", func(t *testing.T) {
+		svc := newService()
+		svc.Spec.Type = v1.ServiceTypeClusterIP
+
+		op := newOperator(t,
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newServiceMonitor("https")), "creating a new monitor")
+
+		if ims := listIMs(t, op); len(ims) != 0 {
+			t.Errorf("Expected no IngressMonitors to be created, got %d", len(ims))
+		}
+	})
+
+	t.Run("garbage collects a changed load balancer address", func(t *testing.T) {
+		op := newOperator(t,
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newServiceMonitor("https")
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		op.op.startInformers(stopCh)
+
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{Hostname: "new-lb.example.com"}}
+		op.op.svcInformer.GetIndexer().Update(svc)
+
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+
+		ims := listIMs(t, op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be available, got %d", len(ims))
+		}
+
+		strEquals(t, "https://new-lb.example.com/test-healthz", ims[0].Spec.Template.HTTP.URL, "monitor URL")
+	})
+
+	t.Run("only enqueues Monitors selecting Services", func(t *testing.T) {
+		svcMon := newServiceMonitor("https")
+		svcMon.Name = "service-monitor"
+
+		op := newOperator(t, withMonitors(newMonitor(), svcMon))
+
+		op.op.OnAdd(newService())
+		queueEquals(t, op.op.monitorQueue, "testing/service-monitor")
+
+		op.op.OnAdd(newIngress())
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+}
+
+func TestOperator_HTTPRouteSource(t *testing.T) {
+	httpListener := map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"}
+	httpsListener := map[string]interface{}{
+		"name":     "https",
+		"hostname": "*.example.com",
+		"port":     int64(443),
+		"protocol": "HTTPS",
+		"tls":      map[string]interface{}{"mode": "Terminate"},
+	}
+
+	newRouteMonitor := func() *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.SourceKind = v1alpha1.SourceKindHTTPRoute
+		return mon
+	}
+
+	urls := func(t *testing.T, op *operatorWrapper) map[string]string {
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		urls := map[string]string{}
+		for _, im := range imList.Items {
+			urls[im.Name] = im.Spec.Template.HTTP.URL
+		}
+		return urls
+	}
+
+	t.Run("creates an IngressMonitor per hostname", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(
+				newHTTPRoute("api.example.com", "api.example.org", "*.example.net"),
+				newGateway(httpListener, httpsListener),
+			),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newRouteMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-route-" + shortHash("api.example.com", 16): "https://api.example.com/test-healthz",
+			"go-route-" + shortHash("api.example.org", 16): "http://api.example.org/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+
+		im, err := op.op.imClient.IngressMonitors("testing").Get(context.TODO(), "go-route-"+shortHash("api.example.com", 16), metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the IngressMonitor")
+
+		owner := metav1.GetControllerOf(im)
+		strEquals(t, "gateway.networking.k8s.io/v1", owner.APIVersion, "owner API version")
+		strEquals(t, "HTTPRoute", owner.Kind, "owner kind")
+		strEquals(t, "go-route", owner.Name, "owner name")
+		strEquals(t, "go-route", im.Labels[httpRouteLabel], "route label")
+	})
+
+	t.Run("only uses the referenced listener", func(t *testing.T) {
+		route := newHTTPRoute("api.example.com")
+		unstructured.SetNestedSlice(route.Object, []interface{}{
+			map[string]interface{}{"name": "go-gateway", "sectionName": "http"},
+		}, "spec", "parentRefs")
+
+		op := newOperator(t,
+			withDynamicObjects(route, newGateway(httpListener, httpsListener)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newRouteMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-route-" + shortHash("api.example.com", 16): "http://api.example.com/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("uses the listener hostnames without route hostnames", func(t *testing.T) {
+		listener := map[string]interface{}{
+			"name":     "https",
+			"hostname": "shop.example.com",
+			"port":     int64(8443),
+			"protocol": "HTTPS",
+		}
+
+		op := newOperator(t,
+			withDynamicObjects(newHTTPRoute(), newGateway(listener)),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newRouteMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-route-" + shortHash("shop.example.com", 16): "https://shop.example.com:8443/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("garbage collects removed hostnames", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(
+				newHTTPRoute("api.example.com", "api.example.org"),
+				newGateway(httpListener),
+			),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newRouteMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
+
+		informerFor(t, op.op, "HTTPRoute").GetIndexer().Update(newHTTPRoute("api.example.com"))
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+
+		exp := map[string]string{
+			"go-route-" + shortHash("api.example.com", 16): "http://api.example.com/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("enqueues the Monitors for a changed Gateway", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(newHTTPRoute("api.example.com"), newGateway(httpListener)),
+			withMonitors(newRouteMonitor()),
+		)
+
+		op.op.OnAdd(newGateway(httpListener, httpsListener))
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("without the Gateway API", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		expError := fmt.Errorf("Error doing garbage collection for testing:test-monitor: The cluster doesn't serve HTTPRoutes under any of the supported API versions")
+		errEquals(t, expError, op.handleMonitor(t, newRouteMonitor()))
+	})
+}
+
+func TestOperator_RouteSource(t *testing.T) {
+	newOpenShiftMonitor := func() *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.SourceKind = v1alpha1.SourceKindRoute
+		return mon
+	}
+
+	tcs := []struct {
+		name  string
+		route *unstructured.Unstructured
+		exp   map[string]string
+	}{
+		{
+			name:  "without TLS",
+			route: newOpenShiftRoute("shop.example.com", false),
+			exp: map[string]string{
+				"go-route-" + shortHash("shop.example.com", 16): "http://shop.example.com/test-healthz",
+			},
+		},
+		{
+			name:  "with TLS",
+			route: newOpenShiftRoute("shop.example.com", true),
+			exp: map[string]string{
+				"go-route-" + shortHash("shop.example.com", 16): "https://shop.example.com/test-healthz",
+			},
+		},
+		{
+			name: "with a generated host",
+			route: func() *unstructured.Unstructured {
+				route := newOpenShiftRoute("", false)
+				unstructured.SetNestedSlice(route.Object, []interface{}{
+					map[string]interface{}{"host": "go-route-testing.apps.example.com"},
+				}, "status", "ingress")
+				return route
+			}(),
+			exp: map[string]string{
+				"go-route-" + shortHash("go-route-testing.apps.example.com", 16): "http://go-route-testing.apps.example.com/test-healthz",
+			},
+		},
+		{
+			name:  "with a wildcard host",
+			route: newOpenShiftRoute("*.example.com", false),
+			exp:   map[string]string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			op := newOperator(t,
+				withDynamicObjects(tc.route),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+
+			errEquals(t, nil, op.handleMonitor(t, newOpenShiftMonitor()), "creating a new monitor")
+
+			imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+
+			act := map[string]string{}
+			for _, im := range imList.Items {
+				act[im.Name] = im.Spec.Template.HTTP.URL
+
+				owner := metav1.GetControllerOf(&im)
+				strEquals(t, "route.openshift.io/v1", owner.APIVersion, "owner API version")
+				strEquals(t, "Route", owner.Kind, "owner kind")
+				strEquals(t, "go-route", im.Labels[routeLabel], "route label")
+			}
+
+			if !reflect.DeepEqual(tc.exp, act) {
+				t.Errorf("Expected IngressMonitors %v, got %v", tc.exp, act)
+			}
+		})
+	}
+
+	t.Run("enqueues the Monitors for a changed Route", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(newOpenShiftRoute("shop.example.com", false)),
+			withMonitors(newOpenShiftMonitor()),
+		)
+
+		old := newOpenShiftRoute("shop.example.com", false)
+		old.SetResourceVersion("1")
+		route := newOpenShiftRoute("shop.example.com", true)
+		route.SetResourceVersion("2")
+
+		op.op.OnUpdate(old, route)
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("without OpenShift", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		expError := fmt.Errorf("Error doing garbage collection for testing:test-monitor: The cluster doesn't serve Routes under any of the supported API versions")
+		errEquals(t, expError, op.handleMonitor(t, newOpenShiftMonitor()))
+	})
+
+	t.Run("with an unknown source kind", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.SourceKind = "Pod"
+
+		expError := fmt.Errorf("Error doing garbage collection for testing:test-monitor: Unsupported source kind 'Pod'")
+		errEquals(t, expError, op.handleMonitor(t, mon))
+	})
+}
+
+func TestOperator_VirtualServiceSource(t *testing.T) {
+	httpServer := map[string]interface{}{
+		"port":  map[string]interface{}{"number": int64(80), "name": "http", "protocol": "HTTP"},
+		"hosts": []interface{}{"*"},
+		"tls":   map[string]interface{}{"httpsRedirect": true},
+	}
+	httpsServer := map[string]interface{}{
+		"port":  map[string]interface{}{"number": int64(443), "name": "https", "protocol": "HTTPS"},
+		"hosts": []interface{}{"testing/*.example.com"},
+		"tls":   map[string]interface{}{"mode": "SIMPLE", "credentialName": "example-com"},
+	}
+
+	newVirtualServiceMonitor := func() *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.SourceKind = v1alpha1.SourceKindVirtualService
+		return mon
+	}
+
+	urls := func(t *testing.T, op *operatorWrapper) map[string]string {
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		urls := map[string]string{}
+		for _, im := range imList.Items {
+			urls[im.Name] = im.Spec.Template.HTTP.URL
+		}
+		return urls
+	}
+
+	t.Run("creates an IngressMonitor per host", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(
+				newVirtualService([]string{"go-gateway"}, "api.example.com", "api.example.org", "*.example.net"),
+				newIstioGateway(httpServer, httpsServer),
+			),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newVirtualServiceMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-vs-" + shortHash("api.example.com", 16): "https://api.example.com/test-healthz",
+			"go-vs-" + shortHash("api.example.org", 16): "http://api.example.org/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+
+		im, err := op.op.imClient.IngressMonitors("testing").Get(context.TODO(), "go-vs-"+shortHash("api.example.com", 16), metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the IngressMonitor")
+
+		owner := metav1.GetControllerOf(im)
+		strEquals(t, "networking.istio.io/v1beta1", owner.APIVersion, "owner API version")
+		strEquals(t, "VirtualService", owner.Kind, "owner kind")
+		strEquals(t, "go-vs", owner.Name, "owner name")
+		strEquals(t, "go-vs", im.Labels[virtualServiceLabel], "virtual service label")
+	})
+
+	t.Run("uses the port of the server", func(t *testing.T) {
+		server := map[string]interface{}{
+			"port":  map[string]interface{}{"number": int64(8443), "name": "https", "protocol": "HTTPS"},
+			"hosts": []interface{}{"shop.example.com"},
+		}
+
+		op := newOperator(t,
+			withDynamicObjects(
+				newVirtualService([]string{"testing/go-gateway"}, "shop.example.com"),
+				newIstioGateway(server),
+			),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, newVirtualServiceMonitor()), "creating a new monitor")
+
+		exp := map[string]string{
+			"go-vs-" + shortHash("shop.example.com", 16): "https://shop.example.com:8443/test-healthz",
+		}
+		if act := urls(t, op); !reflect.DeepEqual(exp, act) {
+			t.Errorf("Expected IngressMonitors %v, got %v", exp, act)
+		}
+	})
+
+	t.Run("skips VirtualServices which are only bound to the mesh", func(t *testing.T) {
+		for _, gateways := range [][]string{nil, {"mesh"}} {
+			op := newOperator(t,
+				withDynamicObjects(newVirtualService(gateways, "api.example.com")),
+				withProviders(newProvider()),
+				withTemplates(newTemplate()),
+			)
+
+			errEquals(t, nil, op.handleMonitor(t, newVirtualServiceMonitor()), "creating a new monitor")
+
+			if act := urls(t, op); len(act) != 0 {
+				t.Errorf("Expected no IngressMonitors for gateways %v, got %v", gateways, act)
+			}
+		}
+	})
+
+	t.Run("enqueues the Monitors for a changed Gateway", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(
+				newVirtualService([]string{"go-gateway"}, "api.example.com"),
+				newIstioGateway(httpServer),
+			),
+			withMonitors(newVirtualServiceMonitor()),
+		)
+
+		op.op.OnAdd(newIstioGateway(httpServer, httpsServer))
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("doesn't enqueue the Monitors for a changed Gateway API Gateway", func(t *testing.T) {
+		op := newOperator(t,
+			withDynamicObjects(
+				newVirtualService([]string{"go-gateway"}, "api.example.com"),
+				newIstioGateway(httpServer),
+			),
+			withMonitors(newVirtualServiceMonitor()),
+		)
+
+		op.op.OnAdd(newGateway())
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("without Istio", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		expError := fmt.Errorf("Error doing garbage collection for testing:test-monitor: The cluster doesn't serve VirtualServices under any of the supported API versions")
+		errEquals(t, expError, op.handleMonitor(t, newVirtualServiceMonitor()))
+	})
+}
+
+func TestOperator_IngressEvents(t *testing.T) {
+	expressionMonitor := newMonitor()
+	expressionMonitor.Name = "expression-monitor"
+	expressionMonitor.Spec.Selector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "squad",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"operations"},
+			},
+		},
+	}
+
+	otherNamespace := newMonitor()
+	otherNamespace.Namespace = "other-namespace"
+
+	setup := func() *operatorWrapper {
+		return newOperator(t,
+			withMonitors(newMonitor(), expressionMonitor, otherNamespace),
+		)
+	}
+
+	t.Run("adding a selected ingress", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnAdd(newIngress())
+
+		queueEquals(t, op.op.monitorQueue, "testing/expression-monitor", "testing/test-monitor")
+	})
+
+	t.Run("adding an ingress which isn't selected", func(t *testing.T) {
+		op := setup()
+
+		ing := newIngress()
+		ing.Labels = map[string]string{"team": "rustaceans"}
+		op.op.OnAdd(ing)
+
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("updating the labels of an ingress", func(t *testing.T) {
+		op := setup()
+
+		old := newIngress()
+		old.ResourceVersion = "1"
+
+		ing := newIngress()
+		ing.ResourceVersion = "2"
+		ing.Labels = map[string]string{"squad": "operations"}
+		op.op.OnUpdate(old, ing)
+
+		// the Monitor which doesn't select the Ingress anymore should be
+		// enqueued so it can garbage collect its IngressMonitors.
+		queueEquals(t, op.op.monitorQueue, "testing/expression-monitor", "testing/test-monitor")
+	})
+
+	t.Run("resyncing an ingress", func(t *testing.T) {
+		op := setup()
+
+		ing := newIngress()
+		ing.ResourceVersion = "1"
+		op.op.OnUpdate(ing, ing)
+
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("deleting a selected ingress", func(t *testing.T) {
+		op := setup()
+
+		ing := newIngress()
+		op.op.OnDelete(cache.DeletedFinalStateUnknown{
+			Key: getKey(t, ing),
+			Obj: ing,
+		})
+
+		queueEquals(t, op.op.monitorQueue, "testing/expression-monitor", "testing/test-monitor")
+	})
+}
+
+func TestOperator_ReferenceEvents(t *testing.T) {
+	otherMonitor := newMonitor()
+	otherMonitor.Name = "other-monitor"
+	otherMonitor.Spec.Provider.Name = "other-provider"
+	otherMonitor.Spec.Template.Name = "other-template"
+
+	setup := func() *operatorWrapper {
+		return newOperator(t, withMonitors(newMonitor(), otherMonitor))
+	}
+
+	t.Run("updating a provider", func(t *testing.T) {
+		op := setup()
+
+		old := newProvider()
+		old.ResourceVersion = "1"
+
+		prov := newProvider()
+		prov.ResourceVersion = "2"
+		op.op.OnUpdate(old, prov)
+
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("updating one of multiple providers", func(t *testing.T) {
+		multiMonitor := newMonitor()
+		multiMonitor.Name = "multi-monitor"
+		multiMonitor.Spec.Provider = nil
+		multiMonitor.Spec.Providers = []v1alpha1.ProviderReference{{Name: "other-provider"}, {Name: "test-provider"}}
+
+		op := newOperator(t, withMonitors(newMonitor(), otherMonitor, multiMonitor))
+
+		prov := newProvider()
+		prov.Name = "other-provider"
+		op.op.OnAdd(prov)
+
+		queueEquals(t, op.op.monitorQueue, "testing/multi-monitor", "testing/other-monitor")
+	})
+
+	t.Run("resyncing a provider", func(t *testing.T) {
+		op := setup()
+
+		prov := newProvider()
+		prov.ResourceVersion = "1"
+		op.op.OnUpdate(prov, prov)
+
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("adding a template", func(t *testing.T) {
+		op := setup()
+
+		tmpl := newTemplate()
+		tmpl.Name = "other-template"
+		op.op.OnAdd(tmpl)
+
+		queueEquals(t, op.op.monitorQueue, "testing/other-monitor")
+	})
+
+	t.Run("deleting a template", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnDelete(newTemplate())
+
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("template changes are propagated to the IngressMonitors", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		tmpl := newTemplate()
+		tmpl.Spec.CheckRate = ptrString("5m")
+		op.op.mtInformer.GetIndexer().Update(tmpl)
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be available, got %d", len(imList.Items))
+		}
+
+		checkRate := imList.Items[0].Spec.Template.CheckRate
+		if checkRate == nil || *checkRate != "5m" {
+			t.Errorf("Expected the CheckRate to be updated to 5m, got %v", checkRate)
+		}
+	})
+}
+
+func TestOperator_ClusterResources(t *testing.T) {
+	clusterProvider := func(allowed *v1alpha1.AllowedNamespaces) *v1alpha1.ClusterProvider {
+		return &v1alpha1.ClusterProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-provider"},
+			Spec: v1alpha1.ClusterProviderSpec{
+				ProviderSpec:      v1alpha1.ProviderSpec{Type: "platform"},
+				AllowedNamespaces: allowed,
+			},
+		}
+	}
+
+	clusterTemplate := &v1alpha1.ClusterMonitorTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-template"},
+		Spec:       newTemplate().Spec,
+	}
+	clusterTemplate.Spec.Name = "platform-{{.Host}}"
+
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "testing",
+		Labels: map[string]string{"team": "gophers"},
+	}}
+
+	clusterMonitor := func() *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.Provider = &v1alpha1.ProviderReference{Kind: v1alpha1.ClusterProviderKind, Name: "platform-provider"}
+		mon.Spec.Template = v1alpha1.TemplateReference{Kind: v1alpha1.ClusterMonitorTemplateKind, Name: "platform-template"}
+		return mon
+	}
+
+	getReferencesCondition := func(op *operatorWrapper, mon *v1alpha1.Monitor) *v1alpha1.MonitorCondition {
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		cond := getMonitorCondition(mon.Status, v1alpha1.MonitorReferencesResolved)
+		if cond == nil {
+			t.Fatalf("Expected condition %s to be set", v1alpha1.MonitorReferencesResolved)
+		}
+
+		return cond
+	}
+
+	t.Run("with a ClusterProvider and ClusterMonitorTemplate", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withClusterResources("ingress-monitor", clusterProvider(nil), clusterTemplate, namespace),
+		)
+
+		mon := clusterMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		im := imList.Items[0]
+		strEquals(t, "platform", im.Spec.Provider.Type, "provider type")
+		strEquals(t, "ingress-monitor", im.Spec.Provider.Namespace, "provider namespace")
+		strEquals(t, "platform-api.example.com", im.Spec.Template.Name, "template name")
+	})
+
+	t.Run("with a ClusterProvider which doesn't allow the namespace", func(t *testing.T) {
+		allowed := &v1alpha1.AllowedNamespaces{Names: []string{"platform"}}
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withClusterResources("ingress-monitor", clusterProvider(allowed), clusterTemplate, namespace),
+		)
+
+		mon := clusterMonitor()
+		expError := fmt.Errorf("ClusterProvider platform-provider doesn't allow Monitors in namespace testing")
+		errEquals(t, expError, op.handleMonitor(t, mon))
+
+		cond := getReferencesCondition(op, mon)
+		strEquals(t, reasonProviderNotAllowed, cond.Reason, "condition reason")
+	})
+
+	t.Run("with a ClusterProvider which allows the namespace by name", func(t *testing.T) {
+		allowed := &v1alpha1.AllowedNamespaces{Names: []string{"platform", "testing"}}
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withClusterResources("ingress-monitor", clusterProvider(allowed), clusterTemplate, namespace),
+		)
+
+		errEquals(t, nil, op.handleMonitor(t, clusterMonitor()))
+	})
+
+	t.Run("with a ClusterProvider which selects namespaces by their labels", func(t *testing.T) {
+		tcs := []struct {
+			team    string
+			allowed bool
+		}{
+			{"gophers", true},
+			{"rustaceans", false},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.team, func(t *testing.T) {
+				allowed := &v1alpha1.AllowedNamespaces{Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": tc.team},
+				}}
+				op := newOperator(t,
+					withIngresses(newIngress()),
+					withClusterResources("ingress-monitor", clusterProvider(allowed), clusterTemplate, namespace),
+				)
+
+				err := op.handleMonitor(t, clusterMonitor())
+				if tc.allowed && err != nil {
+					t.Errorf("Expected the namespace to be allowed, got %s", err)
+				}
+
+				if !tc.allowed && err == nil {
+					t.Errorf("Expected the namespace not to be allowed")
+				}
+			})
+		}
+	})
+
+	t.Run("IngressMonitors using the secrets of a ClusterProvider", func(t *testing.T) {
+		allowed := &v1alpha1.AllowedNamespaces{Names: []string{"testing"}}
+
+		setup := func() (*operatorWrapper, *fake.SimpleProvider, v1alpha1.IngressMonitor) {
+			op := newOperator(t,
+				withIngresses(newIngress()),
+				withClusterResources("ingress-monitor", clusterProvider(allowed), clusterTemplate, namespace),
+			)
+
+			prov := &fake.SimpleProvider{CreateFunc: func(v1alpha1.MonitorTemplateSpec) (string, error) {
+				return "12345", nil
+			}}
+			op.op.providerFactory.Register("platform", fake.FactoryFunc(prov))
+
+			mon := clusterMonitor()
+			mon.UID = "monitor-uid"
+			errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+			if len(imList.Items) != 1 {
+				t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+			}
+
+			return op, prov, imList.Items[0]
+		}
+
+		t.Run("set up by a Monitor", func(t *testing.T) {
+			op, prov, im := setup()
+
+			op.op.imInformer.GetIndexer().Add(&im)
+			errEquals(t, nil, op.op.handleIngressMonitor(getKey(t, &im)), "syncing the IngressMonitor")
+
+			if prov.CreateCount != 1 {
+				t.Errorf("Expected the check to be created, got %d calls", prov.CreateCount)
+			}
+		})
+
+		t.Run("changed after being set up by a Monitor", func(t *testing.T) {
+			op, prov, im := setup()
+
+			im.Spec.Provider.Type = "simple"
+			op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+			op.op.imInformer.GetIndexer().Add(&im)
+			if err := op.op.handleIngressMonitor(getKey(t, &im)); err == nil {
+				t.Errorf("Expected an error for a provider which doesn't match the ClusterProvider")
+			}
+
+			if prov.CreateCount != 0 {
+				t.Errorf("Expected the check not to be created, got %d calls", prov.CreateCount)
+			}
+		})
+
+		t.Run("when the namespace isn't allowed anymore", func(t *testing.T) {
+			op, prov, im := setup()
+
+			cprov := clusterProvider(&v1alpha1.AllowedNamespaces{Names: []string{"platform"}})
+			op.op.cprovInformer.GetIndexer().Update(cprov)
+
+			op.op.imInformer.GetIndexer().Add(&im)
+			expError := fmt.Errorf("Monitor testing:test-monitor doesn't reference a ClusterProvider matching the provider of IngressMonitor %s which it is allowed to use", im.Name)
+			errEquals(t, expError, op.op.handleIngressMonitor(getKey(t, &im)))
+
+			if prov.CreateCount != 0 {
+				t.Errorf("Expected the check not to be created, got %d calls", prov.CreateCount)
+			}
+		})
+
+		t.Run("created without a Monitor", func(t *testing.T) {
+			op, prov, _ := setup()
+
+			im := newIngressMonitor()
+			im.Spec.Provider = v1alpha1.NamespacedProvider{
+				Namespace:    "ingress-monitor",
+				ProviderSpec: v1alpha1.ProviderSpec{Type: "platform"},
+			}
+
+			expError := fmt.Errorf("IngressMonitor testing:test-im isn't owned by a Monitor")
+			errEquals(t, expError, op.handleIngressMonitor(t, im))
+
+			if prov.CreateCount != 0 {
+				t.Errorf("Expected the check not to be created, got %d calls", prov.CreateCount)
+			}
+
+			im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+			errEquals(t, nil, err, "getting updated IngressMonitor")
+			strEquals(t, reasonProviderNotAllowed, getCondition(im.Status, v1alpha1.IngressMonitorReady).Reason, "condition reason")
+		})
+
+		t.Run("using the secrets in another namespace", func(t *testing.T) {
+			op, prov, _ := setup()
+
+			im := newIngressMonitor()
+			im.Spec.Provider = v1alpha1.NamespacedProvider{
+				Namespace:    "kube-system",
+				ProviderSpec: v1alpha1.ProviderSpec{Type: "platform"},
+			}
+
+			expError := fmt.Errorf("IngressMonitor testing:test-im can't use the secrets in namespace kube-system")
+			errEquals(t, expError, op.handleIngressMonitor(t, im))
+
+			if prov.CreateCount != 0 {
+				t.Errorf("Expected the check not to be created, got %d calls", prov.CreateCount)
+			}
+		})
+	})
+
+	t.Run("with a namespaced provider and a ClusterMonitorTemplate", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withClusterResources("ingress-monitor", clusterTemplate),
+		)
+
+		mon := clusterMonitor()
+		mon.Spec.Provider = &v1alpha1.ProviderReference{Name: "test-provider"}
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		strEquals(t, "testing", imList.Items[0].Spec.Provider.Namespace, "provider namespace")
+	})
+
+	t.Run("without a ClusterMonitorTemplate", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withClusterResources("ingress-monitor", clusterProvider(nil), namespace),
+		)
+
+		mon := clusterMonitor()
+		expError := fmt.Errorf("Could not get ClusterMonitorTemplate platform-template: clustermonitortemplate.ingressmonitor.sphc.io \"platform-template\" not found")
+		errEquals(t, expError, op.handleMonitor(t, mon))
+
+		cond := getReferencesCondition(op, mon)
+		strEquals(t, reasonTemplateNotFound, cond.Reason, "condition reason")
+	})
+
+	t.Run("without cluster resources", func(t *testing.T) {
+		op := newOperator(t, withIngresses(newIngress()))
+
+		mon := clusterMonitor()
+		expError := fmt.Errorf("Could not get ClusterProvider platform-provider: %s", errClusterResourcesDisabled)
+		errEquals(t, expError, op.handleMonitor(t, mon))
+	})
+
+	t.Run("events", func(t *testing.T) {
+		// otherMonitor references a namespaced Provider and MonitorTemplate
+		// with the same names as the cluster resources.
+		otherMonitor := newMonitor()
+		otherMonitor.Name = "other-monitor"
+		otherMonitor.Spec.Provider.Name = "platform-provider"
+		otherMonitor.Spec.Template.Name = "platform-template"
+
+		setup := func() *operatorWrapper {
+			return newOperator(t,
+				withMonitors(clusterMonitor(), otherMonitor),
+				withClusterResources("ingress-monitor"),
+			)
+		}
+
+		t.Run("updating a ClusterProvider", func(t *testing.T) {
+			op := setup()
+
+			old := clusterProvider(nil)
+			old.ResourceVersion = "1"
+
+			prov := clusterProvider(nil)
+			prov.ResourceVersion = "2"
+			op.op.OnUpdate(old, prov)
+
+			queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+		})
+
+		t.Run("deleting a ClusterMonitorTemplate", func(t *testing.T) {
+			op := setup()
+
+			op.op.OnDelete(clusterTemplate)
+
+			queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+		})
+
+		t.Run("updating the labels of a namespace", func(t *testing.T) {
+			op := setup()
+
+			updated := namespace.DeepCopy()
+			updated.Labels["team"] = "rustaceans"
+			op.op.OnUpdate(namespace, updated)
+
+			queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+		})
+
+		t.Run("resyncing a namespace", func(t *testing.T) {
+			op := setup()
+
+			op.op.OnUpdate(namespace, namespace)
+
+			queueEquals(t, op.op.monitorQueue)
 		})
 	})
 }
 
-func TestOperator_SyncMonitor(t *testing.T) {
-	t.Run("without matching ingresses", func(t *testing.T) {
-		op := newOperator(t)
+func TestOperator_MultipleProviders(t *testing.T) {
+	backupProvider := newProvider()
+	backupProvider.Name = "backup-provider"
+
+	tmpl := newTemplate()
+	tmpl.Spec.Name = "{{.Provider}}: {{.Host}}"
 
+	multiMonitor := func(providers ...string) *v1alpha1.Monitor {
 		mon := newMonitor()
-		errEquals(t, nil, op.handleMonitor(t, mon))
-	})
+		mon.Spec.Provider = nil
+		for _, name := range providers {
+			mon.Spec.Providers = append(mon.Spec.Providers, v1alpha1.ProviderReference{Name: name})
+		}
+		return mon
+	}
 
-	t.Run("without existing provider", func(t *testing.T) {
-		op := newOperator(t, withIngresses(newIngress()))
+	listIngressMonitors := func(op *operatorWrapper) map[string]v1alpha1.IngressMonitor {
+		imList, err := op.op.imClient.IngressMonitors("testing").List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
 
-		mon := newMonitor()
-		expError := fmt.Errorf("Could not get Provider testing:test-provider: provider.ingressmonitor.sphc.io \"test-provider\" not found")
-		errEquals(t, expError, op.handleMonitor(t, mon))
-	})
+		ims := map[string]v1alpha1.IngressMonitor{}
+		for _, im := range imList.Items {
+			ims[im.Labels[providerLabel]] = im
+		}
+		return ims
+	}
 
-	t.Run("without existing template", func(t *testing.T) {
+	t.Run("sets up an IngressMonitor per provider", func(t *testing.T) {
 		op := newOperator(t,
 			withIngresses(newIngress()),
-			withProviders(newProvider()),
+			withProviders(newProvider(), backupProvider),
+			withTemplates(tmpl),
 		)
 
-		mon := newMonitor()
-		expError := fmt.Errorf("Could not get MonitorTemplate test-template: monitortemplate.ingressmonitor.sphc.io \"test-template\" not found")
-		errEquals(t, expError, op.handleMonitor(t, mon))
+		errEquals(t, nil, op.handleMonitor(t, multiMonitor("test-provider", "backup-provider")), "creating a new monitor")
+
+		ims := listIngressMonitors(op)
+		if len(ims) != 2 {
+			t.Fatalf("Expected an IngressMonitor for each provider, got %d", len(ims))
+		}
+
+		for _, name := range []string{"test-provider", "backup-provider"} {
+			im, ok := ims[name]
+			if !ok {
+				t.Fatalf("Expected an IngressMonitor labelled with provider %s", name)
+			}
+
+			if !strings.HasPrefix(im.Name, "go-ingress-"+name+"-") {
+				t.Errorf("Expected the name of the IngressMonitor to contain the provider, got %s", im.Name)
+			}
+
+			strEquals(t, name+": api.example.com", im.Spec.Template.Name, "template name")
+		}
 	})
 
-	t.Run("with an ingress provider and template should create an IngressMonitor", func(t *testing.T) {
+	t.Run("keeps the names of IngressMonitors with a single provider", func(t *testing.T) {
 		op := newOperator(t,
 			withIngresses(newIngress()),
 			withProviders(newProvider()),
-			withTemplates(newTemplate()),
+			withTemplates(tmpl),
 		)
 
-		stopCh := make(chan struct{})
-		op.op.startInformers(stopCh)
+		errEquals(t, nil, op.handleMonitor(t, newMonitor()), "creating a new monitor")
 
-		defer func() {
-			stopCh <- struct{}{}
-		}()
+		im, ok := listIngressMonitors(op)["test-provider"]
+		if !ok {
+			t.Fatalf("Expected an IngressMonitor labelled with the provider")
+		}
+
+		target := newHostTarget(newIngress(), networkingv1.SchemeGroupVersion.WithKind("Ingress"), ingressLabel, "api.example.com", true)
+		strEquals(t, target.name(), im.Name, "IngressMonitor name")
+	})
+
+	t.Run("keeps the names of IngressMonitors when moving the provider to providers", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
 
 		mon := newMonitor()
 		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
 
-		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
-		errEquals(t, nil, err, "listing the IngressMonitors")
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
 
-		if len(imList.Items) != 1 {
-			t.Errorf("Expected 1 IngressMonitor to be created")
+		before, ok := listIngressMonitors(op)["test-provider"]
+		if !ok {
+			t.Fatalf("Expected an IngressMonitor labelled with the provider")
 		}
 
-		im := imList.Items[0]
+		mon = multiMonitor("test-provider")
+		op.op.mInformer.GetIndexer().Update(mon)
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
 
-		expName := "test-go-ingress-testing"
-		strEquals(t, expName, im.Spec.Template.Name)
+		ims := listIngressMonitors(op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor, got %d", len(ims))
+		}
 
-		expURL := "https://api.example.com/test-healthz"
-		strEquals(t, expURL, im.Spec.Template.HTTP.URL)
+		strEquals(t, before.Name, ims["test-provider"].Name, "IngressMonitor name")
 	})
 
-	t.Run("updating an existing monitor", func(t *testing.T) {
-		var op *operatorWrapper
-		var stopCh chan struct{}
-		setup := func() {
-			op = newOperator(t,
-				withIngresses(newIngress()),
-				withProviders(newProvider()),
-				withTemplates(newTemplate()),
-			)
-
-			// ensure that the monitor is added corectly
-			errEquals(t, nil, op.handleMonitor(t, newMonitor()))
+	t.Run("with a provider which doesn't exist", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
 
-			stopCh = make(chan struct{})
-			op.op.startInformers(stopCh)
+		mon := multiMonitor("test-provider", "backup-provider")
+		if err := op.handleMonitor(t, mon); err == nil {
+			t.Fatalf("Expected an error for the missing provider")
 		}
 
-		cleanup := func() {
-			stopCh <- struct{}{}
+		if ims := listIngressMonitors(op); len(ims) != 0 {
+			t.Errorf("Expected no IngressMonitors to be created, got %d", len(ims))
 		}
 
-		t.Run("changing labels", func(t *testing.T) {
-			setup()
-			defer cleanup()
-
-			mon := newMonitor()
-
-			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
-			errEquals(t, nil, err)
-
-			if len(imList.Items) != 1 {
-				t.Errorf("Expected 1 IngressMonitor to be available, got %d", len(imList.Items))
-			}
-
-			mon.Spec.Selector.MatchLabels["non-existing-key"] = "fake-value"
-			errEquals(t, nil, op.handleMonitor(t, mon))
-
-			imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
-			errEquals(t, nil, err)
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+		cond := getMonitorCondition(mon.Status, v1alpha1.MonitorReferencesResolved)
+		if cond == nil || cond.Status != v1.ConditionFalse {
+			t.Fatalf("Expected condition %s to be False, got %v", v1alpha1.MonitorReferencesResolved, cond)
+		}
+		strEquals(t, reasonProviderNotFound, cond.Reason, "condition reason")
+	})
 
-			if len(imList.Items) != 0 {
-				t.Errorf("Expected 0 IngressMonitor to be available, got %d", len(imList.Items))
-			}
-		})
+	t.Run("garbage collects removed providers", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider(), backupProvider),
+			withTemplates(tmpl),
+		)
 
-		t.Run("adding an ingress and resyncing", func(t *testing.T) {
-			setup()
-			defer cleanup()
+		mon := multiMonitor("test-provider", "backup-provider")
+		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
 
-			ing := newIngress()
-			ing.Name = "new-ingress"
-			newRule := v1beta1.IngressRule{
-				Host: "new.api.example.com",
-			}
-			ing.Spec.Rules = append(ing.Spec.Rules, newRule)
-			op.addIngress(ing)
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errEquals(t, nil, op.op.startInformers(stopCh), "starting the informers")
 
-			// trigger resync
-			mon := newMonitor()
-			errEquals(t, nil, op.handleMonitor(t, mon))
+		mon = multiMonitor("test-provider")
+		op.op.mInformer.GetIndexer().Update(mon)
+		errEquals(t, nil, op.op.handleMonitor(getKey(t, mon)), "resyncing the monitor")
 
-			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
-			errEquals(t, nil, err)
+		ims := listIngressMonitors(op)
+		if len(ims) != 1 {
+			t.Fatalf("Expected the IngressMonitor of the removed provider to be garbage collected, got %d", len(ims))
+		}
 
-			if len(imList.Items) != 3 {
-				t.Errorf("Expected 3 IngressMonitors to be available, got %d", len(imList.Items))
-			}
-		})
+		if _, ok := ims["test-provider"]; !ok {
+			t.Errorf("Expected the IngressMonitor of the remaining provider to be kept")
+		}
 	})
 }
 
@@ -344,18 +3233,56 @@ type operatorWrapper struct {
 }
 
 type operatorConfig struct {
-	ingresses   []runtime.Object
-	kubeObjects []runtime.Object
+	namespaces []string
+
+	// clusterNamespace is the namespace for cluster resources. Cluster
+	// resources aren't watched when it's empty.
+	clusterNamespace string
+
+	// ingressAPIs are the API versions the fake cluster serves Ingresses
+	// under. Defaults to networking.k8s.io/v1.
+	ingressAPIs []string
+
+	ingresses []runtime.Object
+	services  []runtime.Object
+
+	// dynamicObjects are the objects which aren't part of the Kubernetes
+	// API, like the Gateway API objects, served through the dynamic client.
+	dynamicObjects []runtime.Object
+	kubeObjects    []runtime.Object
 
 	providers       []runtime.Object
 	templates       []runtime.Object
 	monitors        []runtime.Object
 	ingressmonitors []runtime.Object
 	crdObjects      []runtime.Object
+
+	// clusterObjects are the ClusterProviders, ClusterMonitorTemplates and
+	// Namespaces which are added to the cluster informers.
+	clusterObjects []runtime.Object
 }
 
 type optionFunc func(*operatorConfig)
 
+func withIngressAPIs(groupVersions ...string) optionFunc {
+	return func(op *operatorConfig) {
+		op.ingressAPIs = append(op.ingressAPIs, groupVersions...)
+	}
+}
+
+func withNamespaces(namespaces ...string) optionFunc {
+	return func(op *operatorConfig) {
+		op.namespaces = append(op.namespaces, namespaces...)
+	}
+}
+
+func withClusterResources(namespace string, obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.clusterNamespace = namespace
+		op.clusterObjects = append(op.clusterObjects, obj...)
+	}
+}
+
 func withIngresses(obj ...runtime.Object) optionFunc {
 	return func(op *operatorConfig) {
 		op.ingresses = append(op.ingresses, obj...)
@@ -363,6 +3290,19 @@ func withIngresses(obj ...runtime.Object) optionFunc {
 	}
 }
 
+func withServices(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.services = append(op.services, obj...)
+		op.kubeObjects = append(op.kubeObjects, obj...)
+	}
+}
+
+func withDynamicObjects(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.dynamicObjects = append(op.dynamicObjects, obj...)
+	}
+}
+
 func withProviders(obj ...runtime.Object) optionFunc {
 	return func(op *operatorConfig) {
 		op.providers = append(op.providers, obj...)
@@ -401,11 +3341,41 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 	mtrc := metrics.New(registry)
 
 	k8sClient := k8sfake.NewSimpleClientset(cfg.kubeObjects...)
+
+	if len(cfg.ingressAPIs) == 0 {
+		cfg.ingressAPIs = []string{networkingv1.SchemeGroupVersion.String()}
+	}
+	for _, gv := range cfg.ingressAPIs {
+		k8sClient.Resources = append(k8sClient.Resources, &metav1.APIResourceList{
+			GroupVersion: gv,
+			APIResources: []metav1.APIResource{{Name: "ingresses", Kind: "Ingress", Namespaced: true}},
+		})
+	}
+	// only serve the optional APIs the dynamic objects belong to.
+	served := map[string]bool{}
+	for _, obj := range cfg.dynamicObjects {
+		group := obj.GetObjectKind().GroupVersionKind().Group
+		if list, ok := dynamicAPIs[group]; ok && !served[group] {
+			k8sClient.Resources = append(k8sClient.Resources, list)
+			served[group] = true
+		}
+	}
+	// the fake discovery client doesn't return a NotFound error for group
+	// versions it doesn't know about, so serve the others without resources.
+	var groupVersions []schema.GroupVersion
+	for _, gvs := range [][]schema.GroupVersion{ingressGroupVersions, gatewayGroupVersions, routeGroupVersions, istioGroupVersions} {
+		groupVersions = append(groupVersions, gvs...)
+	}
+	for _, gv := range groupVersions {
+		k8sClient.Resources = append(k8sClient.Resources, &metav1.APIResourceList{
+			GroupVersion: gv.String(),
+		})
+	}
 	crdClient := imfake.NewSimpleClientset(cfg.crdObjects...)
 	fact := provider.NewFactory(nil)
 	op, err := NewOperator(
-		k8sClient, crdClient, v1.NamespaceAll,
-		noResyncPeriodFunc(), fact, mtrc,
+		k8sClient, crdClient, newDynamicClient(cfg.dynamicObjects...), cfg.namespaces,
+		cfg.clusterNamespace, noResyncPeriodFunc(), fact, mtrc,
 	)
 	if err != nil {
 		t.Fatalf("Error creating the operator: %s", err)
@@ -420,10 +3390,21 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 		"Monitors",
 	)
 
+	op.recorder = record.NewFakeRecorder(100)
+
 	for _, ing := range cfg.ingresses {
 		op.ingInformer.GetIndexer().Add(ing)
 	}
 
+	for _, svc := range cfg.services {
+		op.svcInformer.GetIndexer().Add(svc)
+	}
+
+	for _, obj := range cfg.dynamicObjects {
+		gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+		informerFor(t, op, dynamicInformers[gk]).GetIndexer().Add(obj)
+	}
+
 	for _, prov := range cfg.providers {
 		op.provInformer.GetIndexer().Add(prov)
 	}
@@ -436,25 +3417,97 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 		op.imInformer.GetIndexer().Add(im)
 	}
 
+	for _, mon := range cfg.monitors {
+		op.mInformer.GetIndexer().Add(mon)
+	}
+
+	for _, obj := range cfg.clusterObjects {
+		switch obj.(type) {
+		case *v1alpha1.ClusterProvider:
+			op.cprovInformer.GetIndexer().Add(obj)
+		case *v1alpha1.ClusterMonitorTemplate:
+			op.cmtInformer.GetIndexer().Add(obj)
+		case *v1.Namespace:
+			op.nsInformer.GetIndexer().Add(obj)
+		}
+	}
+
 	return &operatorWrapper{op, k8sClient, crdClient}
 }
 
 func (o *operatorWrapper) handleIngressMonitor(t *testing.T, mon *v1alpha1.IngressMonitor) error {
 	o.op.imInformer.GetIndexer().Add(mon)
-	o.op.imClient.IngressMonitors(mon.Namespace).Create(mon)
+	o.op.imClient.IngressMonitors(mon.Namespace).Create(context.TODO(), mon, metav1.CreateOptions{})
 	return o.op.handleIngressMonitor(getKey(t, mon))
 }
 
 func (o *operatorWrapper) handleMonitor(t *testing.T, mon *v1alpha1.Monitor) error {
 	o.op.mInformer.GetIndexer().Add(mon)
-	o.op.imClient.Monitors(mon.Namespace).Create(mon)
+	o.op.imClient.Monitors(mon.Namespace).Create(context.TODO(), mon, metav1.CreateOptions{})
 	return o.op.handleMonitor(getKey(t, mon))
 }
 
-func (o *operatorWrapper) addIngress(ing *v1beta1.Ingress) {
+func (o *operatorWrapper) addIngress(ing *networkingv1.Ingress) {
 	o.op.ingInformer.GetIndexer().Add(ing)
 }
 
+// dynamicAPIs are the optional APIs the fake cluster serves when there are
+// objects for them.
+var dynamicAPIs = map[string]*metav1.APIResourceList{
+	gatewayGroup: {
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "httproutes", Kind: "HTTPRoute", Namespaced: true},
+			{Name: "gateways", Kind: "Gateway", Namespaced: true},
+		},
+	},
+	routeGroup: {
+		GroupVersion: "route.openshift.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "routes", Kind: "Route", Namespaced: true},
+		},
+	},
+	istioGroup: {
+		GroupVersion: "networking.istio.io/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "virtualservices", Kind: "VirtualService", Namespaced: true},
+			{Name: "gateways", Kind: "Gateway", Namespaced: true},
+		},
+	},
+}
+
+// dynamicInformers are the names of the informers the dynamic objects are
+// cached in.
+var dynamicInformers = map[schema.GroupKind]string{
+	{Group: gatewayGroup, Kind: httpRouteKind}:    "HTTPRoute",
+	{Group: gatewayGroup, Kind: gatewayKind}:      "Gateway",
+	{Group: routeGroup, Kind: routeKind}:          "Route",
+	{Group: istioGroup, Kind: virtualServiceKind}: "VirtualService",
+	{Group: istioGroup, Kind: istioGatewayKind}:   "Istio Gateway",
+}
+
+func newDynamicClient(objs ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: gatewayGroup, Version: "v1", Resource: "httproutes"}:         "HTTPRouteList",
+		{Group: gatewayGroup, Version: "v1", Resource: "gateways"}:           "GatewayList",
+		{Group: routeGroup, Version: "v1", Resource: "routes"}:               "RouteList",
+		{Group: istioGroup, Version: "v1beta1", Resource: "virtualservices"}: "VirtualServiceList",
+		{Group: istioGroup, Version: "v1beta1", Resource: "gateways"}:        "GatewayList",
+	}, objs...)
+}
+
+// informerFor returns the informer with the given name.
+func informerFor(t *testing.T, op *Operator, name string) cache.SharedIndexInformer {
+	for _, inf := range op.informers {
+		if inf.name == name {
+			return inf.informer
+		}
+	}
+
+	t.Fatalf("Could not find informer %s", name)
+	return nil
+}
+
 var noResyncPeriodFunc = func() time.Duration { return 0 }
 
 func getKey(t *testing.T, obj interface{}) string {
@@ -477,6 +3530,39 @@ func strEquals(t *testing.T, exp, act string, str ...string) {
 	}
 }
 
+func queueEquals(t *testing.T, queue workqueue.RateLimitingInterface, exp ...string) {
+	var act []string
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		act = append(act, item.(string))
+		queue.Done(item)
+	}
+
+	sort.Strings(act)
+	if !reflect.DeepEqual(exp, act) {
+		t.Errorf("Expected queue to contain %v, got %v", exp, act)
+	}
+}
+
+// eventsEqual drains the recorded Events and validates that they match the
+// expected Events.
+func eventsEqual(t *testing.T, recorder record.EventRecorder, exp ...string) {
+	events := recorder.(*record.FakeRecorder).Events
+
+	var act []string
+	for len(events) > 0 {
+		act = append(act, <-events)
+	}
+
+	if len(exp) == 0 && len(act) == 0 {
+		return
+	}
+
+	if !reflect.DeepEqual(exp, act) {
+		t.Errorf("Expected events %q, got %q", exp, act)
+	}
+}
+
 func errEquals(t *testing.T, exp, act error, str ...string) {
 	prefix := ""
 	for _, s := range str {
@@ -528,18 +3614,18 @@ func newMonitor() *v1alpha1.Monitor {
 					"team": "gophers",
 				},
 			},
-			Provider: v1.LocalObjectReference{
+			Provider: &v1alpha1.ProviderReference{
 				Name: "test-provider",
 			},
-			Template: v1.LocalObjectReference{
+			Template: v1alpha1.TemplateReference{
 				Name: "test-template",
 			},
 		},
 	}
 }
 
-func newIngress() *v1beta1.Ingress {
-	return &v1beta1.Ingress{
+func newIngress() *networkingv1.Ingress {
+	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "go-ingress",
 			Namespace: "testing",
@@ -548,21 +3634,176 @@ func newIngress() *v1beta1.Ingress {
 				"squad": "operations",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			TLS: []v1beta1.IngressTLS{
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{
 				{
 					Hosts: []string{
 						"api.example.com",
 					},
 				},
 			},
-			Rules: []v1beta1.IngressRule{
+			Rules: []networkingv1.IngressRule{
 				{Host: "api.example.com"},
 			},
 		},
 	}
 }
 
+func newService() *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "go-service",
+			Namespace: "testing",
+			Labels: map[string]string{
+				"team": "gophers",
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeLoadBalancer,
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 8080},
+				{Name: "https", Port: 443},
+				{Name: "postgres", Port: 5432},
+			},
+		},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{
+					{Hostname: "lb.example.com"},
+				},
+			},
+		},
+	}
+}
+
+func newServiceMonitor(port string) *v1alpha1.Monitor {
+	mon := newMonitor()
+	mon.Spec.SourceKind = v1alpha1.SourceKindService
+	mon.Spec.Service = &v1alpha1.ServiceSource{Port: port}
+	return mon
+}
+
+func newHTTPRoute(hostnames ...string) *unstructured.Unstructured {
+	hosts := make([]interface{}, len(hostnames))
+	for i, host := range hostnames {
+		hosts[i] = host
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":      "go-route",
+			"namespace": "testing",
+			"labels": map[string]interface{}{
+				"team": "gophers",
+			},
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "go-gateway"},
+			},
+			"hostnames": hosts,
+		},
+	}}
+}
+
+func newGateway(listeners ...map[string]interface{}) *unstructured.Unstructured {
+	ls := make([]interface{}, len(listeners))
+	for i, l := range listeners {
+		ls[i] = l
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata": map[string]interface{}{
+			"name":      "go-gateway",
+			"namespace": "testing",
+		},
+		"spec": map[string]interface{}{
+			"gatewayClassName": "example",
+			"listeners":        ls,
+		},
+	}}
+}
+
+func newOpenShiftRoute(host string, tls bool) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"to": map[string]interface{}{"kind": "Service", "name": "go-service"},
+	}
+	if host != "" {
+		spec["host"] = host
+	}
+	if tls {
+		spec["tls"] = map[string]interface{}{"termination": "edge"}
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata": map[string]interface{}{
+			"name":      "go-route",
+			"namespace": "testing",
+			"labels": map[string]interface{}{
+				"team": "gophers",
+			},
+		},
+		"spec": spec,
+	}}
+}
+
+func newVirtualService(gateways []string, hosts ...string) *unstructured.Unstructured {
+	spec := map[string]interface{}{}
+
+	hs := make([]interface{}, len(hosts))
+	for i, host := range hosts {
+		hs[i] = host
+	}
+	spec["hosts"] = hs
+
+	if gateways != nil {
+		gws := make([]interface{}, len(gateways))
+		for i, gw := range gateways {
+			gws[i] = gw
+		}
+		spec["gateways"] = gws
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "VirtualService",
+		"metadata": map[string]interface{}{
+			"name":      "go-vs",
+			"namespace": "testing",
+			"labels": map[string]interface{}{
+				"team": "gophers",
+			},
+		},
+		"spec": spec,
+	}}
+}
+
+func newIstioGateway(servers ...map[string]interface{}) *unstructured.Unstructured {
+	ss := make([]interface{}, len(servers))
+	for i, srv := range servers {
+		ss[i] = srv
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "Gateway",
+		"metadata": map[string]interface{}{
+			"name":      "go-gateway",
+			"namespace": "testing",
+		},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"istio": "ingressgateway"},
+			"servers":  ss,
+		},
+	}}
+}
+
 func newProvider() *v1alpha1.Provider {
 	return &v1alpha1.Provider{
 		ObjectMeta: metav1.ObjectMeta{
@@ -592,3 +3833,15 @@ func newIngressMonitor() *v1alpha1.IngressMonitor {
 func ptrString(s string) *string {
 	return &s
 }
+
+// getMonitorCondition returns the condition of the given type, or nil if it
+// isn't set.
+func getMonitorCondition(status v1alpha1.MonitorStatus, tp v1alpha1.MonitorConditionType) *v1alpha1.MonitorCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == tp {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package ingressmonitor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// errClusterResourcesDisabled is returned when a Monitor references a cluster
+// scoped object whilst the Operator isn't watching them.
+var errClusterResourcesDisabled = errors.New("cluster resources are disabled, the Operator needs to run with --cluster-resource-namespace")
+
+// monitorProviders returns the references to all the providers the Monitor
+// sets up its checks with.
+func monitorProviders(mon *v1alpha1.Monitor) []v1alpha1.ProviderReference {
+	if len(mon.Spec.Providers) > 0 {
+		return mon.Spec.Providers
+	}
+
+	if mon.Spec.Provider != nil {
+		return []v1alpha1.ProviderReference{*mon.Spec.Provider}
+	}
+
+	return nil
+}
+
+// providerKind returns the kind of the referenced provider.
+func providerKind(ref v1alpha1.ProviderReference) string {
+	if ref.Kind == "" {
+		return v1alpha1.ProviderKind
+	}
+
+	return ref.Kind
+}
+
+// templateKind returns the kind of template the Monitor references.
+func templateKind(mon *v1alpha1.Monitor) string {
+	if mon.Spec.Template.Kind == "" {
+		return v1alpha1.MonitorTemplateKind
+	}
+
+	return mon.Spec.Template.Kind
+}
+
+// ingressMonitorName returns the name of the IngressMonitor the Monitor sets up
+// for the target with the referenced provider. Monitors which reference a
+// single provider keep the name of the target, whether it's set through
+// Provider or Providers, so upgrading or moving the provider to Providers
+// doesn't replace their existing IngressMonitors.
+func ingressMonitorName(mon *v1alpha1.Monitor, target monitorTarget, ref v1alpha1.ProviderReference) string {
+	if len(monitorProviders(mon)) <= 1 {
+		return target.name()
+	}
+
+	return target.providerName(ref)
+}
+
+// resolveProvider returns the referenced provider of the Monitor, together
+// with the namespace its secrets are looked up in. The returned reason
+// describes why the provider couldn't be resolved.
+func (o *Operator) resolveProvider(mon *v1alpha1.Monitor, ref v1alpha1.ProviderReference) (v1alpha1.NamespacedProvider, string, error) {
+	name := ref.Name
+
+	switch providerKind(ref) {
+	case v1alpha1.ProviderKind:
+		prov, err := o.provLister.Providers(mon.Namespace).Get(name)
+		if err != nil {
+			return v1alpha1.NamespacedProvider{}, reasonProviderNotFound, fmt.Errorf("Could not get Provider %s:%s: %s", mon.Namespace, name, err)
+		}
+
+		return v1alpha1.NamespacedProvider{Namespace: mon.Namespace, ProviderSpec: prov.Spec}, "", nil
+	case v1alpha1.ClusterProviderKind:
+		if o.clusterNamespace == "" {
+			return v1alpha1.NamespacedProvider{}, reasonProviderNotFound, fmt.Errorf("Could not get ClusterProvider %s: %s", name, errClusterResourcesDisabled)
+		}
+
+		prov, err := o.cprovLister.Get(name)
+		if err != nil {
+			return v1alpha1.NamespacedProvider{}, reasonProviderNotFound, fmt.Errorf("Could not get ClusterProvider %s: %s", name, err)
+		}
+
+		allowed, err := o.namespaceAllowed(prov.Spec.AllowedNamespaces, mon.Namespace)
+		if err != nil {
+			return v1alpha1.NamespacedProvider{}, reasonProviderNotAllowed, fmt.Errorf("Could not check the allowed namespaces of ClusterProvider %s: %s", name, err)
+		}
+
+		if !allowed {
+			return v1alpha1.NamespacedProvider{}, reasonProviderNotAllowed, fmt.Errorf("ClusterProvider %s doesn't allow Monitors in namespace %s", name, mon.Namespace)
+		}
+
+		// The secrets of a ClusterProvider live in the namespace for cluster
+		// resources, not in the namespace of the Monitor.
+		return v1alpha1.NamespacedProvider{Namespace: o.clusterNamespace, ProviderSpec: prov.Spec.ProviderSpec}, "", nil
+	}
+
+	return v1alpha1.NamespacedProvider{}, reasonProviderNotFound, fmt.Errorf("Unsupported provider kind '%s'", ref.Kind)
+}
+
+// resolveTemplate returns the template the Monitor references. The returned
+// reason describes why the template couldn't be resolved.
+func (o *Operator) resolveTemplate(mon *v1alpha1.Monitor) (*v1alpha1.MonitorTemplateSpec, string, error) {
+	name := mon.Spec.Template.Name
+
+	switch templateKind(mon) {
+	case v1alpha1.MonitorTemplateKind:
+		tmpl, err := o.mtLister.MonitorTemplates(mon.Namespace).Get(name)
+		if err != nil {
+			return nil, reasonTemplateNotFound, fmt.Errorf("Could not get MonitorTemplate %s: %s", name, err)
+		}
+
+		return &tmpl.Spec, "", nil
+	case v1alpha1.ClusterMonitorTemplateKind:
+		if o.clusterNamespace == "" {
+			return nil, reasonTemplateNotFound, fmt.Errorf("Could not get ClusterMonitorTemplate %s: %s", name, errClusterResourcesDisabled)
+		}
+
+		tmpl, err := o.cmtLister.Get(name)
+		if err != nil {
+			return nil, reasonTemplateNotFound, fmt.Errorf("Could not get ClusterMonitorTemplate %s: %s", name, err)
+		}
+
+		return &tmpl.Spec, "", nil
+	}
+
+	return nil, reasonTemplateNotFound, fmt.Errorf("Unsupported template kind '%s'", mon.Spec.Template.Kind)
+}
+
+// namespaceAllowed returns whether or not the namespace is part of the allowed
+// namespaces. All namespaces are allowed when allowed is nil.
+func (o *Operator) namespaceAllowed(allowed *v1alpha1.AllowedNamespaces, namespace string) (bool, error) {
+	if allowed == nil {
+		return true, nil
+	}
+
+	for _, name := range allowed.Names {
+		if name == namespace {
+			return true, nil
+		}
+	}
+
+	if allowed.Selector == nil {
+		return false, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+	if err != nil {
+		return false, err
+	}
+
+	ns, err := o.nsLister.Get(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	return sel.Matches(labels.Set(ns.Labels)), nil
+}
+
+// authorizeProvider checks whether the IngressMonitor may use the secrets in
+// the namespace of its provider. IngressMonitors can always use the secrets in
+// their own namespace. As IngressMonitors can be created without a Monitor,
+// the secrets in the namespace for cluster resources can only be used when the
+// provider is a ClusterProvider which the Monitor owning the IngressMonitor is
+// allowed to use. The returned reason describes why the provider isn't
+// allowed.
+func (o *Operator) authorizeProvider(im *v1alpha1.IngressMonitor) (string, error) {
+	namespace := im.Spec.Provider.Namespace
+	if namespace == im.Namespace {
+		return "", nil
+	}
+
+	if o.clusterNamespace == "" || namespace != o.clusterNamespace {
+		return reasonProviderNotAllowed, fmt.Errorf("IngressMonitor %s:%s can't use the secrets in namespace %s", im.Namespace, im.Name, namespace)
+	}
+
+	mon, err := o.owningMonitor(im)
+	if err != nil {
+		return reasonProviderNotAllowed, err
+	}
+
+	for _, ref := range monitorProviders(mon) {
+		if providerKind(ref) != v1alpha1.ClusterProviderKind {
+			continue
+		}
+
+		// resolveProvider checks the allowed namespaces of the
+		// ClusterProvider.
+		prov, _, err := o.resolveProvider(mon, ref)
+		if err == nil && reflect.DeepEqual(prov, im.Spec.Provider) {
+			return "", nil
+		}
+	}
+
+	return reasonProviderNotAllowed, fmt.Errorf("Monitor %s:%s doesn't reference a ClusterProvider matching the provider of IngressMonitor %s which it is allowed to use", mon.Namespace, mon.Name, im.Name)
+}
+
+// owningMonitor returns the Monitor which owns the IngressMonitor.
+func (o *Operator) owningMonitor(im *v1alpha1.IngressMonitor) (*v1alpha1.Monitor, error) {
+	for _, ref := range im.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != v1alpha1.GroupName || ref.Kind != "Monitor" {
+			continue
+		}
+
+		item, exists, err := o.mInformer.GetIndexer().GetByKey(im.Namespace + "/" + ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get Monitor %s:%s: %s", im.Namespace, ref.Name, err)
+		}
+
+		// A Monitor which has been recreated with the same name doesn't own
+		// the IngressMonitor.
+		if !exists || item.(*v1alpha1.Monitor).UID != ref.UID {
+			return nil, fmt.Errorf("Monitor %s:%s owning IngressMonitor %s doesn't exist", im.Namespace, ref.Name, im.Name)
+		}
+
+		return item.(*v1alpha1.Monitor), nil
+	}
+
+	return nil, fmt.Errorf("IngressMonitor %s:%s isn't owned by a Monitor", im.Namespace, im.Name)
+}
@@ -0,0 +1,132 @@
+package ingressmonitor
+
+import (
+	"log"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// serviceGVK is the GroupVersionKind which is used to set up the
+// OwnerReferences to Services.
+var serviceGVK = v1.SchemeGroupVersion.WithKind("Service")
+
+// serviceSource selects Services of type LoadBalancer and sets up a target for
+// every load balancer address.
+type serviceSource struct {
+	informer cache.SharedIndexInformer
+	lister   corelisters.ServiceLister
+}
+
+func (s *serviceSource) label() string {
+	return serviceLabel
+}
+
+func (s *serviceSource) list(namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	serviceList, err := s.lister.Services(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]metav1.Object, len(serviceList))
+	for i, svc := range serviceList {
+		objs[i] = svc
+	}
+
+	return objs, nil
+}
+
+func (s *serviceSource) get(namespace, name string) (runtime.Object, bool) {
+	return informerObject(s.informer, namespace, name)
+}
+
+// selects limits the selected Services to the ones of type LoadBalancer.
+func (s *serviceSource) selects(mon *v1alpha1.Monitor, obj metav1.Object) bool {
+	return obj.(*v1.Service).Spec.Type == v1.ServiceTypeLoadBalancer
+}
+
+// targets returns a target for every load balancer address of the Service.
+// Services which haven't been assigned an address yet don't have any targets,
+// they're picked up when the Service status is updated.
+func (s *serviceSource) targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget {
+	svc := obj.(*v1.Service)
+
+	var portName string
+	if mon.Spec.Service != nil {
+		portName = mon.Spec.Service.Port
+	}
+
+	port, ok := servicePort(svc, portName)
+	if !ok {
+		log.Printf("Service %s:%s has no port named '%s'", svc.Namespace, svc.Name, portName)
+		return nil
+	}
+
+	scheme := serviceScheme(svc, port)
+
+	var targets []monitorTarget
+	for _, lb := range svc.Status.LoadBalancer.Ingress {
+		host := lb.Hostname
+		if host == "" {
+			host = lb.IP
+		}
+
+		if host == "" {
+			continue
+		}
+
+		targets = append(targets, monitorTarget{
+			owner:      svc,
+			ownerGVK:   serviceGVK,
+			ownerLabel: serviceLabel,
+			scheme:     scheme,
+			host:       host,
+			port:       port.Port,
+		})
+	}
+
+	return targets
+}
+
+func (s *serviceSource) related(obj interface{}) []metav1.Object {
+	if svc, ok := obj.(*v1.Service); ok {
+		return []metav1.Object{svc}
+	}
+
+	return nil
+}
+
+// serviceScheme returns the scheme the port of the Service is checked with.
+// This is configured through the scheme annotation, otherwise ports named
+// `https` or using port 443 are checked over HTTPS and all other ports over
+// HTTP.
+func serviceScheme(svc *v1.Service, port v1.ServicePort) string {
+	switch scheme := svc.Annotations[schemeAnnotation]; scheme {
+	case "http", "https":
+		return scheme
+	}
+
+	if port.Name == "https" || port.Port == 443 {
+		return "https"
+	}
+
+	return "http"
+}
+
+// servicePort returns the port of the Service with the given name. When no
+// name is given, the first port is returned.
+func servicePort(svc *v1.Service, name string) (v1.ServicePort, bool) {
+	for _, port := range svc.Spec.Ports {
+		if name == "" || port.Name == name {
+			return port, true
+		}
+	}
+
+	return v1.ServicePort{}, false
+}
@@ -0,0 +1,281 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// sourceAdapter turns the objects of a specific kind into the targets the
+// Operator sets up IngressMonitors for. A Monitor picks the adapter through
+// its SourceKind.
+type sourceAdapter interface {
+	// label is the label which links an IngressMonitor to the object it's
+	// set up for.
+	label() string
+
+	// list returns the objects in the given namespace which match the
+	// selector.
+	list(namespace string, selector labels.Selector) ([]metav1.Object, error)
+
+	// get returns the object with the given name, if it exists.
+	get(namespace, name string) (runtime.Object, bool)
+
+	// selects returns whether or not the Monitor selects the object, besides
+	// matching its label selector.
+	selects(mon *v1alpha1.Monitor, obj metav1.Object) bool
+
+	// targets returns the targets the Monitor should set up for the object.
+	targets(mon *v1alpha1.Monitor, obj metav1.Object) []monitorTarget
+
+	// related returns the objects of the adapter's kind which are affected by
+	// a change to the given object. This is the object itself when it's of
+	// the adapter's kind, or the objects attached to it when it's an object
+	// the adapter depends on, like a Gateway.
+	related(obj interface{}) []metav1.Object
+}
+
+// monitorTarget describes a single endpoint of an object selected by a
+// Monitor. An IngressMonitor is set up for every target.
+type monitorTarget struct {
+	// owner is the selected object. The IngressMonitor is controlled by it so
+	// it gets garbage collected when the object is removed.
+	owner    metav1.Object
+	ownerGVK schema.GroupVersionKind
+
+	// ownerLabel is the label which links the IngressMonitor to the owner.
+	ownerLabel string
+
+	scheme string
+	host   string
+	port   int32
+
+	// path is the path prefix the target is served under. This is only set
+	// up when a Monitor sets up a monitor per path.
+	path string
+
+	// exact is set when the path only matches exactly, in which case the
+	// path itself is checked instead of the health endpoint.
+	exact bool
+}
+
+// name returns the name of the IngressMonitor for this target. Targets at the
+// root path are named after their host only, so enabling per path monitors
+// doesn't replace the existing IngressMonitors for those.
+func (t monitorTarget) name() string {
+	return fmt.Sprintf("%s-%s", t.owner.GetName(), shortHash(t.key(), 16))
+}
+
+// providerName returns the name of the IngressMonitor for the target with the
+// referenced provider. The name of the provider is part of the name so the
+// IngressMonitors of a target can be told apart, the hash covers its kind so a
+// Provider and a ClusterProvider with the same name don't collide.
+func (t monitorTarget) providerName(ref v1alpha1.ProviderReference) string {
+	key := strings.Join([]string{t.key(), providerKind(ref), ref.Name}, "/")
+	return fmt.Sprintf("%s-%s-%s", t.owner.GetName(), ref.Name, shortHash(key, 16))
+}
+
+// key identifies the target within the selected object.
+func (t monitorTarget) key() string {
+	if t.path != "" && t.path != "/" {
+		return t.host + t.path
+	}
+
+	return t.host
+}
+
+// url returns the URL which should be checked for the given health endpoint.
+// The endpoint is relative to the path of the target. The port is left out
+// when it's the default port for the scheme.
+func (t monitorTarget) url(endpoint string) string {
+	host := t.host
+	switch {
+	case t.port != 0 && !(t.scheme == "http" && t.port == 80) && !(t.scheme == "https" && t.port == 443):
+		host = net.JoinHostPort(t.host, strconv.Itoa(int(t.port)))
+	case strings.Contains(t.host, ":"):
+		// IPv6 addresses have to be bracketed, even without a port.
+		host = "[" + t.host + "]"
+	}
+
+	path := endpoint
+	switch {
+	case t.exact:
+		path = t.path
+	case t.path != "":
+		if !strings.HasPrefix(endpoint, "/") {
+			endpoint = "/" + endpoint
+		}
+		path = strings.TrimSuffix(t.path, "/") + endpoint
+	}
+
+	return fmt.Sprintf("%s://%s%s", t.scheme, host, path)
+}
+
+// newHostTarget returns a target for a host which is served over HTTPS when
+// tls is set, and over HTTP otherwise.
+func newHostTarget(owner metav1.Object, gvk schema.GroupVersionKind, label, host string, tls bool) monitorTarget {
+	target := monitorTarget{
+		owner:      owner,
+		ownerGVK:   gvk,
+		ownerLabel: label,
+		scheme:     "http",
+		host:       host,
+		port:       80,
+	}
+
+	if tls {
+		target.scheme = "https"
+		target.port = 443
+	}
+
+	return target
+}
+
+// sourceKind returns the kind of objects the Monitor selects.
+func sourceKind(mon *v1alpha1.Monitor) string {
+	if mon.Spec.SourceKind == "" {
+		return v1alpha1.SourceKindIngress
+	}
+
+	return mon.Spec.SourceKind
+}
+
+// source returns the adapter for the kind of objects the Monitor selects.
+func (o *Operator) source(mon *v1alpha1.Monitor) (sourceAdapter, error) {
+	kind := sourceKind(mon)
+	if src, ok := o.sources[kind]; ok {
+		return src, nil
+	}
+
+	// Sources which are backed by optional APIs are only registered when the
+	// cluster serves them.
+	for _, k := range v1alpha1.SourceKinds {
+		if k == kind {
+			return nil, fmt.Errorf("The cluster doesn't serve %ss under any of the supported API versions", kind)
+		}
+	}
+
+	return nil, fmt.Errorf("Unsupported source kind '%s'", kind)
+}
+
+// selectedObjects returns the objects which are selected by the Monitor.
+func (o *Operator) selectedObjects(src sourceAdapter, mon *v1alpha1.Monitor) ([]metav1.Object, error) {
+	sel, err := metav1.LabelSelectorAsSelector(mon.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create label selector for %s:%s: %s", mon.Namespace, mon.Name, err)
+	}
+
+	objs, err := src.list(mon.Namespace, sel)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list %ss: %s", sourceKind(mon), err)
+	}
+
+	var selected []metav1.Object
+	for _, obj := range objs {
+		// Objects which are opted out of monitoring aren't selected, which
+		// garbage collects their IngressMonitors.
+		if src.selects(mon, obj) && monitoringEnabled(obj) {
+			selected = append(selected, obj)
+		}
+	}
+
+	return selected, nil
+}
+
+// monitorTargets returns the targets for all the objects selected by the
+// Monitor, together with the selected objects.
+func (o *Operator) monitorTargets(mon *v1alpha1.Monitor) ([]monitorTarget, []metav1.Object, error) {
+	src, err := o.source(mon)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objs, err := o.selectedObjects(src, mon)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var targets []monitorTarget
+	for _, obj := range objs {
+		targets = append(targets, src.targets(mon, obj)...)
+	}
+
+	return targets, objs, nil
+}
+
+// templateSpecFor fills in the endpoint of the target in the given template
+// spec, depending on the type of check.
+func templateSpecFor(target monitorTarget, spec *v1alpha1.MonitorTemplateSpec) {
+	if spec.Type == v1alpha1.CheckTypeTCP {
+		spec.TCP = &v1alpha1.TCPTemplate{
+			Host: target.host,
+			Port: target.port,
+		}
+		return
+	}
+
+	if spec.HTTP == nil {
+		spec.HTTP = &v1alpha1.HTTPTemplate{}
+	}
+
+	healthPath := "/_healthz"
+	if spec.HTTP.Endpoint != nil {
+		healthPath = *spec.HTTP.Endpoint
+	}
+
+	spec.HTTP.URL = target.url(healthPath)
+}
+
+// enqueueMonitorsForSourceObject enqueues all the Monitors which select an
+// object affected by a change to the given object. This makes sure that new
+// hosts get picked up and removed hosts get garbage collected without having
+// to wait for the Monitor to be resynced.
+func (o *Operator) enqueueMonitorsForSourceObject(obj interface{}) {
+	for kind, src := range o.sources {
+		for _, rel := range src.related(obj) {
+			for _, mon := range o.monitorsFor(kind, rel) {
+				o.enqueueMonitor(mon)
+			}
+		}
+	}
+}
+
+// informerObjects returns the objects in the informer cache which are in the
+// given namespace and match the selector.
+func informerObjects(informer cache.SharedIndexInformer, namespace string, selector labels.Selector) ([]metav1.Object, error) {
+	var objs []metav1.Object
+	err := cache.ListAllByNamespace(informer.GetIndexer(), namespace, selector, func(item interface{}) {
+		obj, err := meta.Accessor(item)
+		if err != nil {
+			log.Printf("Unexpected object %T in cache: %s", item, err)
+			return
+		}
+
+		objs = append(objs, obj)
+	})
+
+	return objs, err
+}
+
+// informerObject returns the object with the given name from the informer
+// cache, if it exists.
+func informerObject(informer cache.SharedIndexInformer, namespace, name string) (runtime.Object, bool) {
+	item, exists, err := informer.GetIndexer().GetByKey(namespacedIndexKey(namespace, name))
+	if err != nil || !exists {
+		return nil, false
+	}
+
+	obj, ok := item.(runtime.Object)
+	return obj, ok
+}
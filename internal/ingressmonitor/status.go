@@ -0,0 +1,250 @@
+package ingressmonitor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// These are the reasons which are used for the conditions of an
+// IngressMonitor.
+const (
+	reasonSynced              = "Synced"
+	reasonProviderUnavailable = "ProviderUnavailable"
+	reasonCreateFailed        = "CreateFailed"
+	reasonUpdateFailed        = "UpdateFailed"
+)
+
+// These are the reasons which are used for the conditions of a Monitor.
+const (
+	reasonResolved               = "Resolved"
+	reasonProviderNotFound       = "ProviderNotFound"
+	reasonProviderNotAllowed     = "ProviderNotAllowed"
+	reasonTemplateNotFound       = "TemplateNotFound"
+	reasonTemplateInvalid        = "TemplateInvalid"
+	reasonCheckTypeNotSupported  = "CheckTypeNotSupported"
+	reasonReconciled             = "Reconciled"
+	reasonReconcileFailed        = "ReconcileFailed"
+	reasonIngressMonitorsFailed  = "IngressMonitorsFailed"
+	reasonIngressMonitorsPending = "IngressMonitorsPending"
+	reasonSelectionValid         = "Valid"
+	reasonSelectionWarnings      = "SelectionWarnings"
+)
+
+// setSyncStatus updates the status of an IngressMonitor with the result of a
+// sync with the provider. The generation is only marked as observed when the
+// sync succeeded. The LastSyncTime is only bumped when the sync changed the
+// status compared to the previous status, so periodic resyncs which don't
+// change anything don't result in a status update.
+func setSyncStatus(status *v1alpha1.IngressMonitorStatus, previous v1alpha1.IngressMonitorStatus, generation int64, reason string, err error) {
+	now := metav1.Now()
+
+	if err == nil {
+		status.ObservedGeneration = generation
+		status.LastError = ""
+
+		setCondition(status, v1alpha1.IngressMonitorProviderSynced, v1.ConditionTrue, reason, "", now)
+		setCondition(status, v1alpha1.IngressMonitorReady, v1.ConditionTrue, reason, "", now)
+		setCondition(status, v1alpha1.IngressMonitorDegraded, v1.ConditionFalse, reason, "", now)
+
+		if status.LastSyncTime == nil || !reflect.DeepEqual(*status, previous) {
+			status.LastSyncTime = &now
+		}
+		return
+	}
+
+	status.LastError = err.Error()
+
+	setCondition(status, v1alpha1.IngressMonitorProviderSynced, v1.ConditionFalse, reason, err.Error(), now)
+	setCondition(status, v1alpha1.IngressMonitorReady, v1.ConditionFalse, reason, err.Error(), now)
+
+	// If there's an ID, the monitor is still running with the provider, it's
+	// just not up to date with the latest spec.
+	if status.ID != "" {
+		msg := fmt.Sprintf("Monitor '%s' is configured with the provider but could not be synced: %s", status.ID, err)
+		setCondition(status, v1alpha1.IngressMonitorDegraded, v1.ConditionTrue, reason, msg, now)
+	} else {
+		setCondition(status, v1alpha1.IngressMonitorDegraded, v1.ConditionFalse, reason, "", now)
+	}
+}
+
+// setCondition sets the condition of the given type on the status. The
+// LastTransitionTime is only updated when the status of the condition changes.
+func setCondition(status *v1alpha1.IngressMonitorStatus, tp v1alpha1.IngressMonitorConditionType, cs v1.ConditionStatus, reason, message string, now metav1.Time) {
+	cond := v1alpha1.IngressMonitorCondition{
+		Type:               tp,
+		Status:             cs,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+
+	for i, c := range status.Conditions {
+		if c.Type != tp {
+			continue
+		}
+
+		if c.Status == cs {
+			cond.LastTransitionTime = c.LastTransitionTime
+		}
+
+		status.Conditions[i] = cond
+		return
+	}
+
+	status.Conditions = append(status.Conditions, cond)
+}
+
+// getCondition returns the condition of the given type, or nil if it isn't
+// set.
+func getCondition(status v1alpha1.IngressMonitorStatus, tp v1alpha1.IngressMonitorConditionType) *v1alpha1.IngressMonitorCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == tp {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// updateIngressMonitorStatus writes the status to the IngressMonitor through
+// the status subresource. The status is only written when it has changed.
+func (o *Operator) updateIngressMonitorStatus(obj *v1alpha1.IngressMonitor, status *v1alpha1.IngressMonitorStatus) error {
+	if reflect.DeepEqual(obj.Status, *status) {
+		return nil
+	}
+
+	obj.Status = *status
+	if _, err := o.imClient.IngressMonitors(obj.Namespace).UpdateStatus(context.TODO(), obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Could not update status: %s", err)
+	}
+
+	return nil
+}
+
+// ingressMonitorChanged returns whether or not the IngressMonitor needs to be
+// synced with the provider after an update. Periodic resyncs, changes to the
+// spec and deletions need a sync, changes to only the status don't.
+func ingressMonitorChanged(old, new *v1alpha1.IngressMonitor) bool {
+	if old.ResourceVersion == new.ResourceVersion {
+		return true
+	}
+
+	if (old.DeletionTimestamp == nil) != (new.DeletionTimestamp == nil) {
+		return true
+	}
+
+	return !reflect.DeepEqual(old.Spec, new.Spec)
+}
+
+// setMonitorStatus fills in the status of a Monitor with the IngressMonitors
+// it manages and the result of the reconciliation.
+func (o *Operator) setMonitorStatus(obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus, err error) {
+	status.ObservedGeneration = obj.Generation
+
+	var pending int
+	imLabels := labels.SelectorFromSet(map[string]string{monitorLabel: obj.Name})
+	cache.ListAllByNamespace(o.imInformer.GetIndexer(), obj.Namespace, imLabels, func(imObj interface{}) {
+		im := imObj.(*v1alpha1.IngressMonitor)
+		status.IngressMonitors = append(status.IngressMonitors, im.Name)
+
+		cond := getCondition(im.Status, v1alpha1.IngressMonitorReady)
+		switch {
+		case cond == nil || cond.Status == v1.ConditionUnknown:
+			pending++
+		case cond.Status == v1.ConditionTrue:
+			status.ReadyIngressMonitors++
+		default:
+			status.FailedIngressMonitors++
+		}
+	})
+	sort.Strings(status.IngressMonitors)
+
+	switch {
+	case err != nil:
+		setMonitorCondition(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonReconcileFailed, err.Error())
+	case status.FailedIngressMonitors > 0:
+		msg := fmt.Sprintf("%d IngressMonitors could not be synced with the provider", status.FailedIngressMonitors)
+		setMonitorCondition(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonIngressMonitorsFailed, msg)
+	case pending > 0:
+		msg := fmt.Sprintf("%d IngressMonitors haven't been synced with the provider yet", pending)
+		setMonitorCondition(status, v1alpha1.MonitorReady, v1.ConditionFalse, reasonIngressMonitorsPending, msg)
+	default:
+		setMonitorCondition(status, v1alpha1.MonitorReady, v1.ConditionTrue, reasonReconciled, "")
+	}
+}
+
+// setMonitorCondition sets the condition of the given type on the Monitor
+// status. The LastTransitionTime is only updated when the status of the
+// condition changes.
+func setMonitorCondition(status *v1alpha1.MonitorStatus, tp v1alpha1.MonitorConditionType, cs v1.ConditionStatus, reason, message string) {
+	cond := v1alpha1.MonitorCondition{
+		Type:               tp,
+		Status:             cs,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+
+	for i, c := range status.Conditions {
+		if c.Type != tp {
+			continue
+		}
+
+		if c.Status == cs {
+			cond.LastTransitionTime = c.LastTransitionTime
+		}
+
+		status.Conditions[i] = cond
+		return
+	}
+
+	status.Conditions = append(status.Conditions, cond)
+}
+
+// updateMonitorStatus writes the status to the Monitor through the status
+// subresource. The status is only written when it has changed.
+func (o *Operator) updateMonitorStatus(obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus) error {
+	if reflect.DeepEqual(obj.Status, *status) {
+		return nil
+	}
+
+	obj.Status = *status
+	if _, err := o.imClient.Monitors(obj.Namespace).UpdateStatus(context.TODO(), obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Could not update status: %s", err)
+	}
+
+	return nil
+}
+
+// monitorChanged returns whether or not the Monitor needs to be reconciled
+// after an update. Periodic resyncs and changes to the spec need a reconcile,
+// changes to only the status don't.
+func monitorChanged(old, new *v1alpha1.Monitor) bool {
+	if old.ResourceVersion == new.ResourceVersion {
+		return true
+	}
+
+	return !reflect.DeepEqual(old.Spec, new.Spec)
+}
+
+// readyChanged returns whether or not the Ready condition of the IngressMonitor
+// has changed.
+func readyChanged(old, new *v1alpha1.IngressMonitor) bool {
+	oldCond := getCondition(old.Status, v1alpha1.IngressMonitorReady)
+	newCond := getCondition(new.Status, v1alpha1.IngressMonitorReady)
+
+	if oldCond == nil || newCond == nil {
+		return oldCond != newCond
+	}
+
+	return oldCond.Status != newCond.Status
+}
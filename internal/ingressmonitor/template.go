@@ -0,0 +1,181 @@
+package ingressmonitor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// templateData is the data which is available to the templated fields of a
+// MonitorTemplate.
+type templateData struct {
+	// Name and Namespace are the name and namespace of the selected object.
+	Name      string
+	Namespace string
+
+	// IngressName and IngressNamespace are kept for templates which were
+	// written before other objects than Ingresses could be selected.
+	IngressName      string
+	IngressNamespace string
+
+	// Host, Path and Scheme describe the URL which is checked.
+	Host   string
+	Path   string
+	Scheme string
+
+	// Labels and Annotations are the labels and annotations of the selected
+	// object.
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// Monitor and Provider are the names of the Monitor which sets up the
+	// check and the Provider it's set up with.
+	Monitor  string
+	Provider string
+}
+
+// newTemplateData returns the template data for the given target of the
+// Monitor and the provider the check is set up with.
+func newTemplateData(target monitorTarget, mon *v1alpha1.Monitor, provider string) templateData {
+	return templateData{
+		Name:             target.owner.GetName(),
+		Namespace:        target.owner.GetNamespace(),
+		IngressName:      target.owner.GetName(),
+		IngressNamespace: target.owner.GetNamespace(),
+		Host:             target.host,
+		Path:             target.path,
+		Scheme:           target.scheme,
+		Labels:           nonNilMap(target.owner.GetLabels()),
+		Annotations:      nonNilMap(target.owner.GetAnnotations()),
+		Monitor:          mon.Name,
+		Provider:         provider,
+	}
+}
+
+// templateFuncs are the functions which can be used in the templated fields.
+// The value the function works on comes last so they can be used in
+// pipelines, like `{{.Host | replace "." "-"}}`.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"replace": func(old, new, s string) string {
+		return strings.Replace(s, old, new, -1)
+	},
+	"trimSuffix": func(suffix, s string) string {
+		return strings.TrimSuffix(s, suffix)
+	},
+	"default": func(def string, val interface{}) string {
+		if val == nil || val == "" {
+			return def
+		}
+
+		return fmt.Sprint(val)
+	},
+}
+
+// renderTemplateSpec renders all the templated fields of the spec with the
+// given data.
+func renderTemplateSpec(spec *v1alpha1.MonitorTemplateSpec, data templateData) error {
+	for _, field := range templatedFields(spec) {
+		rendered, err := renderTemplate(field.name, *field.value, data)
+		if err != nil {
+			return err
+		}
+
+		*field.value = rendered
+	}
+
+	return nil
+}
+
+type templatedField struct {
+	name  string
+	value *string
+}
+
+// templatedFields returns the fields of the spec which support templating.
+func templatedFields(spec *v1alpha1.MonitorTemplateSpec) []templatedField {
+	fields := []templatedField{
+		{"name", &spec.Name},
+	}
+
+	if spec.HTTP != nil {
+		if spec.HTTP.Endpoint != nil {
+			fields = append(fields, templatedField{"http.endpoint", spec.HTTP.Endpoint})
+		}
+
+		fields = append(fields,
+			templatedField{"http.customHeader", &spec.HTTP.CustomHeader},
+			templatedField{"http.userAgent", &spec.HTTP.UserAgent},
+			templatedField{"http.shouldContain", &spec.HTTP.ShouldContain},
+			templatedField{"http.shouldNotContain", &spec.HTTP.ShouldNotContain},
+		)
+	}
+
+	return fields
+}
+
+// renderTemplate renders a single templated field. The fields are rendered as
+// plain text, referencing a label or annotation the object doesn't have is an
+// error. Optional values can be looked up with `index`, which doesn't fail on
+// missing keys.
+func renderTemplate(name, text string, data templateData) (string, error) {
+	return executeTemplate(name, text, "missingkey=error", data)
+}
+
+// sampleTemplateData is used to validate templates before there is an object
+// to render them for.
+var sampleTemplateData = templateData{
+	Name:             "example",
+	Namespace:        "default",
+	IngressName:      "example",
+	IngressNamespace: "default",
+	Host:             "example.com",
+	Path:             "/",
+	Scheme:           "https",
+	Labels:           map[string]string{},
+	Annotations:      map[string]string{},
+	Monitor:          "example",
+	Provider:         "example",
+}
+
+// ValidateTemplateSpec validates that all templated fields of the spec can be
+// rendered. This catches syntax errors, unknown functions and unknown values.
+// Labels and annotations are only known once an object is selected, so
+// missing keys aren't reported.
+func ValidateTemplateSpec(spec v1alpha1.MonitorTemplateSpec) error {
+	// Work on a copy, templatedFields hands out pointers into the spec.
+	spec = *spec.DeepCopy()
+
+	for _, field := range templatedFields(&spec) {
+		if _, err := executeTemplate(field.name, *field.value, "missingkey=zero", sampleTemplateData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func executeTemplate(name, text, missingKey string, data templateData) (string, error) {
+	tpl, err := template.New(name).Option(missingKey).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Could not parse %s: %s", name, err)
+	}
+
+	buf := bytes.NewBufferString("")
+	if err := tpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("Could not render %s: %s", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return m
+}
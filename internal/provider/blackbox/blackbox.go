@@ -0,0 +1,595 @@
+package blackbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultConfigMap is the name of the ConfigMap the modules and targets
+	// are written to when the provider doesn't configure one.
+	defaultConfigMap = "ingress-monitor-blackbox"
+
+	// configKey is the key of the blackbox exporter configuration in the
+	// ConfigMap.
+	configKey = "blackbox.yml"
+
+	// nameLabel is the label which holds the name of the check on the
+	// probed targets.
+	nameLabel = "monitor_name"
+)
+
+// probeResource is the Prometheus Operator Probe, which is managed through the
+// dynamic client.
+var probeResource = schema.GroupVersionResource{
+	Group:    "monitoring.coreos.com",
+	Version:  "v1",
+	Resource: "probes",
+}
+
+// managedLabels are set on the objects the provider manages.
+var managedLabels = map[string]string{"app.kubernetes.io/managed-by": "ingress-monitor"}
+
+// errMissingProber is returned when a Provider renders Probes without
+// configuring the blackbox exporter they're sent to.
+var errMissingProber = errors.New("blackbox.proberURL is required when the output is set to `Probe`")
+
+// Register registers the provider with a certain factory. Probes are managed
+// through the given dynamic client.
+func Register(fact provider.FactoryInterface, dyn dynamic.Interface) {
+	fact.Register("Blackbox", NewFactoryFunc(dyn))
+	fact.RegisterValidator("Blackbox", Validate)
+}
+
+// Validate validates the Blackbox configuration of a Provider. The
+// configuration is optional, all its fields have defaults.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.Blackbox == nil {
+		return nil
+	}
+
+	cfg := withDefaults(spec.Blackbox)
+	switch cfg.Output {
+	case v1alpha1.BlackboxOutputConfigMap:
+	case v1alpha1.BlackboxOutputProbe:
+		if cfg.ProberURL == "" {
+			return errMissingProber
+		}
+	default:
+		return fmt.Errorf("blackbox.output '%s' is invalid, expected `ConfigMap` or `Probe`", cfg.Output)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(cfg.ConfigMap); len(errs) > 0 {
+		return fmt.Errorf("blackbox.configMap '%s' is invalid: %s", cfg.ConfigMap, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// NewFactoryFunc returns the function which will allow us to create clients on
+// the fly which write the probe targets to the cluster.
+func NewFactoryFunc(dyn dynamic.Interface) provider.FactoryFunc {
+	return func(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
+		if err := Validate(prov.ProviderSpec); err != nil {
+			return nil, err
+		}
+
+		return newClient(k8sClient, dyn, prov.Namespace, withDefaults(prov.Blackbox)), nil
+	}
+}
+
+// withDefaults returns a copy of the configuration with the defaults filled
+// in.
+func withDefaults(cfg *v1alpha1.BlackboxProvider) v1alpha1.BlackboxProvider {
+	var out v1alpha1.BlackboxProvider
+	if cfg != nil {
+		out = *cfg.DeepCopy()
+	}
+
+	if out.Output == "" {
+		out.Output = v1alpha1.BlackboxOutputConfigMap
+	}
+
+	if out.ConfigMap == "" {
+		out.ConfigMap = defaultConfigMap
+	}
+
+	return out
+}
+
+// Client renders checks into blackbox exporter modules and probe targets. The
+// modules are written to the ConfigMap of the provider, the targets are
+// either written to that same ConfigMap as file_sd targets or set up as
+// Prometheus Operator Probes. The ID of a check is the name of its module.
+type Client struct {
+	kube      kubernetes.Interface
+	dyn       dynamic.Interface
+	namespace string
+	cfg       v1alpha1.BlackboxProvider
+}
+
+func newClient(kube kubernetes.Interface, dyn dynamic.Interface, namespace string, cfg v1alpha1.BlackboxProvider) *Client {
+	return &Client{
+		kube:      kube,
+		dyn:       dyn,
+		namespace: namespace,
+		cfg:       cfg,
+	}
+}
+
+// config is the blackbox exporter configuration. Modules which aren't managed
+// by the provider are kept as they are.
+type config struct {
+	Modules map[string]json.RawMessage `json:"modules"`
+}
+
+// module is a blackbox exporter module, limited to the settings the provider
+// configures.
+type module struct {
+	Prober  string     `json:"prober"`
+	Timeout string     `json:"timeout,omitempty"`
+	HTTP    *httpProbe `json:"http,omitempty"`
+}
+
+type httpProbe struct {
+	Headers                    map[string]string `json:"headers,omitempty"`
+	FollowRedirects            bool              `json:"follow_redirects"`
+	FailIfBodyMatchesRegexp    []string          `json:"fail_if_body_matches_regexp,omitempty"`
+	FailIfBodyNotMatchesRegexp []string          `json:"fail_if_body_not_matches_regexp,omitempty"`
+	TLSConfig                  tlsConfig         `json:"tls_config"`
+}
+
+type tlsConfig struct {
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+}
+
+// probe describes how Prometheus probes a target through the blackbox
+// exporter.
+type probe struct {
+	Module module
+
+	Target        string
+	Labels        map[string]string
+	Interval      string
+	ScrapeTimeout string
+}
+
+// targetGroup is a file_sd target group.
+type targetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// probeSpec is the part of the Prometheus Operator Probe spec the provider
+// manages.
+type probeSpec struct {
+	Module        string       `json:"module"`
+	Interval      string       `json:"interval,omitempty"`
+	ScrapeTimeout string       `json:"scrapeTimeout,omitempty"`
+	Prober        prober       `json:"prober"`
+	Targets       probeTargets `json:"targets"`
+}
+
+type prober struct {
+	URL  string `json:"url"`
+	Path string `json:"path"`
+}
+
+type probeTargets struct {
+	StaticConfig staticConfig `json:"staticConfig"`
+}
+
+type staticConfig struct {
+	Static []string          `json:"static"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Create translates the MonitorTemplateSpec and writes its module and target.
+func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	id := newID(spec.Name)
+	if err := c.write(id, spec); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update writes the module and target of the check with the given ID. Modules
+// and targets which have been removed are written again, the ID is kept.
+func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	return id, c.write(id, spec)
+}
+
+// Delete removes the module and target of the check with the given ID.
+func (c *Client) Delete(id string) error {
+	found := false
+
+	if c.cfg.Output == v1alpha1.BlackboxOutputProbe {
+		err := c.dyn.Resource(probeResource).Namespace(c.namespace).Delete(context.TODO(), id, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("Could not delete Probe %s: %s", id, err)
+		}
+
+		found = err == nil
+	}
+
+	err := c.updateConfigMap(func(data map[string]string) error {
+		if _, ok := data[targetsKey(id)]; ok {
+			delete(data, targetsKey(id))
+			found = true
+		}
+
+		cfg, err := parseConfig(data[configKey])
+		if err != nil {
+			return err
+		}
+
+		if _, ok := cfg.Modules[id]; !ok {
+			return nil
+		}
+
+		delete(cfg.Modules, id)
+		found = true
+
+		raw, err := yaml.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		data[configKey] = string(raw)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return provider.ErrMonitorNotFound
+	}
+
+	return nil
+}
+
+// write writes the module and target for the check with the given ID.
+func (c *Client) write(id string, spec v1alpha1.MonitorTemplateSpec) error {
+	prb, err := c.translateSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	rawModule, err := json.Marshal(prb.Module)
+	if err != nil {
+		return err
+	}
+
+	err = c.updateConfigMap(func(data map[string]string) error {
+		cfg, err := parseConfig(data[configKey])
+		if err != nil {
+			return err
+		}
+
+		cfg.Modules[id] = rawModule
+		raw, err := yaml.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		data[configKey] = string(raw)
+
+		// Targets which were written before the provider switched to Probes
+		// are removed.
+		if c.cfg.Output != v1alpha1.BlackboxOutputConfigMap {
+			delete(data, targetsKey(id))
+			return nil
+		}
+
+		targets, err := json.Marshal([]targetGroup{prb.targetGroup(id)})
+		if err != nil {
+			return err
+		}
+		data[targetsKey(id)] = string(targets)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.cfg.Output == v1alpha1.BlackboxOutputProbe {
+		return c.writeProbe(id, prb)
+	}
+
+	return nil
+}
+
+// updateConfigMap applies the given changes to the data of the ConfigMap of
+// the provider, creating it when it doesn't exist yet. The ConfigMap is only
+// written when its data changes, and the changes are retried when the
+// ConfigMap was modified in the meantime.
+func (c *Client) updateConfigMap(change func(map[string]string) error) error {
+	retriable := func(err error) bool {
+		return kerrors.IsConflict(err) || kerrors.IsAlreadyExists(err)
+	}
+
+	client := c.kube.CoreV1().ConfigMaps(c.namespace)
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		cm, err := client.Get(context.TODO(), c.cfg.ConfigMap, metav1.GetOptions{})
+		exists := err == nil
+		if kerrors.IsNotFound(err) {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      c.cfg.ConfigMap,
+					Namespace: c.namespace,
+					Labels:    managedLabels,
+				},
+			}
+		} else if err != nil {
+			return err
+		}
+
+		data := map[string]string{}
+		for k, v := range cm.Data {
+			data[k] = v
+		}
+
+		if err := change(data); err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(data, cm.Data) || (len(data) == 0 && len(cm.Data) == 0) {
+			return nil
+		}
+
+		cm = cm.DeepCopy()
+		cm.Data = data
+		if exists {
+			_, err = client.Update(context.TODO(), cm, metav1.UpdateOptions{})
+		} else {
+			_, err = client.Create(context.TODO(), cm, metav1.CreateOptions{})
+		}
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Could not update ConfigMap %s:%s: %s", c.namespace, c.cfg.ConfigMap, err)
+	}
+
+	return nil
+}
+
+// writeProbe creates or updates the Probe for the check with the given ID.
+// The Probe is only written when it changes.
+func (c *Client) writeProbe(id string, prb *probe) error {
+	spec, err := toUnstructured(probeSpec{
+		Module:        id,
+		Interval:      prb.Interval,
+		ScrapeTimeout: prb.ScrapeTimeout,
+		Prober:        prober{URL: c.cfg.ProberURL, Path: "/probe"},
+		Targets: probeTargets{StaticConfig: staticConfig{
+			Static: []string{prb.Target},
+			Labels: prb.Labels,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]interface{}{}
+	for k, v := range c.cfg.Labels {
+		labels[k] = v
+	}
+	for k, v := range managedLabels {
+		labels[k] = v
+	}
+
+	client := c.dyn.Resource(probeResource).Namespace(c.namespace)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := client.Get(context.TODO(), id, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			obj = &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": probeResource.GroupVersion().String(),
+				"kind":       "Probe",
+				"metadata": map[string]interface{}{
+					"name":      id,
+					"namespace": c.namespace,
+					"labels":    labels,
+				},
+				"spec": spec,
+			}}
+
+			_, err = client.Create(context.TODO(), obj, metav1.CreateOptions{})
+			return err
+		} else if err != nil {
+			return err
+		}
+
+		current, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "labels")
+		if reflect.DeepEqual(obj.Object["spec"], spec) && reflect.DeepEqual(current, labels) {
+			return nil
+		}
+
+		obj = obj.DeepCopy()
+		obj.Object["spec"] = spec
+		if err := unstructured.SetNestedField(obj.Object, labels, "metadata", "labels"); err != nil {
+			return err
+		}
+
+		_, err = client.Update(context.TODO(), obj, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Could not write Probe %s:%s: %s", c.namespace, id, err)
+	}
+
+	return nil
+}
+
+// translateSpec does the actual translation from a MonitorTemplateSpec to a
+// blackbox module and the target which is probed with it.
+func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*probe, error) {
+	prb := &probe{Labels: map[string]string{}}
+	for k, v := range c.cfg.Labels {
+		prb.Labels[k] = v
+	}
+	prb.Labels[nameLabel] = spec.Name
+
+	switch {
+	case spec.Type == v1alpha1.CheckTypeHTTP && spec.HTTP != nil:
+		headers, err := provider.RequestHeaders(spec.HTTP)
+		if err != nil {
+			return nil, err
+		}
+
+		prb.Target = spec.HTTP.URL
+		prb.Module = module{
+			Prober: "http",
+			HTTP: &httpProbe{
+				Headers:         headers,
+				FollowRedirects: spec.HTTP.FollowRedirects,
+				TLSConfig:       tlsConfig{InsecureSkipVerify: !spec.HTTP.VerifyCertificate},
+			},
+		}
+
+		if spec.HTTP.ShouldContain != "" {
+			prb.Module.HTTP.FailIfBodyNotMatchesRegexp = []string{regexp.QuoteMeta(spec.HTTP.ShouldContain)}
+		}
+
+		if spec.HTTP.ShouldNotContain != "" {
+			prb.Module.HTTP.FailIfBodyMatchesRegexp = []string{regexp.QuoteMeta(spec.HTTP.ShouldNotContain)}
+		}
+	case spec.Type == v1alpha1.CheckTypeTCP && spec.TCP != nil:
+		prb.Target = fmt.Sprintf("%s:%d", spec.TCP.Host, spec.TCP.Port)
+		prb.Module = module{Prober: "tcp"}
+	default:
+		return nil, fmt.Errorf("Unsupported check type '%s'", spec.Type)
+	}
+
+	var interval, timeout time.Duration
+	if spec.CheckRate != nil {
+		tm, err := time.ParseDuration(*spec.CheckRate)
+		if err != nil {
+			return nil, err
+		}
+
+		interval = tm
+		prb.Interval = promDuration(tm)
+	}
+
+	if spec.Timeout != nil {
+		tm, err := time.ParseDuration(*spec.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		timeout = tm
+		prb.Module.Timeout = promDuration(tm)
+
+		// Prometheus drops targets which time out after the next scrape
+		// should've started.
+		if interval > 0 && timeout > interval {
+			timeout = interval
+		}
+		prb.ScrapeTimeout = promDuration(timeout)
+	}
+
+	return prb, nil
+}
+
+// targetGroup returns the file_sd target group which probes the target with
+// the module of the given ID.
+func (p *probe) targetGroup(id string) targetGroup {
+	labels := map[string]string{"__param_module": id}
+	for k, v := range p.Labels {
+		labels[k] = v
+	}
+
+	if p.Interval != "" {
+		labels["__scrape_interval__"] = p.Interval
+	}
+
+	if p.ScrapeTimeout != "" {
+		labels["__scrape_timeout__"] = p.ScrapeTimeout
+	}
+
+	return targetGroup{Targets: []string{p.Target}, Labels: labels}
+}
+
+func parseConfig(raw string) (*config, error) {
+	cfg := &config{}
+	if err := yaml.Unmarshal([]byte(raw), cfg); err != nil {
+		return nil, fmt.Errorf("Could not parse %s: %s", configKey, err)
+	}
+
+	if cfg.Modules == nil {
+		cfg.Modules = map[string]json.RawMessage{}
+	}
+
+	return cfg, nil
+}
+
+// targetsKey returns the key in the ConfigMap which holds the file_sd targets
+// of the check with the given ID.
+func targetsKey(id string) string {
+	return id + ".json"
+}
+
+// invalidIDChars matches the characters which can't be used in the name of a
+// Probe.
+var invalidIDChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// newID generates an ID for a check with the given name. The ID is used as the
+// name of the module and Probe of the check.
+func newID(name string) string {
+	id := strings.Trim(invalidIDChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(id) > 40 {
+		id = strings.TrimRight(id[:40], "-")
+	}
+
+	if id == "" {
+		id = "check"
+	}
+
+	return id + "-" + utilrand.String(5)
+}
+
+// promDuration formats the duration the way Prometheus parses it.
+func promDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+
+	return strconv.FormatInt(int64(d/time.Millisecond), 10) + "ms"
+}
+
+// toUnstructured converts the object to the types used by unstructured
+// objects.
+func toUnstructured(obj interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{}
+	return out, json.Unmarshal(raw, &out)
+}
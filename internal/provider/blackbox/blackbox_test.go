@@ -0,0 +1,519 @@
+package blackbox
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		name  string
+		cfg   *v1alpha1.BlackboxProvider
+		valid bool
+	}{
+		{"without configuration", nil, true},
+		{"with the defaults", &v1alpha1.BlackboxProvider{}, true},
+		{"with Probes", &v1alpha1.BlackboxProvider{Output: "Probe", ProberURL: "blackbox-exporter:9115"}, true},
+		{"with Probes without a prober", &v1alpha1.BlackboxProvider{Output: "Probe"}, false},
+		{"with an invalid output", &v1alpha1.BlackboxProvider{Output: "Files"}, false},
+		{"with an invalid ConfigMap", &v1alpha1.BlackboxProvider{ConfigMap: "Blackbox Targets"}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(v1alpha1.ProviderSpec{Type: "Blackbox", Blackbox: tc.cfg})
+			if tc.valid && err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestTranslateSpec(t *testing.T) {
+	tcs := []struct {
+		name     string
+		spec     v1alpha1.MonitorTemplateSpec
+		expected *probe
+	}{
+		{
+			"simple HTTPS config",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "api.example.com",
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:               "https://api.example.com/_healthz",
+					VerifyCertificate: true,
+					FollowRedirects:   true,
+				},
+			},
+			&probe{
+				Module: module{
+					Prober: "http",
+					HTTP:   &httpProbe{FollowRedirects: true},
+				},
+				Target: "https://api.example.com/_healthz",
+				Labels: map[string]string{"team": "gophers", nameLabel: "api.example.com"},
+			},
+		},
+		{
+			"HTTP config with timings",
+			v1alpha1.MonitorTemplateSpec{
+				Type:      "HTTP",
+				CheckRate: ptrString("1m"),
+				Timeout:   ptrString("2.5s"),
+				HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			&probe{
+				Module: module{
+					Prober:  "http",
+					Timeout: "2500ms",
+					HTTP:    &httpProbe{TLSConfig: tlsConfig{InsecureSkipVerify: true}},
+				},
+				Target:        "https://api.example.com/",
+				Labels:        map[string]string{"team": "gophers", nameLabel: ""},
+				Interval:      "60s",
+				ScrapeTimeout: "2500ms",
+			},
+		},
+		{
+			"HTTP config with a timeout longer than the check rate",
+			v1alpha1.MonitorTemplateSpec{
+				Type:      "HTTP",
+				CheckRate: ptrString("30s"),
+				Timeout:   ptrString("1m"),
+				HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			&probe{
+				Module: module{
+					Prober:  "http",
+					Timeout: "60s",
+					HTTP:    &httpProbe{TLSConfig: tlsConfig{InsecureSkipVerify: true}},
+				},
+				Target:        "https://api.example.com/",
+				Labels:        map[string]string{"team": "gophers", nameLabel: ""},
+				Interval:      "30s",
+				ScrapeTimeout: "30s",
+			},
+		},
+		{
+			"HTTP config with body matching and headers",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:              "https://api.example.com/",
+					ShouldContain:    `"status": "ok"`,
+					ShouldNotContain: "error (500)",
+					CustomHeader:     "X-Team: gophers",
+					UserAgent:        "ingress-monitor",
+				},
+			},
+			&probe{
+				Module: module{
+					Prober: "http",
+					HTTP: &httpProbe{
+						Headers:                    map[string]string{"X-Team": "gophers", "User-Agent": "ingress-monitor"},
+						FailIfBodyMatchesRegexp:    []string{`error \(500\)`},
+						FailIfBodyNotMatchesRegexp: []string{`"status": "ok"`},
+						TLSConfig:                  tlsConfig{InsecureSkipVerify: true},
+					},
+				},
+				Target: "https://api.example.com/",
+				Labels: map[string]string{"team": "gophers", nameLabel: ""},
+			},
+		},
+		{
+			"TCP config",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "db",
+				Type: "TCP",
+				TCP:  &v1alpha1.TCPTemplate{Host: "db.example.com", Port: 5432},
+			},
+			&probe{
+				Module: module{Prober: "tcp"},
+				Target: "db.example.com:5432",
+				Labels: map[string]string{"team": "gophers", nameLabel: "db"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := newClient(nil, nil, "", withDefaults(&v1alpha1.BlackboxProvider{
+				Labels: map[string]string{"team": "gophers"},
+			}))
+
+			prb, err := cl.translateSpec(tc.spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, prb) {
+				t.Errorf("Expected probe\n%#v\ngot\n%#v", tc.expected, prb)
+			}
+		})
+	}
+
+	errTcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+	}{
+		{"without HTTP config", v1alpha1.MonitorTemplateSpec{Type: "HTTP"}},
+		{"invalid timeout", v1alpha1.MonitorTemplateSpec{Type: "HTTP", Timeout: ptrString("thisisnotvalid"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid check rate", v1alpha1.MonitorTemplateSpec{Type: "HTTP", CheckRate: ptrString("60"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid header", v1alpha1.MonitorTemplateSpec{Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "Custom-Header"}}},
+	}
+
+	for _, tc := range errTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newClient(nil, nil, "", withDefaults(nil)).translateSpec(tc.spec); err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestNewID(t *testing.T) {
+	tcs := []struct {
+		name   string
+		prefix string
+	}{
+		{"api.example.com", "api-example-com-"},
+		{"Website: kuard/Path", "website-kuard-path-"},
+		{"", "check-"},
+		{strings.Repeat("a", 50), strings.Repeat("a", 40) + "-"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			id := newID(tc.name)
+			if !strings.HasPrefix(id, tc.prefix) || len(id) != len(tc.prefix)+5 {
+				t.Errorf("Expected an ID starting with %s, got %s", tc.prefix, id)
+			}
+		})
+	}
+}
+
+func TestClient_ConfigMap(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name:      "api.example.com",
+		Type:      "HTTP",
+		CheckRate: ptrString("30s"),
+		HTTP: &v1alpha1.HTTPTemplate{
+			URL:           "https://api.example.com/_healthz",
+			ShouldContain: "ok",
+		},
+	}
+
+	// A module which isn't managed by the provider, this should be kept.
+	existing := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "targets", Namespace: "monitoring"},
+		Data:       map[string]string{configKey: "modules:\n  icmp:\n    prober: icmp\n"},
+	}
+
+	setup := func(objs ...runtime.Object) (*Client, *k8sfake.Clientset) {
+		kube := k8sfake.NewSimpleClientset(objs...)
+		cfg := withDefaults(&v1alpha1.BlackboxProvider{ConfigMap: "targets"})
+		return newClient(kube, nil, "monitoring", cfg), kube
+	}
+
+	t.Run("creating a check", func(t *testing.T) {
+		cl, kube := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		cm := getConfigMap(t, kube)
+		if cm.Labels["app.kubernetes.io/managed-by"] != "ingress-monitor" {
+			t.Errorf("Expected the ConfigMap to be labelled, got %v", cm.Labels)
+		}
+
+		mod := getModule(t, cm, id)
+		if mod.Prober != "http" || !reflect.DeepEqual(mod.HTTP.FailIfBodyNotMatchesRegexp, []string{"ok"}) {
+			t.Errorf("Expected the module to be written, got %#v", mod)
+		}
+
+		var groups []targetGroup
+		if err := json.Unmarshal([]byte(cm.Data[id+".json"]), &groups); err != nil {
+			t.Fatalf("Expected valid file_sd targets, got %s", err)
+		}
+
+		expected := []targetGroup{{
+			Targets: []string{"https://api.example.com/_healthz"},
+			Labels: map[string]string{
+				"__param_module":      id,
+				"__scrape_interval__": "30s",
+				nameLabel:             "api.example.com",
+			},
+		}}
+		if !reflect.DeepEqual(expected, groups) {
+			t.Errorf("Expected targets\n%#v\ngot\n%#v", expected, groups)
+		}
+	})
+
+	t.Run("updating a check", func(t *testing.T) {
+		cl, kube := setup(existing.DeepCopy())
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.HTTP.ShouldContain = "healthy"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID != id {
+			t.Errorf("Expected the ID to be kept, got %s instead of %s", newID, id)
+		}
+
+		cm := getConfigMap(t, kube)
+		if mod := getModule(t, cm, id); !reflect.DeepEqual(mod.HTTP.FailIfBodyNotMatchesRegexp, []string{"healthy"}) {
+			t.Errorf("Expected the module to be updated, got %#v", mod)
+		}
+
+		if mod := getModule(t, cm, "icmp"); mod.Prober != "icmp" {
+			t.Errorf("Expected the existing module to be kept, got %#v", mod)
+		}
+	})
+
+	t.Run("updating a check without changes", func(t *testing.T) {
+		cl, kube := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		kube.ClearActions()
+		if _, err := cl.Update(id, spec); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		for _, action := range kube.Actions() {
+			if action.GetVerb() != "get" {
+				t.Errorf("Expected the ConfigMap to be left alone, got a %s", action.GetVerb())
+			}
+		}
+	})
+
+	t.Run("deleting a check", func(t *testing.T) {
+		cl, kube := setup(existing.DeepCopy())
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		cm := getConfigMap(t, kube)
+		if _, ok := cm.Data[id+".json"]; ok {
+			t.Errorf("Expected the targets to be removed")
+		}
+
+		cfg, err := parseConfig(cm.Data[configKey])
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, ok := cfg.Modules[id]; ok || len(cfg.Modules) != 1 {
+			t.Errorf("Expected only the module of the check to be removed, got %v", cfg.Modules)
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+
+	t.Run("with an invalid configuration", func(t *testing.T) {
+		cm := existing.DeepCopy()
+		cm.Data[configKey] = "modules: [icmp"
+		cl, _ := setup(cm)
+
+		if _, err := cl.Create(spec); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+}
+
+func TestClient_Probe(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name:      "api.example.com",
+		Type:      "HTTP",
+		CheckRate: ptrString("1m"),
+		Timeout:   ptrString("10s"),
+		HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com/_healthz"},
+	}
+
+	setup := func() (*Client, *k8sfake.Clientset, *dynamicfake.FakeDynamicClient) {
+		kube := k8sfake.NewSimpleClientset()
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			probeResource: "ProbeList",
+		})
+		cfg := withDefaults(&v1alpha1.BlackboxProvider{
+			Output:    "Probe",
+			ConfigMap: "targets",
+			ProberURL: "blackbox-exporter:9115",
+			Labels:    map[string]string{"prometheus": "websites"},
+		})
+
+		return newClient(kube, dyn, "monitoring", cfg), kube, dyn
+	}
+
+	t.Run("creating a check", func(t *testing.T) {
+		cl, kube, dyn := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		obj := getProbe(t, dyn, id)
+		if obj.GetLabels()["prometheus"] != "websites" {
+			t.Errorf("Expected the Probe to be labelled, got %v", obj.GetLabels())
+		}
+
+		expected := map[string]interface{}{
+			"module":        id,
+			"interval":      "60s",
+			"scrapeTimeout": "10s",
+			"prober":        map[string]interface{}{"url": "blackbox-exporter:9115", "path": "/probe"},
+			"targets": map[string]interface{}{
+				"staticConfig": map[string]interface{}{
+					"static": []interface{}{"https://api.example.com/_healthz"},
+					"labels": map[string]interface{}{"prometheus": "websites", nameLabel: "api.example.com"},
+				},
+			},
+		}
+		if !reflect.DeepEqual(expected, obj.Object["spec"]) {
+			t.Errorf("Expected Probe spec\n%#v\ngot\n%#v", expected, obj.Object["spec"])
+		}
+
+		cm := getConfigMap(t, kube)
+		if mod := getModule(t, cm, id); mod.Timeout != "10s" {
+			t.Errorf("Expected the module to be written, got %#v", mod)
+		}
+
+		if _, ok := cm.Data[id+".json"]; ok {
+			t.Errorf("Expected no file_sd targets to be written")
+		}
+	})
+
+	t.Run("updating a check", func(t *testing.T) {
+		cl, _, dyn := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.CheckRate = ptrString("5m")
+
+		if _, err := cl.Update(id, updated); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		interval, _, _ := unstructured.NestedString(getProbe(t, dyn, id).Object, "spec", "interval")
+		if interval != "300s" {
+			t.Errorf("Expected the interval to be updated, got %s", interval)
+		}
+
+		dyn.ClearActions()
+		if _, err := cl.Update(id, updated); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		for _, action := range dyn.Actions() {
+			if action.GetVerb() != "get" {
+				t.Errorf("Expected the Probe to be left alone, got a %s", action.GetVerb())
+			}
+		}
+	})
+
+	t.Run("deleting a check", func(t *testing.T) {
+		cl, _, dyn := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, err := dyn.Resource(probeResource).Namespace("monitoring").Get(context.TODO(), id, metav1.GetOptions{}); err == nil {
+			t.Errorf("Expected the Probe to be deleted")
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+}
+
+func getConfigMap(t *testing.T, kube *k8sfake.Clientset) *v1.ConfigMap {
+	cm, err := kube.CoreV1().ConfigMaps("monitoring").Get(context.TODO(), "targets", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the ConfigMap to exist, got %s", err)
+	}
+
+	return cm
+}
+
+func getModule(t *testing.T, cm *v1.ConfigMap, id string) module {
+	var cfg struct {
+		Modules map[string]module `json:"modules"`
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[configKey]), &cfg); err != nil {
+		t.Fatalf("Expected a valid blackbox configuration, got %s", err)
+	}
+
+	mod, ok := cfg.Modules[id]
+	if !ok {
+		t.Fatalf("Expected module %s to exist", id)
+	}
+
+	return mod
+}
+
+func getProbe(t *testing.T, dyn *dynamicfake.FakeDynamicClient, id string) *unstructured.Unstructured {
+	obj, err := dyn.Resource(probeResource).Namespace("monitoring").Get(context.TODO(), id, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected Probe %s to exist, got %s", id, err)
+	}
+
+	return obj
+}
+
+func ptrString(s string) *string {
+	return &s
+}
@@ -0,0 +1,364 @@
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultSite is the Datadog site which is used when the provider doesn't
+	// configure one.
+	defaultSite = "datadoghq.com"
+
+	// defaultLocation is the location tests run from when the provider
+	// doesn't configure any.
+	defaultLocation = "aws:us-east-1"
+)
+
+// Datadog only allows tests to run every 30 seconds up until once a week.
+const (
+	minTickEvery = 30
+	maxTickEvery = 7 * 24 * 60 * 60
+)
+
+// errMissingConfig is returned when a Datadog Provider doesn't have its
+// `datadog` configuration set.
+var errMissingConfig = errors.New("the datadog configuration is required for Datadog Providers")
+
+// Register registers the provider with a certain factory using the FactoryFunc.
+func Register(fact provider.FactoryInterface) {
+	fact.Register("Datadog", FactoryFunc)
+	fact.RegisterValidator("Datadog", Validate)
+	fact.RegisterCheckTypes("Datadog", v1alpha1.CheckTypeHTTP)
+}
+
+// Validate validates the Datadog configuration of a Provider.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.Datadog == nil {
+		return errMissingConfig
+	}
+
+	for _, handle := range spec.Datadog.Notify {
+		if !strings.HasPrefix(handle, "@") || len(handle) == 1 || strings.ContainsAny(handle, " \t\n") {
+			return fmt.Errorf("datadog.notify contains an invalid handle '%s', expected `@handle`", handle)
+		}
+	}
+
+	if err := provider.ValidateSecretVar("datadog.apiKey", spec.Datadog.APIKey); err != nil {
+		return err
+	}
+
+	return provider.ValidateSecretVar("datadog.appKey", spec.Datadog.AppKey)
+}
+
+// FactoryFunc is the function which will allow us to create clients on the fly
+// which connect to Datadog.
+func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
+	if prov.Datadog == nil {
+		return nil, errMissingConfig
+	}
+
+	apiKey, err := provider.SecretValue(k8sClient, prov.Namespace, prov.Datadog.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	appKey, err := provider.SecretValue(k8sClient, prov.Namespace, prov.Datadog.AppKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient("https://api."+site(*prov.Datadog), apiKey, appKey, *prov.Datadog), nil
+}
+
+// Client talks to the Datadog API. It provides a mapping from a Provider
+// interface to Datadog Synthetics API tests.
+type Client struct {
+	baseURL string
+	apiKey  string
+	appKey  string
+	http    *http.Client
+
+	site      string
+	locations []string
+	tags      []string
+	notify    []string
+}
+
+func newClient(baseURL, apiKey, appKey string, cfg v1alpha1.DatadogProvider) *Client {
+	locations := cfg.Locations
+	if len(locations) == 0 {
+		locations = []string{defaultLocation}
+	}
+
+	return &Client{
+		baseURL:   baseURL,
+		apiKey:    apiKey,
+		appKey:    appKey,
+		http:      &http.Client{Timeout: 30 * time.Second},
+		site:      site(cfg),
+		locations: locations,
+		tags:      cfg.Tags,
+		notify:    cfg.Notify,
+	}
+}
+
+// test is a Datadog Synthetics API test as it's sent to the API.
+type test struct {
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Subtype   string      `json:"subtype"`
+	Status    string      `json:"status"`
+	Message   string      `json:"message"`
+	Locations []string    `json:"locations"`
+	Tags      []string    `json:"tags,omitempty"`
+	Config    testConfig  `json:"config"`
+	Options   testOptions `json:"options"`
+}
+
+type testConfig struct {
+	Request    request     `json:"request"`
+	Assertions []assertion `json:"assertions"`
+}
+
+type request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type assertion struct {
+	Type     string      `json:"type"`
+	Operator string      `json:"operator"`
+	Target   interface{} `json:"target"`
+}
+
+type testOptions struct {
+	TickEvery        int  `json:"tick_every"`
+	FollowRedirects  bool `json:"follow_redirects"`
+	AcceptSelfSigned bool `json:"accept_self_signed"`
+}
+
+type testResponse struct {
+	PublicID string `json:"public_id"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// Create translates the MonitorTemplateSpec and creates a new API test with
+// Datadog.
+func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	tst, err := c.translateSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	var resp testResponse
+	if err := c.do(http.MethodPost, "/api/v1/synthetics/tests/api", tst, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.PublicID, nil
+}
+
+// Delete deletes the API test which is linked to the given ID from Datadog.
+func (c *Client) Delete(id string) error {
+	body := map[string][]string{"public_ids": {id}}
+	return c.do(http.MethodPost, "/api/v1/synthetics/tests/delete", body, nil)
+}
+
+// Update updates the API test linked to the given ID with the new
+// configuration. When the test has been removed from Datadog, a new test is
+// created.
+func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	tst, err := c.translateSpec(spec)
+	if err != nil {
+		return id, err
+	}
+
+	err = c.do(http.MethodPut, "/api/v1/synthetics/tests/api/"+id, tst, nil)
+	if err == provider.ErrMonitorNotFound {
+		return c.Create(spec)
+	}
+
+	return id, err
+}
+
+// URL returns the Datadog page where the API test linked to the given ID can
+// be inspected.
+func (c *Client) URL(id string) string {
+	return fmt.Sprintf("https://app.%s/synthetics/details/%s", c.site, id)
+}
+
+// do sends a request to the Datadog API and decodes the response into out.
+// A 404 response is returned as provider.ErrMonitorNotFound.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("DD-API-KEY", c.apiKey)
+	req.Header.Set("DD-APPLICATION-KEY", c.appKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("Could not send request to Datadog: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return provider.ErrMonitorNotFound
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && len(apiErr.Errors) > 0 {
+			return fmt.Errorf("Datadog returned %d: %s", resp.StatusCode, strings.Join(apiErr.Errors, ", "))
+		}
+
+		return fmt.Errorf("Datadog returned %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("Could not decode Datadog response: %s", err)
+	}
+
+	return nil
+}
+
+// translateSpec does the actual translation from a MonitorTemplateSpec to a
+// Datadog API test.
+func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*test, error) {
+	if spec.Type != v1alpha1.CheckTypeHTTP || spec.HTTP == nil {
+		return nil, fmt.Errorf("Datadog only supports HTTP checks, got '%s'", spec.Type)
+	}
+
+	headers, err := provider.RequestHeaders(spec.HTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	tst := &test{
+		Name:      spec.Name,
+		Type:      "api",
+		Subtype:   "http",
+		Status:    "live",
+		Message:   c.message(spec.Name),
+		Locations: c.locations,
+		Tags:      c.tags,
+		Config: testConfig{
+			Request: request{
+				Method:  http.MethodGet,
+				URL:     spec.HTTP.URL,
+				Headers: headers,
+			},
+			Assertions: []assertion{
+				{Type: "statusCode", Operator: "is", Target: http.StatusOK},
+			},
+		},
+		Options: testOptions{
+			TickEvery:        60,
+			FollowRedirects:  spec.HTTP.FollowRedirects,
+			AcceptSelfSigned: !spec.HTTP.VerifyCertificate,
+		},
+	}
+
+	if spec.HTTP.ShouldContain != "" {
+		tst.Config.Assertions = append(tst.Config.Assertions, assertion{
+			Type: "body", Operator: "contains", Target: spec.HTTP.ShouldContain,
+		})
+	}
+
+	if spec.HTTP.ShouldNotContain != "" {
+		tst.Config.Assertions = append(tst.Config.Assertions, assertion{
+			Type: "body", Operator: "doesNotContain", Target: spec.HTTP.ShouldNotContain,
+		})
+	}
+
+	if spec.Timeout != nil {
+		tm, err := time.ParseDuration(*spec.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		tst.Config.Assertions = append(tst.Config.Assertions, assertion{
+			Type: "responseTime", Operator: "lessThan", Target: int(tm / time.Millisecond),
+		})
+	}
+
+	if spec.CheckRate != nil {
+		tm, err := time.ParseDuration(*spec.CheckRate)
+		if err != nil {
+			return nil, err
+		}
+
+		tst.Options.TickEvery = tickEvery(tm)
+	}
+
+	return tst, nil
+}
+
+// message returns the notification message of the test with the given name,
+// mentioning the handles which are notified.
+func (c *Client) message(name string) string {
+	msg := fmt.Sprintf("%s is failing.", name)
+	if len(c.notify) == 0 {
+		return msg
+	}
+
+	return msg + " " + strings.Join(c.notify, " ")
+}
+
+// tickEvery returns the interval between tests in seconds, within the bounds
+// Datadog supports.
+func tickEvery(rate time.Duration) int {
+	secs := int(rate / time.Second)
+	switch {
+	case secs < minTickEvery:
+		return minTickEvery
+	case secs > maxTickEvery:
+		return maxTickEvery
+	}
+
+	return secs
+}
+
+// site returns the Datadog site of the provider.
+func site(cfg v1alpha1.DatadogProvider) string {
+	if cfg.Site == "" {
+		return defaultSite
+	}
+
+	return cfg.Site
+}
@@ -0,0 +1,459 @@
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestValidate(t *testing.T) {
+	keys := func(cfg v1alpha1.DatadogProvider) *v1alpha1.DatadogProvider {
+		cfg.APIKey = v1alpha1.SecretVar{Value: ptrString("api-key")}
+		cfg.AppKey = v1alpha1.SecretVar{Value: ptrString("app-key")}
+		return &cfg
+	}
+
+	tcs := []struct {
+		name  string
+		cfg   *v1alpha1.DatadogProvider
+		valid bool
+	}{
+		{"with keys", keys(v1alpha1.DatadogProvider{}), true},
+		{"with notification handles", keys(v1alpha1.DatadogProvider{Notify: []string{"@slack-ops", "@ops@example.com"}}), true},
+		{"with an invalid handle", keys(v1alpha1.DatadogProvider{Notify: []string{"slack-ops"}}), false},
+		{"with an empty handle", keys(v1alpha1.DatadogProvider{Notify: []string{"@"}}), false},
+		{"without an app key", &v1alpha1.DatadogProvider{APIKey: v1alpha1.SecretVar{Value: ptrString("api-key")}}, false},
+		{"without an API key", &v1alpha1.DatadogProvider{AppKey: v1alpha1.SecretVar{Value: ptrString("app-key")}}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(v1alpha1.ProviderSpec{Type: "Datadog", Datadog: tc.cfg})
+			if tc.valid && err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+
+	t.Run("without configuration", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{Type: "Datadog"})
+		if err != errMissingConfig {
+			t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+		}
+	})
+}
+
+func TestFactoryFunc_MissingConfig(t *testing.T) {
+	_, err := FactoryFunc(nil, v1alpha1.NamespacedProvider{
+		ProviderSpec: v1alpha1.ProviderSpec{Type: "Datadog"},
+	})
+	if err != errMissingConfig {
+		t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+	}
+}
+
+func TestTranslateSpec(t *testing.T) {
+	statusOK := assertion{Type: "statusCode", Operator: "is", Target: 200}
+
+	tcs := []struct {
+		name     string
+		spec     v1alpha1.MonitorTemplateSpec
+		cfg      v1alpha1.DatadogProvider
+		expected *test
+	}{
+		{
+			"simple HTTPS config",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "api.example.com",
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:               "https://api.example.com/_healthz",
+					VerifyCertificate: true,
+					FollowRedirects:   true,
+				},
+			},
+			v1alpha1.DatadogProvider{},
+			&test{
+				Name:      "api.example.com",
+				Type:      "api",
+				Subtype:   "http",
+				Status:    "live",
+				Message:   "api.example.com is failing.",
+				Locations: []string{"aws:us-east-1"},
+				Config: testConfig{
+					Request:    request{Method: "GET", URL: "https://api.example.com/_healthz"},
+					Assertions: []assertion{statusOK},
+				},
+				Options: testOptions{TickEvery: 60, FollowRedirects: true},
+			},
+		},
+		{
+			"HTTP config with assertions",
+			v1alpha1.MonitorTemplateSpec{
+				Name:      "api.example.com",
+				Type:      "HTTP",
+				CheckRate: ptrString("5m"),
+				Timeout:   ptrString("2.5s"),
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:              "https://api.example.com/",
+					ShouldContain:    "ok",
+					ShouldNotContain: "error",
+					CustomHeader:     "X-Team: gophers",
+				},
+			},
+			v1alpha1.DatadogProvider{},
+			&test{
+				Name:      "api.example.com",
+				Type:      "api",
+				Subtype:   "http",
+				Status:    "live",
+				Message:   "api.example.com is failing.",
+				Locations: []string{"aws:us-east-1"},
+				Config: testConfig{
+					Request: request{
+						Method:  "GET",
+						URL:     "https://api.example.com/",
+						Headers: map[string]string{"X-Team": "gophers"},
+					},
+					Assertions: []assertion{
+						statusOK,
+						{Type: "body", Operator: "contains", Target: "ok"},
+						{Type: "body", Operator: "doesNotContain", Target: "error"},
+						{Type: "responseTime", Operator: "lessThan", Target: 2500},
+					},
+				},
+				Options: testOptions{TickEvery: 300, AcceptSelfSigned: true},
+			},
+		},
+		{
+			"HTTP config with locations, tags and notifications",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "api.example.com",
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			v1alpha1.DatadogProvider{
+				Locations: []string{"aws:eu-central-1", "aws:eu-west-1"},
+				Tags:      []string{"team:gophers"},
+				Notify:    []string{"@slack-ops", "@pagerduty-websites"},
+			},
+			&test{
+				Name:      "api.example.com",
+				Type:      "api",
+				Subtype:   "http",
+				Status:    "live",
+				Message:   "api.example.com is failing. @slack-ops @pagerduty-websites",
+				Locations: []string{"aws:eu-central-1", "aws:eu-west-1"},
+				Tags:      []string{"team:gophers"},
+				Config: testConfig{
+					Request:    request{Method: "GET", URL: "https://api.example.com/"},
+					Assertions: []assertion{statusOK},
+				},
+				Options: testOptions{TickEvery: 60, AcceptSelfSigned: true},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := newClient("", "", "", tc.cfg)
+
+			tst, err := cl.translateSpec(tc.spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, tst) {
+				t.Errorf("Expected test\n%#v\ngot\n%#v", tc.expected, tst)
+			}
+		})
+	}
+
+	errTcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+	}{
+		{"TCP config", v1alpha1.MonitorTemplateSpec{Type: "TCP", TCP: &v1alpha1.TCPTemplate{Host: "db.example.com", Port: 5432}}},
+		{"without HTTP config", v1alpha1.MonitorTemplateSpec{Type: "HTTP"}},
+		{"invalid timeout", v1alpha1.MonitorTemplateSpec{Type: "HTTP", Timeout: ptrString("thisisnotvalid"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid check rate", v1alpha1.MonitorTemplateSpec{Type: "HTTP", CheckRate: ptrString("60"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid header", v1alpha1.MonitorTemplateSpec{Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "Custom-Header"}}},
+	}
+
+	for _, tc := range errTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newClient("", "", "", v1alpha1.DatadogProvider{}).translateSpec(tc.spec); err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestTickEvery(t *testing.T) {
+	tcs := []struct {
+		rate     string
+		expected int
+	}{
+		{"10s", 30},
+		{"30s", 30},
+		{"1m", 60},
+		{"90s", 90},
+		{"168h", 604800},
+		{"720h", 604800},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.rate, func(t *testing.T) {
+			spec := v1alpha1.MonitorTemplateSpec{
+				Type:      "HTTP",
+				CheckRate: ptrString(tc.rate),
+				HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com"},
+			}
+
+			tst, err := newClient("", "", "", v1alpha1.DatadogProvider{}).translateSpec(spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if tst.Options.TickEvery != tc.expected {
+				t.Errorf("Expected tick_every %d, got %d", tc.expected, tst.Options.TickEvery)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name:    "api.example.com",
+		Type:    "HTTP",
+		Timeout: ptrString("5s"),
+		HTTP:    &v1alpha1.HTTPTemplate{URL: "https://api.example.com/_healthz"},
+	}
+
+	api := newFakeDatadog("api-key", "app-key")
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	setup := func() (*Client, *fakeDatadog) {
+		api.reset()
+		return newClient(srv.URL, "api-key", "app-key", v1alpha1.DatadogProvider{}), api
+	}
+
+	t.Run("creating a test", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		tst, ok := api.get(id)
+		if !ok {
+			t.Fatalf("Expected test %s to be created", id)
+		}
+
+		if tst["type"] != "api" || tst["subtype"] != "http" || tst["status"] != "live" {
+			t.Errorf("Expected an HTTP API test, got %v", tst)
+		}
+
+		assertions := tst["config"].(map[string]interface{})["assertions"].([]interface{})
+		if len(assertions) != 2 {
+			t.Errorf("Expected a status code and response time assertion, got %v", assertions)
+		}
+	})
+
+	t.Run("updating a test", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.Name = "renamed"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID != id {
+			t.Errorf("Expected the ID to be kept, got %s instead of %s", newID, id)
+		}
+
+		tst, _ := api.get(id)
+		if tst["name"] != "renamed" {
+			t.Errorf("Expected the test to be renamed, got %v", tst["name"])
+		}
+	})
+
+	t.Run("updating a test which was removed", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Update("abc-def-ghi", spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id == "abc-def-ghi" {
+			t.Errorf("Expected a new test to be created")
+		}
+
+		if _, ok := api.get(id); !ok {
+			t.Errorf("Expected test %s to be created", id)
+		}
+	})
+
+	t.Run("deleting a test", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, ok := api.get(id); ok {
+			t.Errorf("Expected test %s to be deleted", id)
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+
+	t.Run("with invalid keys", func(t *testing.T) {
+		cl := newClient(srv.URL, "api-key", "wrong-key", v1alpha1.DatadogProvider{})
+		_, err := cl.Create(spec)
+		if err == nil || !strings.Contains(err.Error(), "Forbidden") {
+			t.Errorf("Expected the API error to be returned, got %v", err)
+		}
+	})
+}
+
+func TestClient_URL(t *testing.T) {
+	tcs := []struct {
+		site     string
+		expected string
+	}{
+		{"", "https://app.datadoghq.com/synthetics/details/abc-def-ghi"},
+		{"datadoghq.eu", "https://app.datadoghq.eu/synthetics/details/abc-def-ghi"},
+	}
+
+	for _, tc := range tcs {
+		cl := newClient("", "", "", v1alpha1.DatadogProvider{Site: tc.site})
+
+		if url := cl.URL("abc-def-ghi"); url != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, url)
+		}
+	}
+}
+
+// fakeDatadog is a local stand-in for the Synthetics API test endpoints of the
+// Datadog API.
+type fakeDatadog struct {
+	apiKey string
+	appKey string
+
+	lock   sync.Mutex
+	nextID int
+	tests  map[string]map[string]interface{}
+}
+
+func newFakeDatadog(apiKey, appKey string) *fakeDatadog {
+	return &fakeDatadog{
+		apiKey: apiKey,
+		appKey: appKey,
+		tests:  map[string]map[string]interface{}{},
+	}
+}
+
+func (f *fakeDatadog) reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.tests = map[string]map[string]interface{}{}
+}
+
+func (f *fakeDatadog) get(id string) (map[string]interface{}, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	tst, ok := f.tests[id]
+	return tst, ok
+}
+
+func (f *fakeDatadog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("DD-API-KEY") != f.apiKey || r.Header.Get("DD-APPLICATION-KEY") != f.appKey {
+		writeError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	body := map[string]interface{}{}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/synthetics/tests/api/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/synthetics/tests/api":
+		f.nextID++
+		id := fmt.Sprintf("abc-def-%03d", f.nextID)
+		body["public_id"] = id
+		f.tests[id] = body
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPut && f.tests[id] != nil:
+		body["public_id"] = id
+		f.tests[id] = body
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/synthetics/tests/delete":
+		deleted := []map[string]interface{}{}
+		ids, _ := body["public_ids"].([]interface{})
+		for _, id := range ids {
+			if _, ok := f.tests[id.(string)]; ok {
+				delete(f.tests, id.(string))
+				deleted = append(deleted, map[string]interface{}{"public_id": id})
+			}
+		}
+
+		if len(deleted) == 0 {
+			writeError(w, http.StatusNotFound, "Synthetics tests not found")
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"deleted_tests": deleted})
+	default:
+		writeError(w, http.StatusNotFound, "Synthetics test not found")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{msg}})
+}
+
+func ptrString(s string) *string {
+	return &s
+}
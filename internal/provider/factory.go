@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -16,18 +20,29 @@ var ErrProviderNotFound = errors.New("the specified provider can't be found")
 // shoud be used by provider wrappers to allow for creating new clients.
 type FactoryFunc func(kubernetes.Interface, v1alpha1.NamespacedProvider) (Interface, error)
 
+// ValidatorFunc validates the provider specific configuration of a Provider
+// before it's used to set up a client. This is used to reject invalid
+// Providers when they're submitted to the cluster.
+type ValidatorFunc func(v1alpha1.ProviderSpec) error
+
 // FactoryInterface is the interface used for a ProviderFactory. It allows you
 // to fetch providers from a local store and use them to configure monitors.
 type FactoryInterface interface {
 	Register(string, FactoryFunc)
+	RegisterValidator(string, ValidatorFunc)
+	RegisterCheckTypes(string, ...string)
 	From(v1alpha1.NamespacedProvider) (Interface, error)
+	Validate(v1alpha1.ProviderSpec) error
+	ValidateCheckType(string, string) error
 }
 
 // SimpleFactory is a factory object that knows how to get providers.
 type SimpleFactory struct {
-	providers map[string]FactoryFunc
-	lock      sync.RWMutex
-	client    kubernetes.Interface
+	providers  map[string]FactoryFunc
+	validators map[string]ValidatorFunc
+	checkTypes map[string][]string
+	lock       sync.RWMutex
+	client     kubernetes.Interface
 }
 
 // Register registers the given provider with the factory under the given name.
@@ -38,6 +53,104 @@ func (pf *SimpleFactory) Register(name string, ff FactoryFunc) {
 	pf.providers[name] = ff
 }
 
+// RegisterValidator registers a validator for the provider with the given
+// name. Providers without a validator only have their type validated.
+func (pf *SimpleFactory) RegisterValidator(name string, vf ValidatorFunc) {
+	pf.lock.Lock()
+	defer pf.lock.Unlock()
+
+	pf.validators[name] = vf
+}
+
+// RegisterCheckTypes registers the types of checks the provider with the given
+// name supports. Providers without registered check types support all of
+// them.
+func (pf *SimpleFactory) RegisterCheckTypes(name string, types ...string) {
+	pf.lock.Lock()
+	defer pf.lock.Unlock()
+
+	pf.checkTypes[name] = types
+}
+
+// ValidateCheckType validates that the provider with the given name supports
+// the given type of check. This is used to reject checks a provider can't set
+// up before they're synced with it.
+func (pf *SimpleFactory) ValidateCheckType(name, checkType string) error {
+	pf.lock.RLock()
+	defer pf.lock.RUnlock()
+
+	types, ok := pf.checkTypes[name]
+	if !ok {
+		return nil
+	}
+
+	for _, tp := range types {
+		if tp == checkType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s only supports %s checks, got '%s'", name, strings.Join(types, ", "), checkType)
+}
+
+// Validate validates that the provider type is registered with the factory
+// and that its configuration is valid.
+func (pf *SimpleFactory) Validate(spec v1alpha1.ProviderSpec) error {
+	pf.lock.RLock()
+	defer pf.lock.RUnlock()
+
+	if _, ok := pf.providers[spec.Type]; !ok {
+		return ErrProviderNotFound
+	}
+
+	if vf, ok := pf.validators[spec.Type]; ok {
+		return vf(spec)
+	}
+
+	return nil
+}
+
+// ValidateSecretVar validates that the SecretVar either has a value or
+// references a key in a Secret.
+func ValidateSecretVar(name string, sv v1alpha1.SecretVar) error {
+	switch {
+	case sv.Value != nil && sv.ValueFrom != nil:
+		return fmt.Errorf("%s can't have both a value and valueFrom", name)
+	case sv.Value != nil:
+		return nil
+	case sv.ValueFrom == nil:
+		return fmt.Errorf("%s requires a value or valueFrom", name)
+	case sv.ValueFrom.Name == "" || sv.ValueFrom.Key == "":
+		return fmt.Errorf("%s requires the name and key of the Secret", name)
+	}
+
+	return nil
+}
+
+// SecretValue returns the value of the SecretVar. A value which references a
+// Secret is looked up in the given namespace.
+func SecretValue(cl kubernetes.Interface, ns string, env v1alpha1.SecretVar) (string, error) {
+	if env.Value != nil {
+		return *env.Value, nil
+	}
+
+	if env.ValueFrom == nil {
+		return "", fmt.Errorf("No value or valueFrom configured")
+	}
+
+	secret, err := cl.CoreV1().Secrets(ns).Get(context.TODO(), env.ValueFrom.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	data, ok := secret.Data[env.ValueFrom.Key]
+	if !ok {
+		return "", fmt.Errorf("Secret %s for `%s` not found", env.ValueFrom.Key, env.ValueFrom.Name)
+	}
+
+	return string(data), nil
+}
+
 // From creates a new provider from the given configuration. This can then be
 // used to register the provider within the
 func (pf *SimpleFactory) From(prov v1alpha1.NamespacedProvider) (Interface, error) {
@@ -56,7 +169,9 @@ func (pf *SimpleFactory) From(prov v1alpha1.NamespacedProvider) (Interface, erro
 // Providers and create clients for them.
 func NewFactory(client kubernetes.Interface) *SimpleFactory {
 	return &SimpleFactory{
-		client:    client,
-		providers: map[string]FactoryFunc{},
+		client:     client,
+		providers:  map[string]FactoryFunc{},
+		validators: map[string]ValidatorFunc{},
+		checkTypes: map[string][]string{},
 	}
 }
@@ -1,12 +1,17 @@
 package provider_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestProviderFactory(t *testing.T) {
@@ -51,3 +56,196 @@ func TestProviderFactory(t *testing.T) {
 		}
 	})
 }
+
+func TestProviderFactory_Validate(t *testing.T) {
+	fact := provider.NewFactory(nil)
+	fact.Register("simple", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.Register("validated", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.RegisterValidator("validated", func(spec v1alpha1.ProviderSpec) error {
+		return errors.New("invalid configuration")
+	})
+
+	t.Run("without registered provider", func(t *testing.T) {
+		err := fact.Validate(v1alpha1.ProviderSpec{Type: "unknown"})
+		if err != provider.ErrProviderNotFound {
+			t.Errorf("Expected error `%s`, got `%s`", provider.ErrProviderNotFound, err)
+		}
+	})
+
+	t.Run("without validator", func(t *testing.T) {
+		if err := fact.Validate(v1alpha1.ProviderSpec{Type: "simple"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("with validator", func(t *testing.T) {
+		if err := fact.Validate(v1alpha1.ProviderSpec{Type: "validated"}); err == nil {
+			t.Errorf("Expected the validator error, got none")
+		}
+	})
+}
+
+func TestProviderFactory_ValidateCheckType(t *testing.T) {
+	fact := provider.NewFactory(nil)
+	fact.Register("simple", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.Register("http-only", fake.FactoryFunc(new(fake.SimpleProvider)))
+	fact.RegisterCheckTypes("http-only", v1alpha1.CheckTypeHTTP)
+
+	t.Run("without registered check types", func(t *testing.T) {
+		if err := fact.ValidateCheckType("simple", v1alpha1.CheckTypeTCP); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("with a supported check type", func(t *testing.T) {
+		if err := fact.ValidateCheckType("http-only", v1alpha1.CheckTypeHTTP); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("with an unsupported check type", func(t *testing.T) {
+		err := fact.ValidateCheckType("http-only", v1alpha1.CheckTypeTCP)
+		if err == nil || err.Error() != "http-only only supports HTTP checks, got 'TCP'" {
+			t.Errorf("Expected the check type to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestValidateSecretVar(t *testing.T) {
+	value := "plaintext"
+	ref := &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+		Key:                  "key",
+	}
+
+	tcs := []struct {
+		name  string
+		sv    v1alpha1.SecretVar
+		valid bool
+	}{
+		{"with a value", v1alpha1.SecretVar{Value: &value}, true},
+		{"with a reference", v1alpha1.SecretVar{ValueFrom: ref}, true},
+		{"without anything", v1alpha1.SecretVar{}, false},
+		{"with both", v1alpha1.SecretVar{Value: &value, ValueFrom: ref}, false},
+		{"without a key", v1alpha1.SecretVar{ValueFrom: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+		}}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := provider.ValidateSecretVar("apiKey", tc.sv)
+			if tc.valid && err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestSecretValue(t *testing.T) {
+	t.Run("with plaintext value", func(t *testing.T) {
+		sv := v1alpha1.SecretVar{
+			Value: ptrString("plaintext"),
+		}
+
+		val, err := provider.SecretValue(nil, "", sv)
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		if val != "plaintext" {
+			t.Errorf("Expected secret value to be `plaintext`, got `%s`", val)
+		}
+	})
+
+	t.Run("with reference value", func(t *testing.T) {
+		t.Run("with non existing secret", func(t *testing.T) {
+			k8s := k8sfake.NewSimpleClientset()
+			sv := v1alpha1.SecretVar{
+				ValueFrom: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "non-existing",
+					},
+				},
+			}
+
+			_, err := provider.SecretValue(k8s, "", sv)
+			if err == nil {
+				t.Errorf("Expected error, got none")
+			}
+		})
+
+		t.Run("with existing secret", func(t *testing.T) {
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-secret",
+					Namespace: "testing",
+				},
+				Data: map[string][]byte{
+					"username": []byte("my-username"),
+				},
+			}
+			k8s := k8sfake.NewSimpleClientset(secret)
+
+			t.Run("with non existing key", func(t *testing.T) {
+				sv := v1alpha1.SecretVar{
+					ValueFrom: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "test-secret",
+						},
+						Key: "non-existing",
+					},
+				}
+
+				_, err := provider.SecretValue(k8s, "testing", sv)
+				if err == nil {
+					t.Errorf("Expected error, got none")
+				}
+			})
+
+			t.Run("in the wrong namespace", func(t *testing.T) {
+				sv := v1alpha1.SecretVar{
+					ValueFrom: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "test-secret",
+						},
+						Key: "username",
+					},
+				}
+
+				_, err := provider.SecretValue(k8s, "wrong-namespace", sv)
+				if err == nil {
+					t.Errorf("Expected error, got none")
+				}
+			})
+
+			t.Run("with no errors", func(t *testing.T) {
+				sv := v1alpha1.SecretVar{
+					ValueFrom: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "test-secret",
+						},
+						Key: "username",
+					},
+				}
+
+				value, err := provider.SecretValue(k8s, "testing", sv)
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+
+				if value != "my-username" {
+					t.Errorf("Expected username to be `my-username`, got `%s`", value)
+				}
+			})
+		})
+	})
+}
+
+func ptrString(s string) *string {
+	return &s
+}
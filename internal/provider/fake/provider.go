@@ -16,6 +16,8 @@ type SimpleProvider struct {
 
 	UpdateFunc  func(string, v1alpha1.MonitorTemplateSpec) (string, error)
 	UpdateCount int
+
+	URLFunc func(string) string
 }
 
 // Create calls the specified CreateFunc in the SimpleProvider.
@@ -36,6 +38,16 @@ func (fp *SimpleProvider) Update(id string, im v1alpha1.MonitorTemplateSpec) (st
 	return fp.UpdateFunc(id, im)
 }
 
+// URL calls the specified URLFunc in the SimpleProvider. When no URLFunc is
+// set, an empty string is returned.
+func (fp *SimpleProvider) URL(id string) string {
+	if fp.URLFunc == nil {
+		return ""
+	}
+
+	return fp.URLFunc(id)
+}
+
 // FactoryFunc is used to register the factory in a given test so we can use it
 // to test provider calls.
 func FactoryFunc(sp *SimpleProvider) provider.FactoryFunc {
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// RequestHeaders returns the headers which should be sent along with a HTTP
+// check, keyed by their name. The custom header of the template contains a
+// header per line, formatted as `Name: value`. The user agent is set as the
+// `User-Agent` header.
+func RequestHeaders(tpl *v1alpha1.HTTPTemplate) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, line := range strings.Split(tpl.CustomHeader, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid custom header '%s', expected `Name: value`", line)
+		}
+
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	if tpl.UserAgent != "" {
+		headers["User-Agent"] = tpl.UserAgent
+	}
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	return headers, nil
+}
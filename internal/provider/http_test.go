@@ -0,0 +1,47 @@
+package provider_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestRequestHeaders(t *testing.T) {
+	tcs := []struct {
+		name     string
+		tpl      v1alpha1.HTTPTemplate
+		expected map[string]string
+		err      bool
+	}{
+		{"without headers", v1alpha1.HTTPTemplate{}, nil, false},
+		{
+			"with custom headers",
+			v1alpha1.HTTPTemplate{CustomHeader: "X-Team: gophers\n\nX-Env:production "},
+			map[string]string{"X-Team": "gophers", "X-Env": "production"},
+			false,
+		},
+		{
+			"with a user agent",
+			v1alpha1.HTTPTemplate{CustomHeader: "X-Team: gophers", UserAgent: "ingress-monitor"},
+			map[string]string{"X-Team": "gophers", "User-Agent": "ingress-monitor"},
+			false,
+		},
+		{"with a malformed header", v1alpha1.HTTPTemplate{CustomHeader: "Custom-Header"}, nil, true},
+		{"without a header name", v1alpha1.HTTPTemplate{CustomHeader: ": value"}, nil, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			headers, err := provider.RequestHeaders(&tc.tpl)
+			if (err != nil) != tc.err {
+				t.Fatalf("Expected error to be %t, got %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, headers) {
+				t.Errorf("Expected headers %v, got %v", tc.expected, headers)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package pingdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// apiURL is the base URL of the Pingdom API.
+const apiURL = "https://api.pingdom.com/api/3.1"
+
+// errMissingConfig is returned when a Pingdom Provider doesn't have its
+// `pingdom` configuration set.
+var errMissingConfig = errors.New("the pingdom configuration is required for Pingdom Providers")
+
+// resolutions are the intervals between checks, in minutes, which Pingdom
+// supports.
+var resolutions = []int{1, 5, 15, 30, 60}
+
+// Register registers the provider with a certain factory using the FactoryFunc.
+func Register(fact provider.FactoryInterface) {
+	fact.Register("Pingdom", FactoryFunc)
+	fact.RegisterValidator("Pingdom", Validate)
+}
+
+// Validate validates the Pingdom configuration of a Provider.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.Pingdom == nil {
+		return errMissingConfig
+	}
+
+	return provider.ValidateSecretVar("pingdom.apiToken", spec.Pingdom.APIToken)
+}
+
+// FactoryFunc is the function which will allow us to create clients on the fly
+// which connect to Pingdom.
+func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
+	if prov.Pingdom == nil {
+		return nil, errMissingConfig
+	}
+
+	token, err := provider.SecretValue(k8sClient, prov.Namespace, prov.Pingdom.APIToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(apiURL, token, *prov.Pingdom), nil
+}
+
+// Client talks to the Pingdom API. It provides a mapping from a Provider
+// interface to Pingdom checks.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+
+	integrationIDs []int
+	userIDs        []int
+	tags           []string
+}
+
+func newClient(baseURL, token string, cfg v1alpha1.PingdomProvider) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		token:          token,
+		http:           &http.Client{Timeout: 30 * time.Second},
+		integrationIDs: cfg.IntegrationIDs,
+		userIDs:        cfg.UserIDs,
+		tags:           cfg.Tags,
+	}
+}
+
+// check is a Pingdom HTTP check as it's sent to the API.
+type check struct {
+	Name string `json:"name"`
+	Host string `json:"host"`
+	// Type can only be set when the check is created.
+	Type       string `json:"type,omitempty"`
+	URL        string `json:"url"`
+	Encryption bool   `json:"encryption"`
+	Port       int    `json:"port,omitempty"`
+
+	Resolution               int `json:"resolution,omitempty"`
+	SendNotificationWhenDown int `json:"sendnotificationwhendown,omitempty"`
+	ResponseTimeThreshold    int `json:"responsetime_threshold,omitempty"`
+
+	VerifyCertificate bool `json:"verify_certificate"`
+	// ShouldContain and ShouldNotContain can't be set together, an empty
+	// string clears them on an update.
+	ShouldContain    *string           `json:"shouldcontain,omitempty"`
+	ShouldNotContain *string           `json:"shouldnotcontain,omitempty"`
+	RequestHeaders   map[string]string `json:"requestheaders,omitempty"`
+
+	IntegrationIDs []int  `json:"integrationids,omitempty"`
+	UserIDs        string `json:"userids,omitempty"`
+	Tags           string `json:"tags,omitempty"`
+}
+
+type checkResponse struct {
+	Check struct {
+		ID int `json:"id"`
+	} `json:"check"`
+}
+
+type errorResponse struct {
+	Error struct {
+		StatusCode   int    `json:"statuscode"`
+		StatusDesc   string `json:"statusdesc"`
+		ErrorMessage string `json:"errormessage"`
+	} `json:"error"`
+}
+
+// Create translates the MonitorTemplateSpec and creates a new check with
+// Pingdom.
+func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	chk, err := c.translateSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	chk.Type = "http"
+
+	var resp checkResponse
+	if err := c.do(http.MethodPost, "/checks", chk, &resp); err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(resp.Check.ID), nil
+}
+
+// Delete deletes the check which is linked to the given ID from Pingdom.
+func (c *Client) Delete(id string) error {
+	if _, err := strconv.Atoi(id); err != nil {
+		return err
+	}
+
+	return c.do(http.MethodDelete, "/checks/"+id, nil, nil)
+}
+
+// Update updates the check linked to the given ID with the new configuration.
+// When the check has been removed from Pingdom, a new check is created.
+func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	if _, err := strconv.Atoi(id); err != nil {
+		return id, err
+	}
+
+	chk, err := c.translateSpec(spec)
+	if err != nil {
+		return id, err
+	}
+
+	// Pingdom keeps the values of the fields which are left out of an
+	// update, clear the string the check expects when the template no
+	// longer sets one.
+	if chk.ShouldContain == nil && chk.ShouldNotContain == nil {
+		chk.ShouldContain = new(string)
+	}
+
+	err = c.do(http.MethodPut, "/checks/"+id, chk, nil)
+	if err == provider.ErrMonitorNotFound {
+		return c.Create(spec)
+	}
+
+	return id, err
+}
+
+// URL returns the Pingdom page where the check linked to the given ID can be
+// inspected.
+func (c *Client) URL(id string) string {
+	return fmt.Sprintf("https://my.pingdom.com/app/reports/uptime#check=%s", id)
+}
+
+// do sends a request to the Pingdom API and decodes the response into out.
+// A 404 response is returned as provider.ErrMonitorNotFound.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("Could not send request to Pingdom: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return provider.ErrMonitorNotFound
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error.ErrorMessage != "" {
+			return fmt.Errorf("Pingdom returned %d: %s", resp.StatusCode, apiErr.Error.ErrorMessage)
+		}
+
+		return fmt.Errorf("Pingdom returned %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("Could not decode Pingdom response: %s", err)
+	}
+
+	return nil
+}
+
+// translateSpec does the actual translation from a MonitorTemplateSpec to a
+// Pingdom check.
+func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*check, error) {
+	if spec.Type != v1alpha1.CheckTypeHTTP || spec.HTTP == nil {
+		return nil, fmt.Errorf("Pingdom only supports HTTP checks, got '%s'", spec.Type)
+	}
+
+	u, err := url.Parse(spec.HTTP.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse URL '%s': %s", spec.HTTP.URL, err)
+	}
+
+	chk := &check{
+		Name:              spec.Name,
+		Host:              u.Hostname(),
+		URL:               u.RequestURI(),
+		Encryption:        u.Scheme == "https",
+		VerifyCertificate: spec.HTTP.VerifyCertificate,
+		IntegrationIDs:    c.integrationIDs,
+		UserIDs:           joinInts(c.userIDs),
+		Tags:              strings.Join(c.tags, ","),
+	}
+
+	if port := u.Port(); port != "" {
+		if chk.Port, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("Invalid port in URL '%s': %s", spec.HTTP.URL, err)
+		}
+	}
+
+	// Pingdom doesn't allow both, ShouldNotContain takes precedence like it
+	// does for StatusCake.
+	switch {
+	case spec.HTTP.ShouldNotContain != "":
+		chk.ShouldNotContain = &spec.HTTP.ShouldNotContain
+	case spec.HTTP.ShouldContain != "":
+		chk.ShouldContain = &spec.HTTP.ShouldContain
+	}
+
+	if spec.Timeout != nil {
+		tm, err := time.ParseDuration(*spec.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		chk.ResponseTimeThreshold = int(tm / time.Millisecond)
+	}
+
+	if spec.CheckRate != nil {
+		tm, err := time.ParseDuration(*spec.CheckRate)
+		if err != nil {
+			return nil, err
+		}
+
+		chk.Resolution = resolution(tm)
+	}
+
+	if spec.Confirmations != nil {
+		chk.SendNotificationWhenDown = *spec.Confirmations
+	}
+
+	headers, err := provider.RequestHeaders(spec.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	chk.RequestHeaders = headers
+
+	return chk, nil
+}
+
+// resolution returns the smallest interval Pingdom supports which is at least
+// the given check rate.
+func resolution(rate time.Duration) int {
+	for _, res := range resolutions {
+		if rate <= time.Duration(res)*time.Minute {
+			return res
+		}
+	}
+
+	return resolutions[len(resolutions)-1]
+}
+
+func joinInts(ids []int) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.Itoa(id)
+	}
+
+	return strings.Join(strs, ",")
+}
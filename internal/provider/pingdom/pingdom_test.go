@@ -0,0 +1,551 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("without configuration", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{Type: "Pingdom"})
+		if err != errMissingConfig {
+			t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+		}
+	})
+
+	t.Run("without an API token", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type:    "Pingdom",
+			Pingdom: &v1alpha1.PingdomProvider{},
+		})
+		if err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with an API token", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type: "Pingdom",
+			Pingdom: &v1alpha1.PingdomProvider{
+				APIToken: v1alpha1.SecretVar{Value: ptrString("token")},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
+
+func TestFactoryFunc_MissingConfig(t *testing.T) {
+	_, err := FactoryFunc(nil, v1alpha1.NamespacedProvider{
+		ProviderSpec: v1alpha1.ProviderSpec{Type: "Pingdom"},
+	})
+	if err != errMissingConfig {
+		t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+	}
+}
+
+func TestTranslateSpec(t *testing.T) {
+	tcs := []struct {
+		name     string
+		spec     v1alpha1.MonitorTemplateSpec
+		cfg      v1alpha1.PingdomProvider
+		expected *check
+	}{
+		{
+			"simple HTTPS config",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "api.example.com",
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:               "https://api.example.com/_healthz?full=1",
+					VerifyCertificate: true,
+				},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Name:              "api.example.com",
+				Host:              "api.example.com",
+				URL:               "/_healthz?full=1",
+				Encryption:        true,
+				VerifyCertificate: true,
+			},
+		},
+		{
+			"HTTP config with a port",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{URL: "http://api.example.com:8080"},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Host: "api.example.com",
+				URL:  "/",
+				Port: 8080,
+			},
+		},
+		{
+			"HTTP config with timings",
+			v1alpha1.MonitorTemplateSpec{
+				Type:          "HTTP",
+				CheckRate:     ptrString("3m"),
+				Timeout:       ptrString("2.5s"),
+				Confirmations: ptrInt(3),
+				HTTP:          &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Host:                     "api.example.com",
+				URL:                      "/",
+				Encryption:               true,
+				Resolution:               5,
+				ResponseTimeThreshold:    2500,
+				SendNotificationWhenDown: 3,
+			},
+		},
+		{
+			"HTTP config should contain",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:           "https://api.example.com/",
+					ShouldContain: "ok",
+				},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Host:          "api.example.com",
+				URL:           "/",
+				Encryption:    true,
+				ShouldContain: ptrString("ok"),
+			},
+		},
+		{
+			"HTTP config should not contain",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:              "https://api.example.com/",
+					ShouldContain:    "ok",
+					ShouldNotContain: "error",
+				},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Host:             "api.example.com",
+				URL:              "/",
+				Encryption:       true,
+				ShouldNotContain: ptrString("error"),
+			},
+		},
+		{
+			"HTTP config with headers",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:          "https://api.example.com/",
+					CustomHeader: "X-Team: gophers\nX-Env:production",
+					UserAgent:    "ingress-monitor",
+				},
+			},
+			v1alpha1.PingdomProvider{},
+			&check{
+				Host:       "api.example.com",
+				URL:        "/",
+				Encryption: true,
+				RequestHeaders: map[string]string{
+					"X-Team":     "gophers",
+					"X-Env":      "production",
+					"User-Agent": "ingress-monitor",
+				},
+			},
+		},
+		{
+			"HTTP config with alerting and tags",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			v1alpha1.PingdomProvider{
+				IntegrationIDs: []int{1, 2},
+				UserIDs:        []int{3, 4},
+				Tags:           []string{"gophers", "production"},
+			},
+			&check{
+				Host:           "api.example.com",
+				URL:            "/",
+				Encryption:     true,
+				IntegrationIDs: []int{1, 2},
+				UserIDs:        "3,4",
+				Tags:           "gophers,production",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := newClient("", "", tc.cfg)
+
+			chk, err := cl.translateSpec(tc.spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, chk) {
+				t.Errorf("Expected check\n%#v\ngot\n%#v", tc.expected, chk)
+			}
+		})
+	}
+
+	errTcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+	}{
+		{"TCP config", v1alpha1.MonitorTemplateSpec{Type: "TCP", TCP: &v1alpha1.TCPTemplate{Host: "db.example.com", Port: 5432}}},
+		{"without HTTP config", v1alpha1.MonitorTemplateSpec{Type: "HTTP"}},
+		{"invalid timeout", v1alpha1.MonitorTemplateSpec{Type: "HTTP", Timeout: ptrString("thisisnotvalid"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid check rate", v1alpha1.MonitorTemplateSpec{Type: "HTTP", CheckRate: ptrString("60"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid header", v1alpha1.MonitorTemplateSpec{Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "Custom-Header"}}},
+	}
+
+	for _, tc := range errTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newClient("", "", v1alpha1.PingdomProvider{}).translateSpec(tc.spec); err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestResolution(t *testing.T) {
+	tcs := []struct {
+		rate     string
+		expected int
+	}{
+		{"30s", 1},
+		{"1m", 1},
+		{"90s", 5},
+		{"15m", 15},
+		{"20m", 30},
+		{"2h", 60},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.rate, func(t *testing.T) {
+			spec := v1alpha1.MonitorTemplateSpec{
+				Type:      "HTTP",
+				CheckRate: ptrString(tc.rate),
+				HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com"},
+			}
+
+			chk, err := newClient("", "", v1alpha1.PingdomProvider{}).translateSpec(spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if chk.Resolution != tc.expected {
+				t.Errorf("Expected resolution %d, got %d", tc.expected, chk.Resolution)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name: "api.example.com",
+		Type: "HTTP",
+		HTTP: &v1alpha1.HTTPTemplate{URL: "https://api.example.com/_healthz"},
+	}
+
+	api := newFakePingdom("secret-token")
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	setup := func() (*Client, *fakePingdom) {
+		api.reset()
+		return newClient(srv.URL, "secret-token", v1alpha1.PingdomProvider{}), api
+	}
+
+	t.Run("creating a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		chk, ok := api.get(id)
+		if !ok {
+			t.Fatalf("Expected check %s to be created", id)
+		}
+
+		if chk["type"] != "http" || chk["host"] != "api.example.com" || chk["url"] != "/_healthz" {
+			t.Errorf("Expected the check to be created from the spec, got %v", chk)
+		}
+	})
+
+	t.Run("updating a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.Name = "renamed"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID != id {
+			t.Errorf("Expected the ID to be kept, got %s instead of %s", newID, id)
+		}
+
+		chk, _ := api.get(id)
+		if chk["name"] != "renamed" {
+			t.Errorf("Expected the check to be renamed, got %v", chk["name"])
+		}
+
+		if _, ok := api.lastRequest["type"]; ok {
+			t.Errorf("Expected the type not to be sent with an update")
+		}
+	})
+
+	t.Run("clearing the expected string", func(t *testing.T) {
+		tcs := []struct {
+			name   string
+			update func(*v1alpha1.HTTPTemplate)
+			exp    map[string]interface{}
+		}{
+			{
+				"removing shouldContain",
+				func(http *v1alpha1.HTTPTemplate) { http.ShouldContain = "" },
+				map[string]interface{}{},
+			},
+			{
+				"replacing shouldContain with shouldNotContain",
+				func(http *v1alpha1.HTTPTemplate) { http.ShouldContain, http.ShouldNotContain = "", "error" },
+				map[string]interface{}{"shouldnotcontain": "error"},
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				cl, api := setup()
+
+				withString := *spec.DeepCopy()
+				withString.HTTP.ShouldContain = "ok"
+
+				id, err := cl.Create(withString)
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+
+				updated := *withString.DeepCopy()
+				tc.update(updated.HTTP)
+				if _, err := cl.Update(id, updated); err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+
+				chk, _ := api.get(id)
+				act := map[string]interface{}{}
+				for _, key := range []string{"shouldcontain", "shouldnotcontain"} {
+					if v, ok := chk[key]; ok && v != "" {
+						act[key] = v
+					}
+				}
+
+				if !reflect.DeepEqual(tc.exp, act) {
+					t.Errorf("Expected the check to expect %v, got %v", tc.exp, act)
+				}
+			})
+		}
+	})
+
+	t.Run("updating a check which was removed", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Update("12345", spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id == "12345" {
+			t.Errorf("Expected a new check to be created")
+		}
+
+		if _, ok := api.get(id); !ok {
+			t.Errorf("Expected check %s to be created", id)
+		}
+	})
+
+	t.Run("deleting a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, ok := api.get(id); ok {
+			t.Errorf("Expected check %s to be deleted", id)
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+
+	t.Run("with an invalid ID", func(t *testing.T) {
+		cl, _ := setup()
+
+		if err := cl.Delete("not-a-number"); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with an invalid token", func(t *testing.T) {
+		cl := newClient(srv.URL, "wrong-token", v1alpha1.PingdomProvider{})
+		_, err := cl.Create(spec)
+		if err == nil || !strings.Contains(err.Error(), "Invalid token") {
+			t.Errorf("Expected the API error to be returned, got %v", err)
+		}
+	})
+}
+
+func TestClient_URL(t *testing.T) {
+	cl := newClient("", "", v1alpha1.PingdomProvider{})
+
+	if url := cl.URL("12345"); url != "https://my.pingdom.com/app/reports/uptime#check=12345" {
+		t.Errorf("Expected the URL of the check report, got %s", url)
+	}
+}
+
+// fakePingdom is a local stand-in for the checks endpoints of the Pingdom API.
+// Like Pingdom, an update only changes the fields which are sent and a check
+// expects either the string it should or shouldn't contain.
+type fakePingdom struct {
+	token string
+
+	lock   sync.Mutex
+	nextID int
+	checks map[string]map[string]interface{}
+
+	// lastRequest is the body of the last request which created or updated
+	// a check.
+	lastRequest map[string]interface{}
+}
+
+func newFakePingdom(token string) *fakePingdom {
+	return &fakePingdom{
+		token:  token,
+		nextID: 1000,
+		checks: map[string]map[string]interface{}{},
+	}
+}
+
+func (f *fakePingdom) reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.checks = map[string]map[string]interface{}{}
+	f.lastRequest = nil
+}
+
+func (f *fakePingdom) get(id string) (map[string]interface{}, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	chk, ok := f.checks[id]
+	return chk, ok
+}
+
+func (f *fakePingdom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/checks/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/checks":
+		chk := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&chk); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		f.lastRequest = chk
+		f.nextID++
+		f.checks[strconv.Itoa(f.nextID)] = chk
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"check": map[string]interface{}{"id": f.nextID, "name": chk["name"]},
+		})
+	case r.Method == http.MethodPut && f.checks[id] != nil:
+		chk := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&chk); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		f.lastRequest = chk
+		if chk["shouldcontain"] != nil && chk["shouldnotcontain"] != nil {
+			writeError(w, http.StatusBadRequest, "shouldcontain can't be used together with shouldnotcontain")
+			return
+		}
+
+		for _, pair := range [][2]string{{"shouldcontain", "shouldnotcontain"}, {"shouldnotcontain", "shouldcontain"}} {
+			if _, ok := chk[pair[0]]; ok {
+				delete(f.checks[id], pair[1])
+			}
+		}
+
+		for key, value := range chk {
+			f.checks[id][key] = value
+		}
+		json.NewEncoder(w).Encode(map[string]string{"message": "Modification of check was successful!"})
+	case r.Method == http.MethodDelete && f.checks[id] != nil:
+		delete(f.checks, id)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Deletion of check was successful!"})
+	default:
+		writeError(w, http.StatusNotFound, "Check not found")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"statuscode":   code,
+			"statusdesc":   http.StatusText(code),
+			"errormessage": msg,
+		},
+	})
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
@@ -1,10 +1,26 @@
 package provider
 
-import "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+import (
+	"errors"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// ErrMonitorNotFound is an error which is used by providers to indicate that
+// the monitor linked to the given ID doesn't exist with the provider.
+var ErrMonitorNotFound = errors.New("the monitor can't be found with the provider")
 
 // Interface reflects interface we'll use to speak with Monitoring Providers.
+// Delete should return ErrMonitorNotFound when the monitor has already been
+// removed from the provider.
 type Interface interface {
 	Create(v1alpha1.MonitorTemplateSpec) (string, error)
 	Delete(string) error
 	Update(string, v1alpha1.MonitorTemplateSpec) (string, error)
 }
+
+// URLer is an optional interface which can be implemented by providers that
+// expose a page where the monitor can be inspected.
+type URLer interface {
+	URL(string) string
+}
@@ -1,6 +1,7 @@
 package statuscake
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -8,12 +9,15 @@ import (
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/DreamItGetIT/statuscake"
 )
 
+// errMissingConfig is returned when a StatusCake Provider doesn't have its
+// `statusCake` configuration set.
+var errMissingConfig = errors.New("the statusCake configuration is required for StatusCake Providers")
+
 // The StatusCake Client we're using expects us to set this.
 // XXX remove this once we move to our own internal client.
 const statusCodes = "204,205,206,303,400,401,403,404,405,406,408,410,413,444,429,494,495,496,499,500,501,502,503,504,505,506,507,508,509,510,511,521,522,523,524,520,598,599"
@@ -21,17 +25,35 @@ const statusCodes = "204,205,206,303,400,401,403,404,405,406,408,410,413,444,429
 // Register registers the provider with a certain factory using the FactoryFunc.
 func Register(fact provider.FactoryInterface) {
 	fact.Register("StatusCake", FactoryFunc)
+	fact.RegisterValidator("StatusCake", Validate)
+}
+
+// Validate validates the StatusCake configuration of a Provider.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.StatusCake == nil {
+		return errMissingConfig
+	}
+
+	if err := provider.ValidateSecretVar("statusCake.username", spec.StatusCake.Username); err != nil {
+		return err
+	}
+
+	return provider.ValidateSecretVar("statusCake.apiKey", spec.StatusCake.APIKey)
 }
 
 // FactoryFunc is the function which will allow us to create clients on the fly
 // which connect to StatusCake.
 func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
-	username, err := getSecretValue(k8sClient, prov.Namespace, prov.StatusCake.Username)
+	if prov.StatusCake == nil {
+		return nil, errMissingConfig
+	}
+
+	username, err := provider.SecretValue(k8sClient, prov.Namespace, prov.StatusCake.Username)
 	if err != nil {
 		return nil, err
 	}
 
-	apiKey, err := getSecretValue(k8sClient, prov.Namespace, prov.StatusCake.APIKey)
+	apiKey, err := provider.SecretValue(k8sClient, prov.Namespace, prov.StatusCake.APIKey)
 	if err != nil {
 		return nil, err
 	}
@@ -51,24 +73,6 @@ func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvide
 	}, nil
 }
 
-func getSecretValue(cl kubernetes.Interface, ns string, env v1alpha1.SecretVar) (string, error) {
-	if env.Value != nil {
-		return *env.Value, nil
-	}
-
-	secret, err := cl.Core().Secrets(ns).Get(env.ValueFrom.Name, metav1.GetOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	data, ok := secret.Data[env.ValueFrom.Key]
-	if !ok {
-		return "", fmt.Errorf("Secret %s for `%s` not found", env.ValueFrom.Key, env.ValueFrom.Name)
-	}
-
-	return string(data), nil
-}
-
 type statusCakeClient interface {
 	// The client uses Update for both creation and updating.
 	Update(*statuscake.Test) (*statuscake.Test, error)
@@ -105,7 +109,20 @@ func (c *Client) Delete(id string) error {
 		return err
 	}
 
-	return c.cl.Delete(int(iid))
+	err = c.cl.Delete(int(iid))
+	if err != nil && err.Error() == fmt.Sprintf("No matching key can be found on this account. Given: %s", id) {
+		// XXX see if the API returns a 404 HTTP StatusCode, if so, we should add
+		// our own client to add proper error handling. This will do for now.
+		return provider.ErrMonitorNotFound
+	}
+
+	return err
+}
+
+// URL returns the StatusCake page where the Monitor linked to the given ID
+// can be inspected.
+func (c *Client) URL(id string) string {
+	return fmt.Sprintf("https://app.statuscake.com/AllStatus.php?tid=%s", id)
 }
 
 // Update updates the Monitor linked to the given ID with the new configuration.
@@ -200,5 +217,10 @@ func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*statuscake.T
 		}
 	}
 
+	if tcp := spec.TCP; tcp != nil {
+		scTest.WebsiteURL = tcp.Host
+		scTest.Port = int(tcp.Port)
+	}
+
 	return scTest, nil
 }
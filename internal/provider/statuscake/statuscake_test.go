@@ -7,112 +7,52 @@ import (
 	"testing"
 
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 
 	"github.com/DreamItGetIT/statuscake"
-
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/fake"
 )
 
-func TestGetSecretVar(t *testing.T) {
-	t.Run("with plaintext value", func(t *testing.T) {
-		sv := v1alpha1.SecretVar{
-			Value: ptrString("plaintext"),
-		}
-
-		val, err := getSecretValue(nil, "", sv)
-		if err != nil {
-			t.Errorf("Expected no error, got %s", err)
+func TestValidate(t *testing.T) {
+	t.Run("without configuration", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{Type: "StatusCake"})
+		if err != errMissingConfig {
+			t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
 		}
+	})
 
-		if val != "plaintext" {
-			t.Errorf("Expected secret value to be `plaintext`, got `%s`", val)
+	t.Run("without an API key", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type: "StatusCake",
+			StatusCake: &v1alpha1.StatusCakeProvider{
+				Username: v1alpha1.SecretVar{Value: ptrString("user")},
+			},
+		})
+		if err == nil {
+			t.Errorf("Expected an error, got none")
 		}
 	})
 
-	t.Run("with reference value", func(t *testing.T) {
-		t.Run("with non existing secret", func(t *testing.T) {
-			k8s := fake.NewSimpleClientset()
-			sv := v1alpha1.SecretVar{
-				ValueFrom: &v1.SecretKeySelector{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: "non-existing",
-					},
-				},
-			}
-
-			_, err := getSecretValue(k8s, "", sv)
-			if err == nil {
-				t.Errorf("Expected error, got none")
-			}
+	t.Run("with credentials", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type: "StatusCake",
+			StatusCake: &v1alpha1.StatusCakeProvider{
+				Username: v1alpha1.SecretVar{Value: ptrString("user")},
+				APIKey:   v1alpha1.SecretVar{Value: ptrString("key")},
+			},
 		})
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
 
-		t.Run("with existing secret", func(t *testing.T) {
-			secret := &v1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-secret",
-					Namespace: "testing",
-				},
-				Data: map[string][]byte{
-					"username": []byte("my-username"),
-				},
-			}
-			k8s := fake.NewSimpleClientset(secret)
-
-			t.Run("with non existing key", func(t *testing.T) {
-				sv := v1alpha1.SecretVar{
-					ValueFrom: &v1.SecretKeySelector{
-						LocalObjectReference: v1.LocalObjectReference{
-							Name: "test-secret",
-						},
-						Key: "non-existing",
-					},
-				}
-
-				_, err := getSecretValue(k8s, "testing", sv)
-				if err == nil {
-					t.Errorf("Expected error, got none")
-				}
-			})
-
-			t.Run("in the wrong namespace", func(t *testing.T) {
-				sv := v1alpha1.SecretVar{
-					ValueFrom: &v1.SecretKeySelector{
-						LocalObjectReference: v1.LocalObjectReference{
-							Name: "test-secret",
-						},
-						Key: "username",
-					},
-				}
-
-				_, err := getSecretValue(k8s, "wrong-namespace", sv)
-				if err == nil {
-					t.Errorf("Expected error, got none")
-				}
-			})
-
-			t.Run("with no errors", func(t *testing.T) {
-				sv := v1alpha1.SecretVar{
-					ValueFrom: &v1.SecretKeySelector{
-						LocalObjectReference: v1.LocalObjectReference{
-							Name: "test-secret",
-						},
-						Key: "username",
-					},
-				}
-
-				value, err := getSecretValue(k8s, "testing", sv)
-				if err != nil {
-					t.Fatalf("Expected no error, got %s", err)
-				}
-
-				if value != "my-username" {
-					t.Errorf("Expected username to be `my-username`, got `%s`", value)
-				}
-			})
-		})
+func TestFactoryFunc_MissingConfig(t *testing.T) {
+	_, err := FactoryFunc(nil, v1alpha1.NamespacedProvider{
+		ProviderSpec: v1alpha1.ProviderSpec{Type: "StatusCake"},
 	})
+	if err != errMissingConfig {
+		t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+	}
 }
 
 func TestTranslateSpec(t *testing.T) {
@@ -208,6 +148,22 @@ func TestTranslateSpec(t *testing.T) {
 				ContactGroup:   []string{"12345"},
 			},
 		},
+		{
+			"TCP config",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "TCP",
+				TCP: &v1alpha1.TCPTemplate{
+					Host: "203.0.113.10",
+					Port: 5432,
+				},
+			},
+			nil,
+			&statuscake.Test{
+				TestType:   "TCP",
+				WebsiteURL: "203.0.113.10",
+				Port:       5432,
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -286,9 +242,30 @@ func TestClient_Delete(t *testing.T) {
 				t.Errorf("Expected 1 delete call, got %d", fc.deleteCount)
 			}
 		})
+
+		t.Run("monitor not found", func(t *testing.T) {
+			defer fc.flush()
+
+			fc.deleteFunc = func(i int) error {
+				return errors.New("No matching key can be found on this account. Given: 12345")
+			}
+
+			if err := cl.Delete("12345"); err != provider.ErrMonitorNotFound {
+				t.Errorf("Expected `%s` error, got `%s`", provider.ErrMonitorNotFound, err)
+			}
+		})
 	})
 }
 
+func TestClient_URL(t *testing.T) {
+	cl := &Client{cl: new(fakeClient)}
+
+	exp := "https://app.statuscake.com/AllStatus.php?tid=12345"
+	if url := cl.URL("12345"); url != exp {
+		t.Errorf("Expected URL to be `%s`, got `%s`", exp, url)
+	}
+}
+
 func TestClient_Create(t *testing.T) {
 	fc := new(fakeClient)
 	cl := &Client{cl: fc}
@@ -0,0 +1,346 @@
+package uptimerobot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// apiURL is the base URL of the UptimeRobot v2 API.
+const apiURL = "https://api.uptimerobot.com/v2"
+
+// These are the types of monitors UptimeRobot supports which are used by the
+// provider.
+const (
+	monitorTypeHTTP    = 1
+	monitorTypeKeyword = 2
+)
+
+// UptimeRobot marks a keyword monitor as down when the keyword exists or when
+// it doesn't exist. These are the opposite of ShouldNotContain and
+// ShouldContain.
+const (
+	keywordTypeExists    = 1
+	keywordTypeNotExists = 2
+)
+
+// errMissingConfig is returned when an UptimeRobot Provider doesn't have its
+// `uptimeRobot` configuration set.
+var errMissingConfig = errors.New("the uptimeRobot configuration is required for UptimeRobot Providers")
+
+// Register registers the provider with a certain factory using the FactoryFunc.
+func Register(fact provider.FactoryInterface) {
+	fact.Register("UptimeRobot", FactoryFunc)
+	fact.RegisterValidator("UptimeRobot", Validate)
+}
+
+// Validate validates the UptimeRobot configuration of a Provider.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.UptimeRobot == nil {
+		return errMissingConfig
+	}
+
+	for _, id := range spec.UptimeRobot.AlertContacts {
+		if _, err := strconv.Atoi(id); err != nil {
+			return fmt.Errorf("uptimeRobot.alertContacts contains an invalid ID '%s'", id)
+		}
+	}
+
+	return provider.ValidateSecretVar("uptimeRobot.apiKey", spec.UptimeRobot.APIKey)
+}
+
+// FactoryFunc is the function which will allow us to create clients on the fly
+// which connect to UptimeRobot.
+func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
+	if prov.UptimeRobot == nil {
+		return nil, errMissingConfig
+	}
+
+	apiKey, err := provider.SecretValue(k8sClient, prov.Namespace, prov.UptimeRobot.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(apiURL, apiKey, *prov.UptimeRobot), nil
+}
+
+// Client talks to the UptimeRobot API. It provides a mapping from a Provider
+// interface to UptimeRobot monitors.
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+
+	alertContacts []string
+}
+
+func newClient(baseURL, apiKey string, cfg v1alpha1.UptimeRobotProvider) *Client {
+	return &Client{
+		baseURL:       baseURL,
+		apiKey:        apiKey,
+		http:          &http.Client{Timeout: 30 * time.Second},
+		alertContacts: cfg.AlertContacts,
+	}
+}
+
+// monitor is an UptimeRobot monitor as it's sent to the API.
+type monitor struct {
+	FriendlyName  string
+	URL           string
+	Type          int
+	KeywordType   int
+	KeywordValue  string
+	Interval      int
+	Timeout       int
+	AlertContacts string
+	Headers       map[string]string
+}
+
+// values encodes the monitor as the form values of a request.
+func (m *monitor) values() (url.Values, error) {
+	v := url.Values{}
+	v.Set("friendly_name", m.FriendlyName)
+	v.Set("url", m.URL)
+	v.Set("type", strconv.Itoa(m.Type))
+
+	if m.Type == monitorTypeKeyword {
+		v.Set("keyword_type", strconv.Itoa(m.KeywordType))
+		v.Set("keyword_value", m.KeywordValue)
+	}
+
+	if m.Interval > 0 {
+		v.Set("interval", strconv.Itoa(m.Interval))
+	}
+
+	if m.Timeout > 0 {
+		v.Set("timeout", strconv.Itoa(m.Timeout))
+	}
+
+	if m.AlertContacts != "" {
+		v.Set("alert_contacts", m.AlertContacts)
+	}
+
+	if len(m.Headers) > 0 {
+		headers, err := json.Marshal(m.Headers)
+		if err != nil {
+			return nil, err
+		}
+		v.Set("custom_http_headers", string(headers))
+	}
+
+	return v, nil
+}
+
+type response struct {
+	Stat    string `json:"stat"`
+	Monitor struct {
+		ID int `json:"id"`
+	} `json:"monitor"`
+	Monitors []struct {
+		ID   int `json:"id"`
+		Type int `json:"type"`
+	} `json:"monitors"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Create translates the MonitorTemplateSpec and creates a new monitor with
+// UptimeRobot.
+func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	mon, err := c.translateSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	params, err := mon.values()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do("newMonitor", params)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(resp.Monitor.ID), nil
+}
+
+// Delete deletes the monitor which is linked to the given ID from UptimeRobot.
+func (c *Client) Delete(id string) error {
+	if _, err := strconv.Atoi(id); err != nil {
+		return err
+	}
+
+	_, err := c.do("deleteMonitor", url.Values{"id": {id}})
+	return err
+}
+
+// Update updates the monitor linked to the given ID with the new
+// configuration. UptimeRobot doesn't allow changing the type of a monitor, so
+// the monitor is recreated when it changes between a HTTP and keyword monitor.
+// A monitor which has been removed from UptimeRobot is created again.
+func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	if _, err := strconv.Atoi(id); err != nil {
+		return id, err
+	}
+
+	mon, err := c.translateSpec(spec)
+	if err != nil {
+		return id, err
+	}
+
+	current, err := c.do("getMonitors", url.Values{"monitors": {id}})
+	if err != nil && err != provider.ErrMonitorNotFound {
+		return id, err
+	}
+
+	if err == provider.ErrMonitorNotFound || len(current.Monitors) == 0 {
+		return c.Create(spec)
+	}
+
+	if current.Monitors[0].Type != mon.Type {
+		if err := c.Delete(id); err != nil && err != provider.ErrMonitorNotFound {
+			return id, err
+		}
+
+		return c.Create(spec)
+	}
+
+	params, err := mon.values()
+	if err != nil {
+		return id, err
+	}
+
+	// The type of a monitor can't be edited.
+	params.Del("type")
+	params.Set("id", id)
+
+	_, err = c.do("editMonitor", params)
+	if err == provider.ErrMonitorNotFound {
+		return c.Create(spec)
+	}
+
+	return id, err
+}
+
+// URL returns the UptimeRobot page where the monitor linked to the given ID
+// can be inspected.
+func (c *Client) URL(id string) string {
+	return fmt.Sprintf("https://uptimerobot.com/dashboard#%s", id)
+}
+
+// do calls the given method of the UptimeRobot API. Errors of the `not_found`
+// type are returned as provider.ErrMonitorNotFound.
+func (c *Client) do(method string, params url.Values) (*response, error) {
+	params.Set("api_key", c.apiKey)
+	params.Set("format", "json")
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/"+method, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Could not send request to UptimeRobot: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("UptimeRobot returned %d for %s", resp.StatusCode, method)
+	}
+
+	var out response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("Could not decode UptimeRobot response: %s", err)
+	}
+
+	if out.Stat == "ok" {
+		return &out, nil
+	}
+
+	if out.Error == nil {
+		return nil, fmt.Errorf("UptimeRobot could not %s", method)
+	}
+
+	if out.Error.Type == "not_found" {
+		return nil, provider.ErrMonitorNotFound
+	}
+
+	return nil, fmt.Errorf("UptimeRobot could not %s: %s", method, out.Error.Message)
+}
+
+// translateSpec does the actual translation from a MonitorTemplateSpec to an
+// UptimeRobot monitor. A check which should or shouldn't contain a string
+// becomes a keyword monitor.
+func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*monitor, error) {
+	if spec.Type != v1alpha1.CheckTypeHTTP || spec.HTTP == nil {
+		return nil, fmt.Errorf("UptimeRobot only supports HTTP checks, got '%s'", spec.Type)
+	}
+
+	mon := &monitor{
+		FriendlyName: spec.Name,
+		URL:          spec.HTTP.URL,
+		Type:         monitorTypeHTTP,
+	}
+
+	switch {
+	case spec.HTTP.ShouldNotContain != "":
+		mon.Type = monitorTypeKeyword
+		mon.KeywordType = keywordTypeExists
+		mon.KeywordValue = spec.HTTP.ShouldNotContain
+	case spec.HTTP.ShouldContain != "":
+		mon.Type = monitorTypeKeyword
+		mon.KeywordType = keywordTypeNotExists
+		mon.KeywordValue = spec.HTTP.ShouldContain
+	}
+
+	if spec.CheckRate != nil {
+		tm, err := time.ParseDuration(*spec.CheckRate)
+		if err != nil {
+			return nil, err
+		}
+
+		mon.Interval = int(tm.Seconds())
+	}
+
+	if spec.Timeout != nil {
+		tm, err := time.ParseDuration(*spec.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		mon.Timeout = int(tm.Seconds())
+	}
+
+	// Alert contacts are notified as soon as the monitor goes down, without
+	// recurring notifications.
+	contacts := make([]string, len(c.alertContacts))
+	for i, id := range c.alertContacts {
+		contacts[i] = id + "_0_0"
+	}
+	mon.AlertContacts = strings.Join(contacts, "-")
+
+	headers, err := provider.RequestHeaders(spec.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	mon.Headers = headers
+
+	return mon, nil
+}
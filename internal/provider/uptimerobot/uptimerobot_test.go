@@ -0,0 +1,492 @@
+package uptimerobot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("without configuration", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{Type: "UptimeRobot"})
+		if err != errMissingConfig {
+			t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+		}
+	})
+
+	t.Run("without an API key", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type:        "UptimeRobot",
+			UptimeRobot: &v1alpha1.UptimeRobotProvider{},
+		})
+		if err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with an invalid alert contact", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type: "UptimeRobot",
+			UptimeRobot: &v1alpha1.UptimeRobotProvider{
+				APIKey:        v1alpha1.SecretVar{Value: ptrString("key")},
+				AlertContacts: []string{"123", "ops"},
+			},
+		})
+		if err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with an API key", func(t *testing.T) {
+		err := Validate(v1alpha1.ProviderSpec{
+			Type: "UptimeRobot",
+			UptimeRobot: &v1alpha1.UptimeRobotProvider{
+				APIKey:        v1alpha1.SecretVar{Value: ptrString("key")},
+				AlertContacts: []string{"123"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
+
+func TestFactoryFunc_MissingConfig(t *testing.T) {
+	_, err := FactoryFunc(nil, v1alpha1.NamespacedProvider{
+		ProviderSpec: v1alpha1.ProviderSpec{Type: "UptimeRobot"},
+	})
+	if err != errMissingConfig {
+		t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+	}
+}
+
+func TestTranslateSpec(t *testing.T) {
+	tcs := []struct {
+		name     string
+		spec     v1alpha1.MonitorTemplateSpec
+		cfg      v1alpha1.UptimeRobotProvider
+		expected *monitor
+	}{
+		{
+			"simple HTTPS config",
+			v1alpha1.MonitorTemplateSpec{
+				Name: "api.example.com",
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{URL: "https://api.example.com/_healthz"},
+			},
+			v1alpha1.UptimeRobotProvider{},
+			&monitor{
+				FriendlyName: "api.example.com",
+				URL:          "https://api.example.com/_healthz",
+				Type:         monitorTypeHTTP,
+			},
+		},
+		{
+			"HTTP config with timings",
+			v1alpha1.MonitorTemplateSpec{
+				Type:      "HTTP",
+				CheckRate: ptrString("5m"),
+				Timeout:   ptrString("30s"),
+				HTTP:      &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			v1alpha1.UptimeRobotProvider{},
+			&monitor{
+				URL:      "https://api.example.com/",
+				Type:     monitorTypeHTTP,
+				Interval: 300,
+				Timeout:  30,
+			},
+		},
+		{
+			"HTTP config should contain",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:           "https://api.example.com/",
+					ShouldContain: "ok",
+				},
+			},
+			v1alpha1.UptimeRobotProvider{},
+			&monitor{
+				URL:          "https://api.example.com/",
+				Type:         monitorTypeKeyword,
+				KeywordType:  keywordTypeNotExists,
+				KeywordValue: "ok",
+			},
+		},
+		{
+			"HTTP config should not contain",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:              "https://api.example.com/",
+					ShouldContain:    "ok",
+					ShouldNotContain: "error",
+				},
+			},
+			v1alpha1.UptimeRobotProvider{},
+			&monitor{
+				URL:          "https://api.example.com/",
+				Type:         monitorTypeKeyword,
+				KeywordType:  keywordTypeExists,
+				KeywordValue: "error",
+			},
+		},
+		{
+			"HTTP config with headers",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:          "https://api.example.com/",
+					CustomHeader: "X-Team: gophers",
+					UserAgent:    "ingress-monitor",
+				},
+			},
+			v1alpha1.UptimeRobotProvider{},
+			&monitor{
+				URL:  "https://api.example.com/",
+				Type: monitorTypeHTTP,
+				Headers: map[string]string{
+					"X-Team":     "gophers",
+					"User-Agent": "ingress-monitor",
+				},
+			},
+		},
+		{
+			"HTTP config with alert contacts",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{URL: "https://api.example.com/"},
+			},
+			v1alpha1.UptimeRobotProvider{AlertContacts: []string{"123", "456"}},
+			&monitor{
+				URL:           "https://api.example.com/",
+				Type:          monitorTypeHTTP,
+				AlertContacts: "123_0_0-456_0_0",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := newClient("", "", tc.cfg)
+
+			mon, err := cl.translateSpec(tc.spec)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, mon) {
+				t.Errorf("Expected monitor\n%#v\ngot\n%#v", tc.expected, mon)
+			}
+		})
+	}
+
+	errTcs := []struct {
+		name string
+		spec v1alpha1.MonitorTemplateSpec
+	}{
+		{"TCP config", v1alpha1.MonitorTemplateSpec{Type: "TCP", TCP: &v1alpha1.TCPTemplate{Host: "db.example.com", Port: 5432}}},
+		{"without HTTP config", v1alpha1.MonitorTemplateSpec{Type: "HTTP"}},
+		{"invalid timeout", v1alpha1.MonitorTemplateSpec{Type: "HTTP", Timeout: ptrString("thisisnotvalid"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid check rate", v1alpha1.MonitorTemplateSpec{Type: "HTTP", CheckRate: ptrString("60"), HTTP: &v1alpha1.HTTPTemplate{}}},
+		{"invalid header", v1alpha1.MonitorTemplateSpec{Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{CustomHeader: "Custom-Header"}}},
+	}
+
+	for _, tc := range errTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newClient("", "", v1alpha1.UptimeRobotProvider{}).translateSpec(tc.spec); err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name: "api.example.com",
+		Type: "HTTP",
+		HTTP: &v1alpha1.HTTPTemplate{
+			URL:          "https://api.example.com/_healthz",
+			CustomHeader: "X-Team: gophers",
+		},
+	}
+
+	api := newFakeUptimeRobot("secret-key")
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	setup := func() (*Client, *fakeUptimeRobot) {
+		api.reset()
+		return newClient(srv.URL, "secret-key", v1alpha1.UptimeRobotProvider{AlertContacts: []string{"123"}}), api
+	}
+
+	t.Run("creating a monitor", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		mon, ok := api.get(id)
+		if !ok {
+			t.Fatalf("Expected monitor %s to be created", id)
+		}
+
+		if mon.Get("type") != "1" || mon.Get("url") != "https://api.example.com/_healthz" || mon.Get("alert_contacts") != "123_0_0" {
+			t.Errorf("Expected the monitor to be created from the spec, got %v", mon)
+		}
+
+		if h := mon.Get("custom_http_headers"); h != `{"X-Team":"gophers"}` {
+			t.Errorf("Expected the headers to be sent as JSON, got %s", h)
+		}
+	})
+
+	t.Run("updating a monitor", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.Name = "renamed"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID != id {
+			t.Errorf("Expected the ID to be kept, got %s instead of %s", newID, id)
+		}
+
+		mon, _ := api.get(id)
+		if mon.Get("friendly_name") != "renamed" {
+			t.Errorf("Expected the monitor to be renamed, got %v", mon.Get("friendly_name"))
+		}
+	})
+
+	t.Run("updating a monitor to a keyword monitor", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.HTTP.ShouldContain = "ok"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID == id {
+			t.Fatalf("Expected the monitor to be recreated")
+		}
+
+		if _, ok := api.get(id); ok {
+			t.Errorf("Expected monitor %s to be deleted", id)
+		}
+
+		mon, ok := api.get(newID)
+		if !ok {
+			t.Fatalf("Expected monitor %s to be created", newID)
+		}
+
+		if mon.Get("type") != "2" || mon.Get("keyword_type") != "2" || mon.Get("keyword_value") != "ok" {
+			t.Errorf("Expected a keyword monitor, got %v", mon)
+		}
+	})
+
+	t.Run("updating a monitor which was removed", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Update("12345", spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id == "12345" {
+			t.Errorf("Expected a new monitor to be created")
+		}
+
+		if _, ok := api.get(id); !ok {
+			t.Errorf("Expected monitor %s to be created", id)
+		}
+	})
+
+	t.Run("deleting a monitor", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, ok := api.get(id); ok {
+			t.Errorf("Expected monitor %s to be deleted", id)
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+
+	t.Run("with an invalid ID", func(t *testing.T) {
+		cl, _ := setup()
+
+		if err := cl.Delete("not-a-number"); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+
+	t.Run("with an invalid API key", func(t *testing.T) {
+		cl := newClient(srv.URL, "wrong-key", v1alpha1.UptimeRobotProvider{})
+		_, err := cl.Create(spec)
+		if err == nil || !strings.Contains(err.Error(), "api_key is wrong") {
+			t.Errorf("Expected the API error to be returned, got %v", err)
+		}
+	})
+}
+
+func TestClient_URL(t *testing.T) {
+	cl := newClient("", "", v1alpha1.UptimeRobotProvider{})
+
+	if url := cl.URL("12345"); url != "https://uptimerobot.com/dashboard#12345" {
+		t.Errorf("Expected the URL of the monitor, got %s", url)
+	}
+}
+
+// fakeUptimeRobot is a local stand-in for the monitor methods of the
+// UptimeRobot v2 API.
+type fakeUptimeRobot struct {
+	apiKey string
+
+	lock     sync.Mutex
+	nextID   int
+	monitors map[string]url.Values
+}
+
+func newFakeUptimeRobot(apiKey string) *fakeUptimeRobot {
+	return &fakeUptimeRobot{
+		apiKey:   apiKey,
+		nextID:   770000000,
+		monitors: map[string]url.Values{},
+	}
+}
+
+func (f *fakeUptimeRobot) reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.monitors = map[string]url.Values{}
+}
+
+func (f *fakeUptimeRobot) get(id string) (url.Values, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	mon, ok := f.monitors[id]
+	return mon, ok
+}
+
+func (f *fakeUptimeRobot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	params := r.PostForm
+	if params.Get("api_key") != f.apiKey {
+		writeError(w, "invalid_parameter", "api_key is wrong")
+		return
+	}
+
+	params.Del("api_key")
+	params.Del("format")
+
+	id := params.Get("id")
+	mid, _ := strconv.Atoi(id)
+	switch r.URL.Path {
+	case "/newMonitor":
+		f.nextID++
+		f.monitors[strconv.Itoa(f.nextID)] = params
+		writeOK(w, map[string]interface{}{
+			"monitor": map[string]interface{}{"id": f.nextID, "status": 1},
+		})
+	case "/editMonitor":
+		mon, ok := f.monitors[id]
+		if !ok {
+			writeError(w, "not_found", "monitor not found")
+			return
+		}
+
+		if params.Get("type") != "" {
+			writeError(w, "invalid_parameter", "type can't be edited")
+			return
+		}
+
+		params.Set("type", mon.Get("type"))
+		params.Del("id")
+		f.monitors[id] = params
+		writeOK(w, map[string]interface{}{"monitor": map[string]interface{}{"id": mid}})
+	case "/getMonitors":
+		monitors := []map[string]interface{}{}
+		if mon, ok := f.monitors[params.Get("monitors")]; ok {
+			mid, _ := strconv.Atoi(params.Get("monitors"))
+			typ, _ := strconv.Atoi(mon.Get("type"))
+			monitors = append(monitors, map[string]interface{}{"id": mid, "type": typ})
+		}
+		writeOK(w, map[string]interface{}{"monitors": monitors})
+	case "/deleteMonitor":
+		if _, ok := f.monitors[id]; !ok {
+			writeError(w, "not_found", "monitor not found")
+			return
+		}
+
+		delete(f.monitors, id)
+		writeOK(w, map[string]interface{}{"monitor": map[string]interface{}{"id": mid}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeOK(w http.ResponseWriter, body map[string]interface{}) {
+	body["stat"] = "ok"
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, typ, msg string) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"stat": "fail",
+		"error": map[string]interface{}{
+			"type":    typ,
+			"message": msg,
+		},
+	})
+}
+
+func ptrString(s string) *string {
+	return &s
+}
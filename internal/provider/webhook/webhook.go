@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultAuthHeaderName is the header the authentication is sent in when the
+// provider doesn't configure one.
+const defaultAuthHeaderName = "Authorization"
+
+// errMissingConfig is returned when a Webhook Provider doesn't have its
+// `webhook` configuration set.
+var errMissingConfig = errors.New("the webhook configuration is required for Webhook Providers")
+
+// headerName matches valid HTTP header names.
+var headerName = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+// Register registers the provider with a certain factory using the FactoryFunc.
+func Register(fact provider.FactoryInterface) {
+	fact.Register("Webhook", FactoryFunc)
+	fact.RegisterValidator("Webhook", Validate)
+}
+
+// Validate validates the Webhook configuration of a Provider.
+func Validate(spec v1alpha1.ProviderSpec) error {
+	if spec.Webhook == nil {
+		return errMissingConfig
+	}
+
+	u, err := url.Parse(spec.Webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook.url '%s' is invalid, expected an absolute HTTP(S) URL", spec.Webhook.URL)
+	}
+
+	if name := spec.Webhook.AuthHeaderName; name != "" && !headerName.MatchString(name) {
+		return fmt.Errorf("webhook.authHeaderName '%s' is not a valid header name", name)
+	}
+
+	if err := provider.ValidateSecretVar("webhook.authHeader", spec.Webhook.AuthHeader); err != nil {
+		return err
+	}
+
+	if spec.Webhook.CA != nil {
+		return provider.ValidateSecretVar("webhook.ca", *spec.Webhook.CA)
+	}
+
+	return nil
+}
+
+// FactoryFunc is the function which will allow us to create clients on the fly
+// which connect to the monitoring service.
+func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvider) (provider.Interface, error) {
+	if prov.Webhook == nil {
+		return nil, errMissingConfig
+	}
+
+	auth, err := provider.SecretValue(k8sClient, prov.Namespace, prov.Webhook.AuthHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var ca []byte
+	if prov.Webhook.CA != nil {
+		pem, err := provider.SecretValue(k8sClient, prov.Namespace, *prov.Webhook.CA)
+		if err != nil {
+			return nil, err
+		}
+
+		ca = []byte(pem)
+	}
+
+	return newClient(*prov.Webhook, auth, ca)
+}
+
+// Client talks to a monitoring service which implements the webhook contract,
+// which is described in `docs/webhook/contract.schema.json`.
+type Client struct {
+	baseURL    string
+	authHeader string
+	auth       string
+	http       *http.Client
+}
+
+func newClient(cfg v1alpha1.WebhookProvider, auth string, ca []byte) (*Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("Could not parse the certificates of webhook.ca")
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	authHeader := cfg.AuthHeaderName
+	if authHeader == "" {
+		authHeader = defaultAuthHeaderName
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		authHeader: authHeader,
+		auth:       auth,
+		http:       &http.Client{Timeout: 30 * time.Second, Transport: transport},
+	}, nil
+}
+
+// request is the body of the requests which create and update a check.
+type request struct {
+	Spec v1alpha1.MonitorTemplateSpec `json:"spec"`
+}
+
+// response is the body of the responses to requests which create and update a
+// check.
+type response struct {
+	ID string `json:"id"`
+}
+
+type errorResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Create creates a new check with a POST to the `monitors` endpoint.
+func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	var resp response
+	if err := c.do(http.MethodPost, "/monitors", &request{Spec: spec}, &resp); err != nil {
+		return "", err
+	}
+
+	if resp.ID == "" {
+		return "", errors.New("Webhook didn't return the ID of the created check")
+	}
+
+	return resp.ID, nil
+}
+
+// Delete deletes the check which is linked to the given ID with a DELETE to
+// its endpoint.
+func (c *Client) Delete(id string) error {
+	return c.do(http.MethodDelete, "/monitors/"+url.PathEscape(id), nil, nil)
+}
+
+// Update updates the check linked to the given ID with a PUT to its endpoint.
+// The service may return a new ID for the check. When the check doesn't exist,
+// a new check is created.
+func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	var resp response
+	err := c.do(http.MethodPut, "/monitors/"+url.PathEscape(id), &request{Spec: spec}, &resp)
+	if err == provider.ErrMonitorNotFound {
+		return c.Create(spec)
+	}
+
+	if err != nil {
+		return id, err
+	}
+
+	if resp.ID == "" {
+		return id, nil
+	}
+
+	return resp.ID, nil
+}
+
+// do sends a request to the monitoring service and decodes the response into
+// out. A 404 response is returned as provider.ErrMonitorNotFound.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(c.authHeader, c.auth)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("Could not send request to the webhook: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return provider.ErrMonitorNotFound
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error != "" {
+			return fmt.Errorf("Webhook returned %d: %s", resp.StatusCode, apiErr.Error)
+		}
+
+		return fmt.Errorf("Webhook returned %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("Could not decode webhook response: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,469 @@
+package webhook
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const contract = "../../../docs/webhook/contract.schema.json"
+
+func TestValidate(t *testing.T) {
+	auth := v1alpha1.SecretVar{Value: ptrString("Bearer token")}
+
+	tcs := []struct {
+		name  string
+		cfg   *v1alpha1.WebhookProvider
+		valid bool
+	}{
+		{"with a URL", &v1alpha1.WebhookProvider{URL: "https://uptime.example.com/api", AuthHeader: auth}, true},
+		{"with a header name", &v1alpha1.WebhookProvider{URL: "http://uptime.internal", AuthHeader: auth, AuthHeaderName: "X-API-Key"}, true},
+		{"with a CA", &v1alpha1.WebhookProvider{URL: "https://uptime.internal", AuthHeader: auth, CA: &v1alpha1.SecretVar{Value: ptrString("pem")}}, true},
+		{"without a URL", &v1alpha1.WebhookProvider{AuthHeader: auth}, false},
+		{"with a relative URL", &v1alpha1.WebhookProvider{URL: "/api", AuthHeader: auth}, false},
+		{"with an unsupported scheme", &v1alpha1.WebhookProvider{URL: "ftp://uptime.internal", AuthHeader: auth}, false},
+		{"with an invalid header name", &v1alpha1.WebhookProvider{URL: "https://uptime.internal", AuthHeader: auth, AuthHeaderName: "API Key"}, false},
+		{"without an auth header", &v1alpha1.WebhookProvider{URL: "https://uptime.internal"}, false},
+		{"with an invalid CA", &v1alpha1.WebhookProvider{URL: "https://uptime.internal", AuthHeader: auth, CA: &v1alpha1.SecretVar{}}, false},
+		{"without configuration", nil, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(v1alpha1.ProviderSpec{Type: "Webhook", Webhook: tc.cfg})
+			if tc.valid && err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Errorf("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestFactoryFunc_MissingConfig(t *testing.T) {
+	_, err := FactoryFunc(nil, v1alpha1.NamespacedProvider{
+		ProviderSpec: v1alpha1.ProviderSpec{Type: "Webhook"},
+	})
+	if err != errMissingConfig {
+		t.Errorf("Expected error `%s`, got `%v`", errMissingConfig, err)
+	}
+}
+
+func TestFactoryFunc_SecretNamespace(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-secrets", Namespace: "ingress-monitor"},
+		Data:       map[string][]byte{"token": []byte("platform-token")},
+	}
+	k8sClient := k8sfake.NewSimpleClientset(secret)
+
+	newProvider := func(namespace string) v1alpha1.NamespacedProvider {
+		return v1alpha1.NamespacedProvider{
+			Namespace: namespace,
+			ProviderSpec: v1alpha1.ProviderSpec{
+				Type: "Webhook",
+				Webhook: &v1alpha1.WebhookProvider{
+					URL: "https://uptime.example.com/api",
+					AuthHeader: v1alpha1.SecretVar{ValueFrom: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "platform-secrets"},
+						Key:                  "token",
+					}},
+				},
+			},
+		}
+	}
+
+	t.Run("in the namespace of the provider", func(t *testing.T) {
+		cl, err := FactoryFunc(k8sClient, newProvider("ingress-monitor"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if auth := cl.(*Client).auth; auth != "platform-token" {
+			t.Errorf("Expected the auth header to be read from the secret, got %s", auth)
+		}
+	})
+
+	t.Run("in another namespace", func(t *testing.T) {
+		if _, err := FactoryFunc(k8sClient, newProvider("websites")); err == nil {
+			t.Errorf("Expected the secret of another namespace not to be found")
+		}
+	})
+}
+
+func TestClient(t *testing.T) {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name:      "api.example.com",
+		Type:      "HTTP",
+		CheckRate: ptrString("1m0s"),
+		HTTP: &v1alpha1.HTTPTemplate{
+			URL:           "https://api.example.com/_healthz",
+			ShouldContain: "ok",
+		},
+	}
+
+	api := newFakeService("X-API-Key", "secret")
+	srv := httptest.NewTLSServer(api)
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	cfg := v1alpha1.WebhookProvider{URL: srv.URL + "/api/", AuthHeaderName: "X-API-Key"}
+
+	setup := func() (*Client, *fakeService) {
+		api.reset()
+
+		cl, err := newClient(cfg, "secret", ca)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		return cl, api
+	}
+
+	t.Run("creating a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		created, ok := api.get(id)
+		if !ok {
+			t.Fatalf("Expected check %s to be created", id)
+		}
+
+		if !reflect.DeepEqual(spec, created) {
+			t.Errorf("Expected the full spec to be sent\n%#v\ngot\n%#v", spec, created)
+		}
+	})
+
+	t.Run("updating a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.Name = "renamed"
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID != id {
+			t.Errorf("Expected the ID to be kept, got %s instead of %s", newID, id)
+		}
+
+		if chk, _ := api.get(id); chk.Name != "renamed" {
+			t.Errorf("Expected the check to be renamed, got %s", chk.Name)
+		}
+	})
+
+	t.Run("updating a check which gets a new ID", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		updated := *spec.DeepCopy()
+		updated.Type = "TCP"
+		updated.HTTP = nil
+		updated.TCP = &v1alpha1.TCPTemplate{Host: "api.example.com", Port: 443}
+
+		newID, err := cl.Update(id, updated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if newID == id {
+			t.Fatalf("Expected the ID returned by the service to be used")
+		}
+
+		if _, ok := api.get(newID); !ok {
+			t.Errorf("Expected check %s to exist", newID)
+		}
+	})
+
+	t.Run("updating a check which was removed", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Update("check/1", spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id == "check/1" {
+			t.Errorf("Expected a new check to be created")
+		}
+
+		if _, ok := api.get(id); !ok {
+			t.Errorf("Expected check %s to be created", id)
+		}
+	})
+
+	t.Run("deleting a check", func(t *testing.T) {
+		cl, api := setup()
+
+		id, err := cl.Create(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if err := cl.Delete(id); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, ok := api.get(id); ok {
+			t.Errorf("Expected check %s to be deleted", id)
+		}
+
+		if err := cl.Delete(id); err != provider.ErrMonitorNotFound {
+			t.Errorf("Expected error `%s`, got `%v`", provider.ErrMonitorNotFound, err)
+		}
+	})
+
+	t.Run("with an invalid auth header", func(t *testing.T) {
+		cl, err := newClient(cfg, "wrong", ca)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		_, err = cl.Create(spec)
+		if err == nil || !strings.Contains(err.Error(), "invalid API key") {
+			t.Errorf("Expected the service error to be returned, got %v", err)
+		}
+	})
+
+	t.Run("without the CA", func(t *testing.T) {
+		cl, err := newClient(cfg, "secret", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if _, err := cl.Create(spec); err == nil {
+			t.Errorf("Expected the certificate of the service to be rejected")
+		}
+	})
+
+	t.Run("with an invalid CA", func(t *testing.T) {
+		if _, err := newClient(cfg, "secret", []byte("not a certificate")); err == nil {
+			t.Errorf("Expected an error, got none")
+		}
+	})
+}
+
+// schema is the subset of JSON Schema the contract uses.
+type schema struct {
+	Ref        string            `json:"$ref"`
+	Type       string            `json:"type"`
+	Properties map[string]schema `json:"properties"`
+	Required   []string          `json:"required"`
+}
+
+// TestContract makes sure the published contract describes the requests and
+// responses the client sends and expects.
+func TestContract(t *testing.T) {
+	raw, err := ioutil.ReadFile(contract)
+	if err != nil {
+		t.Fatalf("Could not read the contract: %s", err)
+	}
+
+	var doc struct {
+		Definitions map[string]schema `json:"definitions"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("Could not parse the contract: %s", err)
+	}
+
+	tcs := []struct {
+		definition string
+		typ        reflect.Type
+	}{
+		{"request", reflect.TypeOf(request{})},
+		{"response", reflect.TypeOf(response{})},
+		{"error", reflect.TypeOf(errorResponse{})},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.definition, func(t *testing.T) {
+			def, ok := doc.Definitions[tc.definition]
+			if !ok {
+				t.Fatalf("Expected the contract to define `%s`", tc.definition)
+			}
+
+			compareSchema(t, doc.Definitions, tc.definition, tc.typ, def)
+		})
+	}
+}
+
+func compareSchema(t *testing.T, defs map[string]schema, path string, typ reflect.Type, s schema) {
+	t.Helper()
+
+	if s.Ref != "" {
+		s = defs[strings.TrimPrefix(s.Ref, "#/definitions/")]
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	var expected string
+	switch typ.Kind() {
+	case reflect.Struct:
+		expected = "object"
+	case reflect.String:
+		expected = "string"
+	case reflect.Bool:
+		expected = "boolean"
+	default:
+		expected = "integer"
+	}
+
+	if s.Type != expected {
+		t.Errorf("Expected %s to be of type `%s`, got `%s`", path, expected, s.Type)
+		return
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+
+	var fields, required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		fields = append(fields, tag[0])
+
+		prop, ok := s.Properties[tag[0]]
+		if !ok {
+			t.Errorf("Expected %s to describe `%s`", path, tag[0])
+			continue
+		}
+
+		compareSchema(t, defs, path+"."+tag[0], field.Type, prop)
+
+		if len(tag) == 1 {
+			required = append(required, tag[0])
+		}
+	}
+
+	if len(fields) != len(s.Properties) {
+		t.Errorf("Expected %s to describe %v, got %d properties", path, fields, len(s.Properties))
+	}
+
+	actual := append([]string{}, s.Required...)
+	sort.Strings(required)
+	sort.Strings(actual)
+	if len(required) > 0 && !reflect.DeepEqual(required, actual) {
+		t.Errorf("Expected %s to require %v, got %v", path, required, actual)
+	}
+}
+
+// fakeService is a local stand-in for a monitoring service which implements the
+// webhook contract. It gives a check a new ID when its type changes.
+type fakeService struct {
+	header string
+	value  string
+
+	lock   sync.Mutex
+	nextID int
+	checks map[string]v1alpha1.MonitorTemplateSpec
+}
+
+func newFakeService(header, value string) *fakeService {
+	return &fakeService{
+		header: header,
+		value:  value,
+		checks: map[string]v1alpha1.MonitorTemplateSpec{},
+	}
+}
+
+func (f *fakeService) reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.checks = map[string]v1alpha1.MonitorTemplateSpec{}
+}
+
+func (f *fakeService) get(id string) (v1alpha1.MonitorTemplateSpec, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	chk, ok := f.checks[id]
+	return chk, ok
+}
+
+func (f *fakeService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get(f.header) != f.value {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	var req request
+	if r.Method != http.MethodDelete {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/monitors/")
+	_, exists := f.checks[id]
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/monitors":
+		f.nextID++
+		id := fmt.Sprintf("check/%d", f.nextID)
+		f.checks[id] = req.Spec
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response{ID: id})
+	case r.Method == http.MethodPut && exists && f.checks[id].Type != req.Spec.Type:
+		delete(f.checks, id)
+		f.nextID++
+		id := fmt.Sprintf("check/%d", f.nextID)
+		f.checks[id] = req.Spec
+		json.NewEncoder(w).Encode(response{ID: id})
+	case r.Method == http.MethodPut && exists:
+		f.checks[id] = req.Spec
+		json.NewEncoder(w).Encode(response{ID: id})
+	case r.Method == http.MethodDelete && exists:
+		delete(f.checks, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "check not found")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(errorResponse{Error: msg})
+}
+
+func ptrString(s string) *string {
+	return &s
+}
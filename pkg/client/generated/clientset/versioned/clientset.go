@@ -25,8 +25,10 @@
 package versioned
 
 import (
-	glog "github.com/golang/glog"
+	"fmt"
+
 	ingressmonitorv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1alpha1"
+	ingressmonitorv1beta1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -35,8 +37,7 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	IngressmonitorV1alpha1() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface
-	// Deprecated: please explicitly pick a version if possible.
-	Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface
+	IngressmonitorV1beta1() ingressmonitorv1beta1.IngressmonitorV1beta1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -44,6 +45,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	ingressmonitorV1alpha1 *ingressmonitorv1alpha1.IngressmonitorV1alpha1Client
+	ingressmonitorV1beta1  *ingressmonitorv1beta1.IngressmonitorV1beta1Client
 }
 
 // IngressmonitorV1alpha1 retrieves the IngressmonitorV1alpha1Client
@@ -51,10 +53,9 @@ func (c *Clientset) IngressmonitorV1alpha1() ingressmonitorv1alpha1.Ingressmonit
 	return c.ingressmonitorV1alpha1
 }
 
-// Deprecated: Ingressmonitor retrieves the default version of IngressmonitorClient.
-// Please explicitly pick a version.
-func (c *Clientset) Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface {
-	return c.ingressmonitorV1alpha1
+// IngressmonitorV1beta1 retrieves the IngressmonitorV1beta1Client
+func (c *Clientset) IngressmonitorV1beta1() ingressmonitorv1beta1.IngressmonitorV1beta1Interface {
+	return c.ingressmonitorV1beta1
 }
 
 // Discovery retrieves the DiscoveryClient
@@ -66,9 +67,14 @@ func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 }
 
 // NewForConfig creates a new Clientset for the given config.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfig will generate a rate-limiter in configShallowCopy.
 func NewForConfig(c *rest.Config) (*Clientset, error) {
 	configShallowCopy := *c
 	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		if configShallowCopy.Burst <= 0 {
+			return nil, fmt.Errorf("burst is required to be greater than 0 when RateLimiter is not set and QPS is set to greater than 0")
+		}
 		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
 	}
 	var cs Clientset
@@ -77,10 +83,13 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.ingressmonitorV1beta1, err = ingressmonitorv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
-		glog.Errorf("failed to create the DiscoveryClient: %v", err)
 		return nil, err
 	}
 	return &cs, nil
@@ -91,6 +100,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.ingressmonitorV1alpha1 = ingressmonitorv1alpha1.NewForConfigOrDie(c)
+	cs.ingressmonitorV1beta1 = ingressmonitorv1beta1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -100,6 +110,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.ingressmonitorV1alpha1 = ingressmonitorv1alpha1.New(c)
+	cs.ingressmonitorV1beta1 = ingressmonitorv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -28,6 +28,8 @@ import (
 	clientset "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned"
 	ingressmonitorv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1alpha1"
 	fakeingressmonitorv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1alpha1/fake"
+	ingressmonitorv1beta1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1beta1"
+	fakeingressmonitorv1beta1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -47,9 +49,10 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 		}
 	}
 
-	fakePtr := testing.Fake{}
-	fakePtr.AddReactor("*", "*", testing.ObjectReaction(o))
-	fakePtr.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
+	cs := &Clientset{tracker: o}
+	cs.discovery = &fakediscovery.FakeDiscovery{Fake: &cs.Fake}
+	cs.AddReactor("*", "*", testing.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
 		gvr := action.GetResource()
 		ns := action.GetNamespace()
 		watch, err := o.Watch(gvr, ns)
@@ -59,7 +62,7 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 		return true, watch, nil
 	})
 
-	return &Clientset{fakePtr, &fakediscovery.FakeDiscovery{Fake: &fakePtr}}
+	return cs
 }
 
 // Clientset implements clientset.Interface. Meant to be embedded into a
@@ -68,12 +71,17 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 type Clientset struct {
 	testing.Fake
 	discovery *fakediscovery.FakeDiscovery
+	tracker   testing.ObjectTracker
 }
 
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	return c.discovery
 }
 
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
 var _ clientset.Interface = &Clientset{}
 
 // IngressmonitorV1alpha1 retrieves the IngressmonitorV1alpha1Client
@@ -81,7 +89,7 @@ func (c *Clientset) IngressmonitorV1alpha1() ingressmonitorv1alpha1.Ingressmonit
 	return &fakeingressmonitorv1alpha1.FakeIngressmonitorV1alpha1{Fake: &c.Fake}
 }
 
-// Ingressmonitor retrieves the IngressmonitorV1alpha1Client
-func (c *Clientset) Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface {
-	return &fakeingressmonitorv1alpha1.FakeIngressmonitorV1alpha1{Fake: &c.Fake}
+// IngressmonitorV1beta1 retrieves the IngressmonitorV1beta1Client
+func (c *Clientset) IngressmonitorV1beta1() ingressmonitorv1beta1.IngressmonitorV1beta1Interface {
+	return &fakeingressmonitorv1beta1.FakeIngressmonitorV1beta1{Fake: &c.Fake}
 }
@@ -0,0 +1,128 @@
+// MIT License
+//
+// Copyright (c) 2018 Jelmer Snoeck
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterMonitorTemplates implements ClusterMonitorTemplateInterface
+type FakeClusterMonitorTemplates struct {
+	Fake *FakeIngressmonitorV1alpha1
+}
+
+var clustermonitortemplatesResource = schema.GroupVersionResource{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Resource: "clustermonitortemplates"}
+
+var clustermonitortemplatesKind = schema.GroupVersionKind{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Kind: "ClusterMonitorTemplate"}
+
+// Get takes name of the clusterMonitorTemplate, and returns the corresponding clusterMonitorTemplate object, and an error if there is any.
+func (c *FakeClusterMonitorTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterMonitorTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clustermonitortemplatesResource, name), &v1alpha1.ClusterMonitorTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterMonitorTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterMonitorTemplates that match those selectors.
+func (c *FakeClusterMonitorTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterMonitorTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clustermonitortemplatesResource, clustermonitortemplatesKind, opts), &v1alpha1.ClusterMonitorTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterMonitorTemplateList{ListMeta: obj.(*v1alpha1.ClusterMonitorTemplateList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterMonitorTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterMonitorTemplates.
+func (c *FakeClusterMonitorTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clustermonitortemplatesResource, opts))
+}
+
+// Create takes the representation of a clusterMonitorTemplate and creates it.  Returns the server's representation of the clusterMonitorTemplate, and an error, if there is any.
+func (c *FakeClusterMonitorTemplates) Create(ctx context.Context, clusterMonitorTemplate *v1alpha1.ClusterMonitorTemplate, opts v1.CreateOptions) (result *v1alpha1.ClusterMonitorTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clustermonitortemplatesResource, clusterMonitorTemplate), &v1alpha1.ClusterMonitorTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterMonitorTemplate), err
+}
+
+// Update takes the representation of a clusterMonitorTemplate and updates it. Returns the server's representation of the clusterMonitorTemplate, and an error, if there is any.
+func (c *FakeClusterMonitorTemplates) Update(ctx context.Context, clusterMonitorTemplate *v1alpha1.ClusterMonitorTemplate, opts v1.UpdateOptions) (result *v1alpha1.ClusterMonitorTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clustermonitortemplatesResource, clusterMonitorTemplate), &v1alpha1.ClusterMonitorTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterMonitorTemplate), err
+}
+
+// Delete takes name of the clusterMonitorTemplate and deletes it. Returns an error if one occurs.
+func (c *FakeClusterMonitorTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clustermonitortemplatesResource, name), &v1alpha1.ClusterMonitorTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterMonitorTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clustermonitortemplatesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterMonitorTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterMonitorTemplate.
+func (c *FakeClusterMonitorTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterMonitorTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustermonitortemplatesResource, name, pt, data, subresources...), &v1alpha1.ClusterMonitorTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterMonitorTemplate), err
+}
@@ -25,6 +25,8 @@
 package fake
 
 import (
+	"context"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -45,7 +47,7 @@ var ingressmonitorsResource = schema.GroupVersionResource{Group: "ingressmonitor
 var ingressmonitorsKind = schema.GroupVersionKind{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Kind: "IngressMonitor"}
 
 // Get takes name of the ingressMonitor, and returns the corresponding ingressMonitor object, and an error if there is any.
-func (c *FakeIngressMonitors) Get(name string, options v1.GetOptions) (result *v1alpha1.IngressMonitor, err error) {
+func (c *FakeIngressMonitors) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.IngressMonitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(ingressmonitorsResource, c.ns, name), &v1alpha1.IngressMonitor{})
 
@@ -56,7 +58,7 @@ func (c *FakeIngressMonitors) Get(name string, options v1.GetOptions) (result *v
 }
 
 // List takes label and field selectors, and returns the list of IngressMonitors that match those selectors.
-func (c *FakeIngressMonitors) List(opts v1.ListOptions) (result *v1alpha1.IngressMonitorList, err error) {
+func (c *FakeIngressMonitors) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.IngressMonitorList, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(ingressmonitorsResource, ingressmonitorsKind, c.ns, opts), &v1alpha1.IngressMonitorList{})
 
@@ -68,7 +70,7 @@ func (c *FakeIngressMonitors) List(opts v1.ListOptions) (result *v1alpha1.Ingres
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &v1alpha1.IngressMonitorList{}
+	list := &v1alpha1.IngressMonitorList{ListMeta: obj.(*v1alpha1.IngressMonitorList).ListMeta}
 	for _, item := range obj.(*v1alpha1.IngressMonitorList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
@@ -78,14 +80,14 @@ func (c *FakeIngressMonitors) List(opts v1.ListOptions) (result *v1alpha1.Ingres
 }
 
 // Watch returns a watch.Interface that watches the requested ingressMonitors.
-func (c *FakeIngressMonitors) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeIngressMonitors) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(ingressmonitorsResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a ingressMonitor and creates it.  Returns the server's representation of the ingressMonitor, and an error, if there is any.
-func (c *FakeIngressMonitors) Create(ingressMonitor *v1alpha1.IngressMonitor) (result *v1alpha1.IngressMonitor, err error) {
+func (c *FakeIngressMonitors) Create(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.CreateOptions) (result *v1alpha1.IngressMonitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(ingressmonitorsResource, c.ns, ingressMonitor), &v1alpha1.IngressMonitor{})
 
@@ -96,7 +98,7 @@ func (c *FakeIngressMonitors) Create(ingressMonitor *v1alpha1.IngressMonitor) (r
 }
 
 // Update takes the representation of a ingressMonitor and updates it. Returns the server's representation of the ingressMonitor, and an error, if there is any.
-func (c *FakeIngressMonitors) Update(ingressMonitor *v1alpha1.IngressMonitor) (result *v1alpha1.IngressMonitor, err error) {
+func (c *FakeIngressMonitors) Update(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (result *v1alpha1.IngressMonitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(ingressmonitorsResource, c.ns, ingressMonitor), &v1alpha1.IngressMonitor{})
 
@@ -108,7 +110,7 @@ func (c *FakeIngressMonitors) Update(ingressMonitor *v1alpha1.IngressMonitor) (r
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeIngressMonitors) UpdateStatus(ingressMonitor *v1alpha1.IngressMonitor) (*v1alpha1.IngressMonitor, error) {
+func (c *FakeIngressMonitors) UpdateStatus(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (*v1alpha1.IngressMonitor, error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateSubresourceAction(ingressmonitorsResource, "status", c.ns, ingressMonitor), &v1alpha1.IngressMonitor{})
 
@@ -119,7 +121,7 @@ func (c *FakeIngressMonitors) UpdateStatus(ingressMonitor *v1alpha1.IngressMonit
 }
 
 // Delete takes name of the ingressMonitor and deletes it. Returns an error if one occurs.
-func (c *FakeIngressMonitors) Delete(name string, options *v1.DeleteOptions) error {
+func (c *FakeIngressMonitors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
 		Invokes(testing.NewDeleteAction(ingressmonitorsResource, c.ns, name), &v1alpha1.IngressMonitor{})
 
@@ -127,17 +129,17 @@ func (c *FakeIngressMonitors) Delete(name string, options *v1.DeleteOptions) err
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeIngressMonitors) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(ingressmonitorsResource, c.ns, listOptions)
+func (c *FakeIngressMonitors) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(ingressmonitorsResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1alpha1.IngressMonitorList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched ingressMonitor.
-func (c *FakeIngressMonitors) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.IngressMonitor, err error) {
+func (c *FakeIngressMonitors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IngressMonitor, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(ingressmonitorsResource, c.ns, name, data, subresources...), &v1alpha1.IngressMonitor{})
+		Invokes(testing.NewPatchSubresourceAction(ingressmonitorsResource, c.ns, name, pt, data, subresources...), &v1alpha1.IngressMonitor{})
 
 	if obj == nil {
 		return nil, err
@@ -34,6 +34,14 @@ type FakeIngressmonitorV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeIngressmonitorV1alpha1) ClusterMonitorTemplates() v1alpha1.ClusterMonitorTemplateInterface {
+	return &FakeClusterMonitorTemplates{c}
+}
+
+func (c *FakeIngressmonitorV1alpha1) ClusterProviders() v1alpha1.ClusterProviderInterface {
+	return &FakeClusterProviders{c}
+}
+
 func (c *FakeIngressmonitorV1alpha1) IngressMonitors(namespace string) v1alpha1.IngressMonitorInterface {
 	return &FakeIngressMonitors{c, namespace}
 }
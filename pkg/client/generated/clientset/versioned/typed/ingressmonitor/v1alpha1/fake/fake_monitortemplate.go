@@ -25,6 +25,8 @@
 package fake
 
 import (
+	"context"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -45,7 +47,7 @@ var monitortemplatesResource = schema.GroupVersionResource{Group: "ingressmonito
 var monitortemplatesKind = schema.GroupVersionKind{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Kind: "MonitorTemplate"}
 
 // Get takes name of the monitorTemplate, and returns the corresponding monitorTemplate object, and an error if there is any.
-func (c *FakeMonitorTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *FakeMonitorTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(monitortemplatesResource, c.ns, name), &v1alpha1.MonitorTemplate{})
 
@@ -56,7 +58,7 @@ func (c *FakeMonitorTemplates) Get(name string, options v1.GetOptions) (result *
 }
 
 // List takes label and field selectors, and returns the list of MonitorTemplates that match those selectors.
-func (c *FakeMonitorTemplates) List(opts v1.ListOptions) (result *v1alpha1.MonitorTemplateList, err error) {
+func (c *FakeMonitorTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MonitorTemplateList, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(monitortemplatesResource, monitortemplatesKind, c.ns, opts), &v1alpha1.MonitorTemplateList{})
 
@@ -68,7 +70,7 @@ func (c *FakeMonitorTemplates) List(opts v1.ListOptions) (result *v1alpha1.Monit
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &v1alpha1.MonitorTemplateList{}
+	list := &v1alpha1.MonitorTemplateList{ListMeta: obj.(*v1alpha1.MonitorTemplateList).ListMeta}
 	for _, item := range obj.(*v1alpha1.MonitorTemplateList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
@@ -78,14 +80,14 @@ func (c *FakeMonitorTemplates) List(opts v1.ListOptions) (result *v1alpha1.Monit
 }
 
 // Watch returns a watch.Interface that watches the requested monitorTemplates.
-func (c *FakeMonitorTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeMonitorTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(monitortemplatesResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a monitorTemplate and creates it.  Returns the server's representation of the monitorTemplate, and an error, if there is any.
-func (c *FakeMonitorTemplates) Create(monitorTemplate *v1alpha1.MonitorTemplate) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *FakeMonitorTemplates) Create(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.CreateOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(monitortemplatesResource, c.ns, monitorTemplate), &v1alpha1.MonitorTemplate{})
 
@@ -96,7 +98,7 @@ func (c *FakeMonitorTemplates) Create(monitorTemplate *v1alpha1.MonitorTemplate)
 }
 
 // Update takes the representation of a monitorTemplate and updates it. Returns the server's representation of the monitorTemplate, and an error, if there is any.
-func (c *FakeMonitorTemplates) Update(monitorTemplate *v1alpha1.MonitorTemplate) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *FakeMonitorTemplates) Update(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.UpdateOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(monitortemplatesResource, c.ns, monitorTemplate), &v1alpha1.MonitorTemplate{})
 
@@ -107,7 +109,7 @@ func (c *FakeMonitorTemplates) Update(monitorTemplate *v1alpha1.MonitorTemplate)
 }
 
 // Delete takes name of the monitorTemplate and deletes it. Returns an error if one occurs.
-func (c *FakeMonitorTemplates) Delete(name string, options *v1.DeleteOptions) error {
+func (c *FakeMonitorTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
 		Invokes(testing.NewDeleteAction(monitortemplatesResource, c.ns, name), &v1alpha1.MonitorTemplate{})
 
@@ -115,17 +117,17 @@ func (c *FakeMonitorTemplates) Delete(name string, options *v1.DeleteOptions) er
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeMonitorTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(monitortemplatesResource, c.ns, listOptions)
+func (c *FakeMonitorTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(monitortemplatesResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1alpha1.MonitorTemplateList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched monitorTemplate.
-func (c *FakeMonitorTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *FakeMonitorTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MonitorTemplate, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(monitortemplatesResource, c.ns, name, data, subresources...), &v1alpha1.MonitorTemplate{})
+		Invokes(testing.NewPatchSubresourceAction(monitortemplatesResource, c.ns, name, pt, data, subresources...), &v1alpha1.MonitorTemplate{})
 
 	if obj == nil {
 		return nil, err
@@ -24,6 +24,10 @@
 
 package v1alpha1
 
+type ClusterMonitorTemplateExpansion interface{}
+
+type ClusterProviderExpansion interface{}
+
 type IngressMonitorExpansion interface{}
 
 type MonitorExpansion interface{}
@@ -25,6 +25,9 @@
 package v1alpha1
 
 import (
+	"context"
+	"time"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,15 +44,15 @@ type IngressMonitorsGetter interface {
 
 // IngressMonitorInterface has methods to work with IngressMonitor resources.
 type IngressMonitorInterface interface {
-	Create(*v1alpha1.IngressMonitor) (*v1alpha1.IngressMonitor, error)
-	Update(*v1alpha1.IngressMonitor) (*v1alpha1.IngressMonitor, error)
-	UpdateStatus(*v1alpha1.IngressMonitor) (*v1alpha1.IngressMonitor, error)
-	Delete(name string, options *v1.DeleteOptions) error
-	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
-	Get(name string, options v1.GetOptions) (*v1alpha1.IngressMonitor, error)
-	List(opts v1.ListOptions) (*v1alpha1.IngressMonitorList, error)
-	Watch(opts v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.IngressMonitor, err error)
+	Create(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.CreateOptions) (*v1alpha1.IngressMonitor, error)
+	Update(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (*v1alpha1.IngressMonitor, error)
+	UpdateStatus(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (*v1alpha1.IngressMonitor, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.IngressMonitor, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.IngressMonitorList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IngressMonitor, err error)
 	IngressMonitorExpansion
 }
 
@@ -68,113 +71,131 @@ func newIngressMonitors(c *IngressmonitorV1alpha1Client, namespace string) *ingr
 }
 
 // Get takes name of the ingressMonitor, and returns the corresponding ingressMonitor object, and an error if there is any.
-func (c *ingressMonitors) Get(name string, options v1.GetOptions) (result *v1alpha1.IngressMonitor, err error) {
+func (c *ingressMonitors) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.IngressMonitor, err error) {
 	result = &v1alpha1.IngressMonitor{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of IngressMonitors that match those selectors.
-func (c *ingressMonitors) List(opts v1.ListOptions) (result *v1alpha1.IngressMonitorList, err error) {
+func (c *ingressMonitors) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.IngressMonitorList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	result = &v1alpha1.IngressMonitorList{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
+		Timeout(timeout).
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested ingressMonitors.
-func (c *ingressMonitors) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *ingressMonitors) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	opts.Watch = true
 	return c.client.Get().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
+		Timeout(timeout).
+		Watch(ctx)
 }
 
 // Create takes the representation of a ingressMonitor and creates it.  Returns the server's representation of the ingressMonitor, and an error, if there is any.
-func (c *ingressMonitors) Create(ingressMonitor *v1alpha1.IngressMonitor) (result *v1alpha1.IngressMonitor, err error) {
+func (c *ingressMonitors) Create(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.CreateOptions) (result *v1alpha1.IngressMonitor, err error) {
 	result = &v1alpha1.IngressMonitor{}
 	err = c.client.Post().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(ingressMonitor).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a ingressMonitor and updates it. Returns the server's representation of the ingressMonitor, and an error, if there is any.
-func (c *ingressMonitors) Update(ingressMonitor *v1alpha1.IngressMonitor) (result *v1alpha1.IngressMonitor, err error) {
+func (c *ingressMonitors) Update(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (result *v1alpha1.IngressMonitor, err error) {
 	result = &v1alpha1.IngressMonitor{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		Name(ingressMonitor.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(ingressMonitor).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-
-func (c *ingressMonitors) UpdateStatus(ingressMonitor *v1alpha1.IngressMonitor) (result *v1alpha1.IngressMonitor, err error) {
+func (c *ingressMonitors) UpdateStatus(ctx context.Context, ingressMonitor *v1alpha1.IngressMonitor, opts v1.UpdateOptions) (result *v1alpha1.IngressMonitor, err error) {
 	result = &v1alpha1.IngressMonitor{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		Name(ingressMonitor.Name).
 		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(ingressMonitor).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the ingressMonitor and deletes it. Returns an error if one occurs.
-func (c *ingressMonitors) Delete(name string, options *v1.DeleteOptions) error {
+func (c *ingressMonitors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
 		Name(name).
-		Body(options).
-		Do().
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *ingressMonitors) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+func (c *ingressMonitors) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("ingressmonitors").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched ingressMonitor.
-func (c *ingressMonitors) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.IngressMonitor, err error) {
+func (c *ingressMonitors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IngressMonitor, err error) {
 	result = &v1alpha1.IngressMonitor{}
 	err = c.client.Patch(pt).
 		Namespace(c.ns).
 		Resource("ingressmonitors").
-		SubResource(subresources...).
 		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(data).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
@@ -27,12 +27,13 @@ package v1alpha1
 import (
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
-	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	rest "k8s.io/client-go/rest"
 )
 
 type IngressmonitorV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterMonitorTemplatesGetter
+	ClusterProvidersGetter
 	IngressMonitorsGetter
 	MonitorsGetter
 	MonitorTemplatesGetter
@@ -44,6 +45,14 @@ type IngressmonitorV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *IngressmonitorV1alpha1Client) ClusterMonitorTemplates() ClusterMonitorTemplateInterface {
+	return newClusterMonitorTemplates(c)
+}
+
+func (c *IngressmonitorV1alpha1Client) ClusterProviders() ClusterProviderInterface {
+	return newClusterProviders(c)
+}
+
 func (c *IngressmonitorV1alpha1Client) IngressMonitors(namespace string) IngressMonitorInterface {
 	return newIngressMonitors(c, namespace)
 }
@@ -92,7 +101,7 @@ func setConfigDefaults(config *rest.Config) error {
 	gv := v1alpha1.SchemeGroupVersion
 	config.GroupVersion = &gv
 	config.APIPath = "/apis"
-	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: scheme.Codecs}
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
 
 	if config.UserAgent == "" {
 		config.UserAgent = rest.DefaultKubernetesUserAgent()
@@ -25,6 +25,9 @@
 package v1alpha1
 
 import (
+	"context"
+	"time"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,14 +44,15 @@ type MonitorsGetter interface {
 
 // MonitorInterface has methods to work with Monitor resources.
 type MonitorInterface interface {
-	Create(*v1alpha1.Monitor) (*v1alpha1.Monitor, error)
-	Update(*v1alpha1.Monitor) (*v1alpha1.Monitor, error)
-	Delete(name string, options *v1.DeleteOptions) error
-	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
-	Get(name string, options v1.GetOptions) (*v1alpha1.Monitor, error)
-	List(opts v1.ListOptions) (*v1alpha1.MonitorList, error)
-	Watch(opts v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Monitor, err error)
+	Create(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.CreateOptions) (*v1alpha1.Monitor, error)
+	Update(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (*v1alpha1.Monitor, error)
+	UpdateStatus(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (*v1alpha1.Monitor, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Monitor, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.MonitorList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Monitor, err error)
 	MonitorExpansion
 }
 
@@ -67,97 +71,131 @@ func newMonitors(c *IngressmonitorV1alpha1Client, namespace string) *monitors {
 }
 
 // Get takes name of the monitor, and returns the corresponding monitor object, and an error if there is any.
-func (c *monitors) Get(name string, options v1.GetOptions) (result *v1alpha1.Monitor, err error) {
+func (c *monitors) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Monitor, err error) {
 	result = &v1alpha1.Monitor{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("monitors").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of Monitors that match those selectors.
-func (c *monitors) List(opts v1.ListOptions) (result *v1alpha1.MonitorList, err error) {
+func (c *monitors) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MonitorList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	result = &v1alpha1.MonitorList{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("monitors").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
+		Timeout(timeout).
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested monitors.
-func (c *monitors) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *monitors) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	opts.Watch = true
 	return c.client.Get().
 		Namespace(c.ns).
 		Resource("monitors").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
+		Timeout(timeout).
+		Watch(ctx)
 }
 
 // Create takes the representation of a monitor and creates it.  Returns the server's representation of the monitor, and an error, if there is any.
-func (c *monitors) Create(monitor *v1alpha1.Monitor) (result *v1alpha1.Monitor, err error) {
+func (c *monitors) Create(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.CreateOptions) (result *v1alpha1.Monitor, err error) {
 	result = &v1alpha1.Monitor{}
 	err = c.client.Post().
 		Namespace(c.ns).
 		Resource("monitors").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(monitor).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a monitor and updates it. Returns the server's representation of the monitor, and an error, if there is any.
-func (c *monitors) Update(monitor *v1alpha1.Monitor) (result *v1alpha1.Monitor, err error) {
+func (c *monitors) Update(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (result *v1alpha1.Monitor, err error) {
+	result = &v1alpha1.Monitor{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("monitors").
+		Name(monitor.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(monitor).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *monitors) UpdateStatus(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (result *v1alpha1.Monitor, err error) {
 	result = &v1alpha1.Monitor{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("monitors").
 		Name(monitor.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(monitor).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the monitor and deletes it. Returns an error if one occurs.
-func (c *monitors) Delete(name string, options *v1.DeleteOptions) error {
+func (c *monitors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("monitors").
 		Name(name).
-		Body(options).
-		Do().
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *monitors) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+func (c *monitors) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("monitors").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched monitor.
-func (c *monitors) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Monitor, err error) {
+func (c *monitors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Monitor, err error) {
 	result = &v1alpha1.Monitor{}
 	err = c.client.Patch(pt).
 		Namespace(c.ns).
 		Resource("monitors").
-		SubResource(subresources...).
 		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(data).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
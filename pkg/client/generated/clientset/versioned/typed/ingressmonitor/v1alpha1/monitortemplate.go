@@ -25,6 +25,9 @@
 package v1alpha1
 
 import (
+	"context"
+	"time"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,14 +44,14 @@ type MonitorTemplatesGetter interface {
 
 // MonitorTemplateInterface has methods to work with MonitorTemplate resources.
 type MonitorTemplateInterface interface {
-	Create(*v1alpha1.MonitorTemplate) (*v1alpha1.MonitorTemplate, error)
-	Update(*v1alpha1.MonitorTemplate) (*v1alpha1.MonitorTemplate, error)
-	Delete(name string, options *v1.DeleteOptions) error
-	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
-	Get(name string, options v1.GetOptions) (*v1alpha1.MonitorTemplate, error)
-	List(opts v1.ListOptions) (*v1alpha1.MonitorTemplateList, error)
-	Watch(opts v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.MonitorTemplate, err error)
+	Create(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.CreateOptions) (*v1alpha1.MonitorTemplate, error)
+	Update(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.UpdateOptions) (*v1alpha1.MonitorTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.MonitorTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.MonitorTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MonitorTemplate, err error)
 	MonitorTemplateExpansion
 }
 
@@ -67,97 +70,115 @@ func newMonitorTemplates(c *IngressmonitorV1alpha1Client, namespace string) *mon
 }
 
 // Get takes name of the monitorTemplate, and returns the corresponding monitorTemplate object, and an error if there is any.
-func (c *monitorTemplates) Get(name string, options v1.GetOptions) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *monitorTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	result = &v1alpha1.MonitorTemplate{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("monitortemplates").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of MonitorTemplates that match those selectors.
-func (c *monitorTemplates) List(opts v1.ListOptions) (result *v1alpha1.MonitorTemplateList, err error) {
+func (c *monitorTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MonitorTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	result = &v1alpha1.MonitorTemplateList{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("monitortemplates").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
+		Timeout(timeout).
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested monitorTemplates.
-func (c *monitorTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *monitorTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	opts.Watch = true
 	return c.client.Get().
 		Namespace(c.ns).
 		Resource("monitortemplates").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
+		Timeout(timeout).
+		Watch(ctx)
 }
 
 // Create takes the representation of a monitorTemplate and creates it.  Returns the server's representation of the monitorTemplate, and an error, if there is any.
-func (c *monitorTemplates) Create(monitorTemplate *v1alpha1.MonitorTemplate) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *monitorTemplates) Create(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.CreateOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	result = &v1alpha1.MonitorTemplate{}
 	err = c.client.Post().
 		Namespace(c.ns).
 		Resource("monitortemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(monitorTemplate).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a monitorTemplate and updates it. Returns the server's representation of the monitorTemplate, and an error, if there is any.
-func (c *monitorTemplates) Update(monitorTemplate *v1alpha1.MonitorTemplate) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *monitorTemplates) Update(ctx context.Context, monitorTemplate *v1alpha1.MonitorTemplate, opts v1.UpdateOptions) (result *v1alpha1.MonitorTemplate, err error) {
 	result = &v1alpha1.MonitorTemplate{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("monitortemplates").
 		Name(monitorTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(monitorTemplate).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the monitorTemplate and deletes it. Returns an error if one occurs.
-func (c *monitorTemplates) Delete(name string, options *v1.DeleteOptions) error {
+func (c *monitorTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("monitortemplates").
 		Name(name).
-		Body(options).
-		Do().
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *monitorTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+func (c *monitorTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("monitortemplates").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched monitorTemplate.
-func (c *monitorTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.MonitorTemplate, err error) {
+func (c *monitorTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MonitorTemplate, err error) {
 	result = &v1alpha1.MonitorTemplate{}
 	err = c.client.Patch(pt).
 		Namespace(c.ns).
 		Resource("monitortemplates").
-		SubResource(subresources...).
 		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(data).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
@@ -25,6 +25,9 @@
 package v1alpha1
 
 import (
+	"context"
+	"time"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,14 +44,14 @@ type ProvidersGetter interface {
 
 // ProviderInterface has methods to work with Provider resources.
 type ProviderInterface interface {
-	Create(*v1alpha1.Provider) (*v1alpha1.Provider, error)
-	Update(*v1alpha1.Provider) (*v1alpha1.Provider, error)
-	Delete(name string, options *v1.DeleteOptions) error
-	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
-	Get(name string, options v1.GetOptions) (*v1alpha1.Provider, error)
-	List(opts v1.ListOptions) (*v1alpha1.ProviderList, error)
-	Watch(opts v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Provider, err error)
+	Create(ctx context.Context, provider *v1alpha1.Provider, opts v1.CreateOptions) (*v1alpha1.Provider, error)
+	Update(ctx context.Context, provider *v1alpha1.Provider, opts v1.UpdateOptions) (*v1alpha1.Provider, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Provider, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ProviderList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Provider, err error)
 	ProviderExpansion
 }
 
@@ -67,97 +70,115 @@ func newProviders(c *IngressmonitorV1alpha1Client, namespace string) *providers
 }
 
 // Get takes name of the provider, and returns the corresponding provider object, and an error if there is any.
-func (c *providers) Get(name string, options v1.GetOptions) (result *v1alpha1.Provider, err error) {
+func (c *providers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Provider, err error) {
 	result = &v1alpha1.Provider{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("providers").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of Providers that match those selectors.
-func (c *providers) List(opts v1.ListOptions) (result *v1alpha1.ProviderList, err error) {
+func (c *providers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ProviderList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	result = &v1alpha1.ProviderList{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("providers").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
+		Timeout(timeout).
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested providers.
-func (c *providers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *providers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	opts.Watch = true
 	return c.client.Get().
 		Namespace(c.ns).
 		Resource("providers").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
+		Timeout(timeout).
+		Watch(ctx)
 }
 
 // Create takes the representation of a provider and creates it.  Returns the server's representation of the provider, and an error, if there is any.
-func (c *providers) Create(provider *v1alpha1.Provider) (result *v1alpha1.Provider, err error) {
+func (c *providers) Create(ctx context.Context, provider *v1alpha1.Provider, opts v1.CreateOptions) (result *v1alpha1.Provider, err error) {
 	result = &v1alpha1.Provider{}
 	err = c.client.Post().
 		Namespace(c.ns).
 		Resource("providers").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(provider).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a provider and updates it. Returns the server's representation of the provider, and an error, if there is any.
-func (c *providers) Update(provider *v1alpha1.Provider) (result *v1alpha1.Provider, err error) {
+func (c *providers) Update(ctx context.Context, provider *v1alpha1.Provider, opts v1.UpdateOptions) (result *v1alpha1.Provider, err error) {
 	result = &v1alpha1.Provider{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("providers").
 		Name(provider.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(provider).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the provider and deletes it. Returns an error if one occurs.
-func (c *providers) Delete(name string, options *v1.DeleteOptions) error {
+func (c *providers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("providers").
 		Name(name).
-		Body(options).
-		Do().
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *providers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+func (c *providers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("providers").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched provider.
-func (c *providers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Provider, err error) {
+func (c *providers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Provider, err error) {
 	result = &v1alpha1.Provider{}
 	err = c.client.Patch(pt).
 		Namespace(c.ns).
 		Resource("providers").
-		SubResource(subresources...).
 		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(data).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
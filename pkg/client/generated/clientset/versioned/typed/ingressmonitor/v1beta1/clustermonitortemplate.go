@@ -0,0 +1,174 @@
+// MIT License
+//
+// Copyright (c) 2018 Jelmer Snoeck
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
+	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterMonitorTemplatesGetter has a method to return a ClusterMonitorTemplateInterface.
+// A group's client should implement this interface.
+type ClusterMonitorTemplatesGetter interface {
+	ClusterMonitorTemplates() ClusterMonitorTemplateInterface
+}
+
+// ClusterMonitorTemplateInterface has methods to work with ClusterMonitorTemplate resources.
+type ClusterMonitorTemplateInterface interface {
+	Create(ctx context.Context, clusterMonitorTemplate *v1beta1.ClusterMonitorTemplate, opts v1.CreateOptions) (*v1beta1.ClusterMonitorTemplate, error)
+	Update(ctx context.Context, clusterMonitorTemplate *v1beta1.ClusterMonitorTemplate, opts v1.UpdateOptions) (*v1beta1.ClusterMonitorTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ClusterMonitorTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.ClusterMonitorTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterMonitorTemplate, err error)
+	ClusterMonitorTemplateExpansion
+}
+
+// clusterMonitorTemplates implements ClusterMonitorTemplateInterface
+type clusterMonitorTemplates struct {
+	client rest.Interface
+}
+
+// newClusterMonitorTemplates returns a ClusterMonitorTemplates
+func newClusterMonitorTemplates(c *IngressmonitorV1beta1Client) *clusterMonitorTemplates {
+	return &clusterMonitorTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterMonitorTemplate, and returns the corresponding clusterMonitorTemplate object, and an error if there is any.
+func (c *clusterMonitorTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ClusterMonitorTemplate, err error) {
+	result = &v1beta1.ClusterMonitorTemplate{}
+	err = c.client.Get().
+		Resource("clustermonitortemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterMonitorTemplates that match those selectors.
+func (c *clusterMonitorTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ClusterMonitorTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.ClusterMonitorTemplateList{}
+	err = c.client.Get().
+		Resource("clustermonitortemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterMonitorTemplates.
+func (c *clusterMonitorTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustermonitortemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterMonitorTemplate and creates it.  Returns the server's representation of the clusterMonitorTemplate, and an error, if there is any.
+func (c *clusterMonitorTemplates) Create(ctx context.Context, clusterMonitorTemplate *v1beta1.ClusterMonitorTemplate, opts v1.CreateOptions) (result *v1beta1.ClusterMonitorTemplate, err error) {
+	result = &v1beta1.ClusterMonitorTemplate{}
+	err = c.client.Post().
+		Resource("clustermonitortemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterMonitorTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterMonitorTemplate and updates it. Returns the server's representation of the clusterMonitorTemplate, and an error, if there is any.
+func (c *clusterMonitorTemplates) Update(ctx context.Context, clusterMonitorTemplate *v1beta1.ClusterMonitorTemplate, opts v1.UpdateOptions) (result *v1beta1.ClusterMonitorTemplate, err error) {
+	result = &v1beta1.ClusterMonitorTemplate{}
+	err = c.client.Put().
+		Resource("clustermonitortemplates").
+		Name(clusterMonitorTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterMonitorTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterMonitorTemplate and deletes it. Returns an error if one occurs.
+func (c *clusterMonitorTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustermonitortemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterMonitorTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clustermonitortemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterMonitorTemplate.
+func (c *clusterMonitorTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterMonitorTemplate, err error) {
+	result = &v1beta1.ClusterMonitorTemplate{}
+	err = c.client.Patch(pt).
+		Resource("clustermonitortemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
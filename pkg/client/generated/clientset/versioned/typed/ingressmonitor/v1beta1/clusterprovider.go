@@ -0,0 +1,174 @@
+// MIT License
+//
+// Copyright (c) 2018 Jelmer Snoeck
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
+	scheme "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterProvidersGetter has a method to return a ClusterProviderInterface.
+// A group's client should implement this interface.
+type ClusterProvidersGetter interface {
+	ClusterProviders() ClusterProviderInterface
+}
+
+// ClusterProviderInterface has methods to work with ClusterProvider resources.
+type ClusterProviderInterface interface {
+	Create(ctx context.Context, clusterProvider *v1beta1.ClusterProvider, opts v1.CreateOptions) (*v1beta1.ClusterProvider, error)
+	Update(ctx context.Context, clusterProvider *v1beta1.ClusterProvider, opts v1.UpdateOptions) (*v1beta1.ClusterProvider, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ClusterProvider, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.ClusterProviderList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterProvider, err error)
+	ClusterProviderExpansion
+}
+
+// clusterProviders implements ClusterProviderInterface
+type clusterProviders struct {
+	client rest.Interface
+}
+
+// newClusterProviders returns a ClusterProviders
+func newClusterProviders(c *IngressmonitorV1beta1Client) *clusterProviders {
+	return &clusterProviders{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterProvider, and returns the corresponding clusterProvider object, and an error if there is any.
+func (c *clusterProviders) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ClusterProvider, err error) {
+	result = &v1beta1.ClusterProvider{}
+	err = c.client.Get().
+		Resource("clusterproviders").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterProviders that match those selectors.
+func (c *clusterProviders) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ClusterProviderList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.ClusterProviderList{}
+	err = c.client.Get().
+		Resource("clusterproviders").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterProviders.
+func (c *clusterProviders) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterproviders").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterProvider and creates it.  Returns the server's representation of the clusterProvider, and an error, if there is any.
+func (c *clusterProviders) Create(ctx context.Context, clusterProvider *v1beta1.ClusterProvider, opts v1.CreateOptions) (result *v1beta1.ClusterProvider, err error) {
+	result = &v1beta1.ClusterProvider{}
+	err = c.client.Post().
+		Resource("clusterproviders").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterProvider).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterProvider and updates it. Returns the server's representation of the clusterProvider, and an error, if there is any.
+func (c *clusterProviders) Update(ctx context.Context, clusterProvider *v1beta1.ClusterProvider, opts v1.UpdateOptions) (result *v1beta1.ClusterProvider, err error) {
+	result = &v1beta1.ClusterProvider{}
+	err = c.client.Put().
+		Resource("clusterproviders").
+		Name(clusterProvider.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterProvider).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterProvider and deletes it. Returns an error if one occurs.
+func (c *clusterProviders) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterproviders").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterProviders) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterproviders").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterProvider.
+func (c *clusterProviders) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterProvider, err error) {
+	result = &v1beta1.ClusterProvider{}
+	err = c.client.Patch(pt).
+		Resource("clusterproviders").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
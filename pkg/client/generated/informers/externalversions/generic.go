@@ -28,6 +28,7 @@ import (
 	"fmt"
 
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	v1beta1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -59,6 +60,10 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=ingressmonitor.sphc.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clustermonitortemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1alpha1().ClusterMonitorTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterproviders"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1alpha1().ClusterProviders().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ingressmonitors"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1alpha1().IngressMonitors().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("monitors"):
@@ -68,6 +73,20 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha1.SchemeGroupVersion.WithResource("providers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1alpha1().Providers().Informer()}, nil
 
+		// Group=ingressmonitor.sphc.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("clustermonitortemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().ClusterMonitorTemplates().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterproviders"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().ClusterProviders().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("ingressmonitors"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().IngressMonitors().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("monitors"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().Monitors().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("monitortemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().MonitorTemplates().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("providers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingressmonitor().V1beta1().Providers().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
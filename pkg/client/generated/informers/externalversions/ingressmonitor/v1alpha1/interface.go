@@ -30,6 +30,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterMonitorTemplates returns a ClusterMonitorTemplateInformer.
+	ClusterMonitorTemplates() ClusterMonitorTemplateInformer
+	// ClusterProviders returns a ClusterProviderInformer.
+	ClusterProviders() ClusterProviderInformer
 	// IngressMonitors returns a IngressMonitorInformer.
 	IngressMonitors() IngressMonitorInformer
 	// Monitors returns a MonitorInformer.
@@ -51,6 +55,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterMonitorTemplates returns a ClusterMonitorTemplateInformer.
+func (v *version) ClusterMonitorTemplates() ClusterMonitorTemplateInformer {
+	return &clusterMonitorTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterProviders returns a ClusterProviderInformer.
+func (v *version) ClusterProviders() ClusterProviderInformer {
+	return &clusterProviderInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IngressMonitors returns a IngressMonitorInformer.
 func (v *version) IngressMonitors() IngressMonitorInformer {
 	return &ingressMonitorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -24,6 +24,14 @@
 
 package v1alpha1
 
+// ClusterMonitorTemplateListerExpansion allows custom methods to be added to
+// ClusterMonitorTemplateLister.
+type ClusterMonitorTemplateListerExpansion interface{}
+
+// ClusterProviderListerExpansion allows custom methods to be added to
+// ClusterProviderLister.
+type ClusterProviderListerExpansion interface{}
+
 // IngressMonitorListerExpansion allows custom methods to be added to
 // IngressMonitorLister.
 type IngressMonitorListerExpansion interface{}
@@ -32,8 +32,10 @@ import (
 )
 
 // IngressMonitorLister helps list IngressMonitors.
+// All objects returned here must be treated as read-only.
 type IngressMonitorLister interface {
 	// List lists all IngressMonitors in the indexer.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.IngressMonitor, err error)
 	// IngressMonitors returns an object that can list and get IngressMonitors.
 	IngressMonitors(namespace string) IngressMonitorNamespaceLister
@@ -64,10 +66,13 @@ func (s *ingressMonitorLister) IngressMonitors(namespace string) IngressMonitorN
 }
 
 // IngressMonitorNamespaceLister helps list and get IngressMonitors.
+// All objects returned here must be treated as read-only.
 type IngressMonitorNamespaceLister interface {
 	// List lists all IngressMonitors in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.IngressMonitor, err error)
 	// Get retrieves the IngressMonitor from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
 	Get(name string) (*v1alpha1.IngressMonitor, error)
 	IngressMonitorNamespaceListerExpansion
 }
@@ -32,8 +32,10 @@ import (
 )
 
 // MonitorLister helps list Monitors.
+// All objects returned here must be treated as read-only.
 type MonitorLister interface {
 	// List lists all Monitors in the indexer.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.Monitor, err error)
 	// Monitors returns an object that can list and get Monitors.
 	Monitors(namespace string) MonitorNamespaceLister
@@ -64,10 +66,13 @@ func (s *monitorLister) Monitors(namespace string) MonitorNamespaceLister {
 }
 
 // MonitorNamespaceLister helps list and get Monitors.
+// All objects returned here must be treated as read-only.
 type MonitorNamespaceLister interface {
 	// List lists all Monitors in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.Monitor, err error)
 	// Get retrieves the Monitor from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
 	Get(name string) (*v1alpha1.Monitor, error)
 	MonitorNamespaceListerExpansion
 }
@@ -32,8 +32,10 @@ import (
 )
 
 // MonitorTemplateLister helps list MonitorTemplates.
+// All objects returned here must be treated as read-only.
 type MonitorTemplateLister interface {
 	// List lists all MonitorTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.MonitorTemplate, err error)
 	// MonitorTemplates returns an object that can list and get MonitorTemplates.
 	MonitorTemplates(namespace string) MonitorTemplateNamespaceLister
@@ -64,10 +66,13 @@ func (s *monitorTemplateLister) MonitorTemplates(namespace string) MonitorTempla
 }
 
 // MonitorTemplateNamespaceLister helps list and get MonitorTemplates.
+// All objects returned here must be treated as read-only.
 type MonitorTemplateNamespaceLister interface {
 	// List lists all MonitorTemplates in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.MonitorTemplate, err error)
 	// Get retrieves the MonitorTemplate from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
 	Get(name string) (*v1alpha1.MonitorTemplate, error)
 	MonitorTemplateNamespaceListerExpansion
 }
@@ -32,8 +32,10 @@ import (
 )
 
 // ProviderLister helps list Providers.
+// All objects returned here must be treated as read-only.
 type ProviderLister interface {
 	// List lists all Providers in the indexer.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.Provider, err error)
 	// Providers returns an object that can list and get Providers.
 	Providers(namespace string) ProviderNamespaceLister
@@ -64,10 +66,13 @@ func (s *providerLister) Providers(namespace string) ProviderNamespaceLister {
 }
 
 // ProviderNamespaceLister helps list and get Providers.
+// All objects returned here must be treated as read-only.
 type ProviderNamespaceLister interface {
 	// List lists all Providers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*v1alpha1.Provider, err error)
 	// Get retrieves the Provider from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
 	Get(name string) (*v1alpha1.Provider, error)
 	ProviderNamespaceListerExpansion
 }